@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/josephburgess/gust/internal/cli"
+	"github.com/josephburgess/gust/internal/redact"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
@@ -18,6 +20,14 @@ func main() {
 	}
 
 	if err := cli.Run(ctx, cliInstance); err != nil {
-		styles.ExitWithError(fmt.Sprintf("Command failed: %s", ctx.Command()), err)
+		code := cli.ExitCode(err)
+		// matches are already printed, and ctrl+c needs no explanation
+		if code != cli.ExitRulesMatched && code != cli.ExitCancelled {
+			log.Printf("Command failed: %s: %v", ctx.Command(), redact.Error(err))
+			if hint := cli.ErrorHint(err); hint != "" {
+				fmt.Fprintln(os.Stderr, hint)
+			}
+		}
+		os.Exit(code)
 	}
 }
@@ -1,15 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/redact"
 )
 
 type WeatherResponse struct {
@@ -24,112 +30,199 @@ type RateLimitInfo struct {
 }
 
 type Client struct {
-	baseURL       string
-	apiKey        string
-	units         string
-	client        *http.Client
-	RateLimitInfo *RateLimitInfo
+	baseURL string
+	apiKey  string
+	client  *http.Client
+	// passed on to breeze so condition descriptions come back translated, english when empty
+	Lang string
+
+	// requests can run in parallel, e.g. for --all
+	mu        sync.Mutex
+	rateLimit RateLimitInfo
+	// older breeze servers only read the key from the query string, set once one has said so
+	keyInQuery bool
 }
 
-func NewClient(baseURL, apiKey string, units string) *Client {
+func NewClient(baseURL, apiKey string) *Client {
+	redact.Secret(apiKey)
 	return &Client{
-		baseURL:       baseURL,
-		apiKey:        apiKey,
-		units:         units,
-		client:        &http.Client{},
-		RateLimitInfo: &RateLimitInfo{},
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: DefaultTimeout},
 	}
 }
 
+// the limits breeze reported with the last response
+func (c *Client) RateLimit() RateLimitInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
 func (c *Client) extractRateLimitInfo(resp *http.Response) {
-	if c.RateLimitInfo == nil {
-		c.RateLimitInfo = &RateLimitInfo{}
-	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	if limit := resp.Header.Get("X-RateLimit-Limit"); limit != "" {
 		if val, err := strconv.Atoi(limit); err == nil {
-			c.RateLimitInfo.Limit = val
+			c.rateLimit.Limit = val
 		}
 	}
 
 	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
 		if val, err := strconv.Atoi(remaining); err == nil {
-			c.RateLimitInfo.Remaining = val
+			c.rateLimit.Remaining = val
 		} else {
-			c.RateLimitInfo.Remaining = 0
+			c.rateLimit.Remaining = 0
 		}
 	}
 
 	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
 		resetTime, err := time.Parse(time.RFC3339, reset)
 		if err == nil {
-			c.RateLimitInfo.ResetTime = resetTime
+			c.rateLimit.ResetTime = resetTime
 		} else {
-			c.RateLimitInfo.ResetTime = time.Now().Add(time.Hour)
+			c.rateLimit.ResetTime = time.Now().Add(time.Hour)
 		}
 	}
 }
 
 func (c *Client) GetWeather(cityName string) (*WeatherResponse, error) {
-	endpoint := fmt.Sprintf(
-		"%s/api/weather/%s?api_key=%s",
-		c.baseURL,
-		url.QueryEscape(cityName),
-		c.apiKey,
-	)
+	return c.GetWeatherContext(context.Background(), cityName)
+}
+
+func (c *Client) GetWeatherContext(ctx context.Context, cityName string) (*WeatherResponse, error) {
+	endpoint := fmt.Sprintf("%s/api/weather/%s", c.baseURL, url.QueryEscape(cityName))
+
+	return c.getWeather(ctx, endpoint, url.Values{}, cityName)
+}
+
+func (c *Client) GetWeatherByCoords(coords models.Coordinates) (*WeatherResponse, error) {
+	return c.GetWeatherByCoordsContext(context.Background(), coords)
+}
 
-	if c.units != "" {
-		endpoint = fmt.Sprintf("%s&units=%s", endpoint, c.units)
+func (c *Client) GetWeatherByCoordsContext(ctx context.Context, coords models.Coordinates) (*WeatherResponse, error) {
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%f", coords.Lat))
+	params.Set("lon", fmt.Sprintf("%f", coords.Lon))
+
+	return c.getWeather(ctx, c.baseURL+"/api/weather", params, "")
+}
+
+// city is the name searched for, empty for coordinates
+func (c *Client) getWeather(ctx context.Context, endpoint string, params url.Values, city string) (*WeatherResponse, error) {
+	params.Set("units", CanonicalUnits)
+	if c.Lang != "" {
+		params.Set("lang", c.Lang)
 	}
 
-	resp, err := c.client.Get(endpoint)
+	resp, err := c.get(ctx, endpoint, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to API: %w", err)
+		return nil, &UnavailableError{Provider: ProviderBreeze, Err: err}
 	}
 	defer resp.Body.Close()
 
 	c.extractRateLimitInfo(resp)
 
-	if resp.StatusCode == http.StatusTooManyRequests {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("rate limit exceeded: %s", string(body))
+	if resp.StatusCode == http.StatusNotFound {
+		if city == "" {
+			return nil, ErrCoordinatesUnsupported
+		}
+		return nil, &CityNotFoundError{City: city}
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+		err := responseError(ProviderBreeze, resp)
+		var rateLimited *RateLimitError
+		if reset := c.RateLimit().ResetTime; errors.As(err, &rateLimited) && !reset.IsZero() {
+			rateLimited.ResetTime = reset
+		}
+		return nil, err
 	}
 
 	var response WeatherResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode API response: %w", err)
+		return nil, &DecodeError{Err: err}
 	}
 
 	return &response, nil
 }
 
 func (c *Client) SearchCities(query string) ([]models.City, error) {
-	endpoint := fmt.Sprintf(
-		"%s/api/cities/search?q=%s",
-		c.baseURL,
-		url.QueryEscape(query),
-	)
+	return c.SearchCitiesContext(context.Background(), query)
+}
 
-	resp, err := c.client.Get(endpoint)
+func (c *Client) SearchCitiesContext(ctx context.Context, query string) ([]models.City, error) {
+	params := url.Values{}
+	params.Set("q", query)
+
+	resp, err := c.get(ctx, c.baseURL+"/api/cities/search", params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to API: %w", err)
+		return nil, &UnavailableError{Provider: ProviderBreeze, Err: err}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+		return nil, responseError(ProviderBreeze, resp)
 	}
 
 	var cities []models.City
 	if err := json.NewDecoder(resp.Body).Decode(&cities); err != nil {
-		return nil, fmt.Errorf("failed to decode API response: %w", err)
+		return nil, &DecodeError{Err: err}
 	}
 
 	return cities, nil
 }
+
+// sends the key in the Authorization header. Only a 401 that says the api_key parameter is missing
+// comes from a server that predates header auth, and the request is repeated with the key in the
+// query string. Any other 401 is a bad key, which shouldn't be sent again in the url
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	if c.apiKey == "" {
+		return getContext(ctx, c.client, endpoint+"?"+params.Encode(), nil)
+	}
+
+	c.mu.Lock()
+	keyInQuery := c.keyInQuery
+	c.mu.Unlock()
+
+	if !keyInQuery {
+		header := http.Header{"Authorization": {"Bearer " + c.apiKey}}
+		resp, err := getContext(ctx, c.client, endpoint+"?"+params.Encode(), header)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || !wantsQueryKey(resp) {
+			return resp, err
+		}
+		resp.Body.Close()
+
+		c.mu.Lock()
+		c.keyInQuery = true
+		c.mu.Unlock()
+	}
+
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("api_key", c.apiKey)
+	return getContext(ctx, c.client, endpoint+"?"+query.Encode(), nil)
+}
+
+// how much of a 401 is read looking for the old servers' complaint
+const maxUnauthorizedBody = 4 << 10
+
+// older servers answer a request without the query parameter with e.g. "missing api_key".
+// The body is put back so the caller can still read the error
+func wantsQueryKey(resp *http.Response) bool {
+	for _, challenge := range resp.Header.Values("WWW-Authenticate") {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(challenge)), "bearer") {
+			return false
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxUnauthorizedBody))
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	message := strings.ToLower(string(body))
+	return strings.Contains(message, "api_key") && (strings.Contains(message, "missing") || strings.Contains(message, "required"))
+}
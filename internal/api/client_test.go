@@ -1,17 +1,22 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/models"
 )
 
 func TestNewClient(t *testing.T) {
 	baseURL := "https://example.com"
 	apiKey := "test-api-key"
-	units := "metric"
 
-	client := NewClient(baseURL, apiKey, units)
+	client := NewClient(baseURL, apiKey)
 
 	if client.baseURL != baseURL {
 		t.Errorf("Expected baseURL to be %s, got %s", baseURL, client.baseURL)
@@ -24,6 +29,10 @@ func TestNewClient(t *testing.T) {
 	if client.client == nil {
 		t.Error("HTTP client should not be nil")
 	}
+
+	if client.client.Timeout != DefaultTimeout {
+		t.Errorf("Expected the default timeout, got %v", client.client.Timeout)
+	}
 }
 
 func TestGetWeather(t *testing.T) {
@@ -32,8 +41,12 @@ func TestGetWeather(t *testing.T) {
 			t.Errorf("Expected path /api/weather/London, got %s", r.URL.Path)
 		}
 
-		if apiKey := r.URL.Query().Get("api_key"); apiKey != "test-api-key" {
-			t.Errorf("Expected api_key=test-api-key, got %s", apiKey)
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-key" {
+			t.Errorf("Expected the key in the Authorization header, got %q", auth)
+		}
+
+		if r.URL.Query().Has("api_key") {
+			t.Errorf("The key shouldn't be in the url, got %s", r.URL.RawQuery)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -59,7 +72,7 @@ func TestGetWeather(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-api-key", "metric")
+	client := NewClient(server.URL, "test-api-key")
 
 	resp, err := client.GetWeather("London")
 	if err != nil {
@@ -83,6 +96,50 @@ func TestGetWeather(t *testing.T) {
 	}
 }
 
+func TestGetWeatherByCoords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/weather" {
+			t.Errorf("Expected path /api/weather, got %s", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("lat") != "51.500000" || query.Get("lon") != "-0.120000" {
+			t.Errorf("Expected lat=51.500000&lon=-0.120000, got %s", r.URL.RawQuery)
+		}
+
+		if units := query.Get("units"); units != "metric" {
+			t.Errorf("Expected units=metric, got %s", units)
+		}
+
+		w.Write([]byte(`{"city": {"name": "London", "lat": 51.5, "lon": -0.12}, "weather": {"current": {"temp": 11}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+
+	resp, err := client.GetWeatherByCoords(models.Coordinates{Lat: 51.5, Lon: -0.12})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.City.Name != "London" || resp.Weather.Current.Temp != 11 {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+}
+
+func TestGetWeatherByCoordsUnsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	_, err := client.GetWeatherByCoords(models.Coordinates{Lat: 51.5, Lon: -0.12})
+	if !errors.Is(err, ErrCoordinatesUnsupported) {
+		t.Errorf("Expected ErrCoordinatesUnsupported, got %v", err)
+	}
+}
+
 func TestGetWeatherError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -90,7 +147,7 @@ func TestGetWeatherError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-api-key", "metric")
+	client := NewClient(server.URL, "test-api-key")
 
 	resp, err := client.GetWeather("NonExistentCity")
 
@@ -101,4 +158,191 @@ func TestGetWeatherError(t *testing.T) {
 	if resp != nil {
 		t.Errorf("Expected nil response, got %+v", resp)
 	}
+
+	var notFound *CityNotFoundError
+	if !errors.As(err, &notFound) || notFound.City != "NonExistentCity" {
+		t.Errorf("Expected a CityNotFoundError, got %v", err)
+	}
+}
+
+func TestGetWeatherTypedErrors(t *testing.T) {
+	useFastRetries(t)
+
+	reset := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		status int
+		body   string
+		check  func(err error) bool
+	}{
+		{"rate limited", http.StatusTooManyRequests, "slow down", func(err error) bool {
+			var target *RateLimitError
+			return errors.As(err, &target) && target.ResetTime.Equal(reset)
+		}},
+		{"bad key", http.StatusUnauthorized, "invalid api key", func(err error) bool {
+			return errors.As(err, new(*UnauthorizedError))
+		}},
+		{"server down", http.StatusBadGateway, "", func(err error) bool {
+			var target *UnavailableError
+			return errors.As(err, &target) && target.StatusCode == http.StatusBadGateway
+		}},
+		{"garbage", http.StatusOK, "<html>", func(err error) bool {
+			return errors.As(err, new(*DecodeError))
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Reset", reset.Format(time.RFC3339))
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL, "test-api-key").GetWeather("London")
+			if !tc.check(err) {
+				t.Errorf("Unexpected error %v (%T)", err, err)
+			}
+		})
+	}
+}
+
+func TestGetWeatherUnreachable(t *testing.T) {
+	useFastRetries(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	_, err := NewClient(server.URL, "test-api-key").GetWeather("London")
+
+	var unavailable *UnavailableError
+	if !errors.As(err, &unavailable) || unavailable.StatusCode != 0 {
+		t.Errorf("Expected an UnavailableError without a status, got %v", err)
+	}
+}
+
+// a server that never answers, like a hung proxy
+func newHangingServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetWeatherTimeout(t *testing.T) {
+	server := newHangingServer(t)
+
+	provider, err := NewProvider(ProviderBreeze, server.URL, "test-api-key", "", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = provider.GetWeather("London")
+
+	var unavailable *UnavailableError
+	var netErr net.Error
+	if !errors.As(err, &unavailable) || !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Expected an UnavailableError from the timeout, got %v", err)
+	}
+}
+
+func TestGetWeatherContextCancelled(t *testing.T) {
+	server := newHangingServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := NewClient(server.URL, "test-api-key").GetWeatherContext(ctx, "London")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the request to be cancelled, got %v", err)
+	}
+}
+
+func TestGetWeatherQueryKeyFallback(t *testing.T) {
+	var headerRequests, queryRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// an older server that only knows the query parameter
+		if r.URL.Query().Get("api_key") != "test-api-key" {
+			headerRequests++
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "missing api_key"}`))
+			return
+		}
+		queryRequests++
+		w.Write([]byte(`{"city": {"name": "London"}, "weather": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetWeather("London"); err != nil {
+			t.Fatalf("Expected the fallback to succeed, got %v", err)
+		}
+	}
+
+	if headerRequests != 1 || queryRequests != 2 {
+		t.Errorf("Expected the header to be tried once, got %d header and %d query requests", headerRequests, queryRequests)
+	}
+}
+
+func TestGetWeatherBearerChallenge(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, "wrong-api-key").GetWeather("London")
+
+	var unauthorized *UnauthorizedError
+	if !errors.As(err, &unauthorized) {
+		t.Errorf("Expected an UnauthorizedError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("A server that accepts the header shouldn't be sent the key in the url, got %d requests", calls)
+	}
+}
+
+func TestGetWeatherUnauthorizedKeepsKeyOutOfURL(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Has("api_key") {
+			t.Error("Expected the key to stay out of the url")
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid api key"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, "wrong-api-key").GetWeather("London")
+
+	var unauthorized *UnauthorizedError
+	if !errors.As(err, &unauthorized) {
+		t.Errorf("Expected an UnauthorizedError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single request, got %d", calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if !retryAfter(resp).IsZero() {
+		t.Error("Expected no reset time without the header")
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if until := time.Until(retryAfter(resp)); until < 110*time.Second || until > 120*time.Second {
+		t.Errorf("Expected about 2 minutes, got %s", until)
+	}
+
+	resp.Header.Set("Retry-After", "Wed, 21 Oct 2037 07:28:00 GMT")
+	if got := retryAfter(resp); got.Year() != 2037 {
+		t.Errorf("Expected the http date, got %s", got)
+	}
 }
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// breeze only looks weather up by name unless the server has the coordinates route,
+// callers fall back to a name when they have one
+var ErrCoordinatesUnsupported = errors.New("this weather server can't look up weather by coordinates")
+
+// the provider has run out of requests for now
+type RateLimitError struct {
+	// when requests are allowed again, zero if the provider didn't say
+	ResetTime time.Time
+	Message   string
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Message == "" {
+		return "rate limit exceeded"
+	}
+	return "rate limit exceeded: " + e.Message
+}
+
+// the api key is missing, wrong or doesn't cover the request
+type UnauthorizedError struct {
+	Provider string
+	Message  string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return fmt.Sprintf("%s rejected the api key: %s", e.Provider, e.Message)
+}
+
+type CityNotFoundError struct {
+	City string
+}
+
+func (e *CityNotFoundError) Error() string {
+	return fmt.Sprintf("city %q not found", e.City)
+}
+
+// the provider couldn't be reached or had a server error, StatusCode is 0 when there was no response
+type UnavailableError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *UnavailableError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("failed to connect to %s: %v", e.Provider, e.Err)
+	}
+	return fmt.Sprintf("%s is unavailable (%d): %s", e.Provider, e.StatusCode, e.Message)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// the response wasn't what the provider documents
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode API response: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// the typed error for a response that isn't a 200, reading the body for the message
+func responseError(provider string, resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	message := strings.TrimSpace(string(body))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitError{ResetTime: retryAfter(resp), Message: message}
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return &UnauthorizedError{Provider: provider, Message: message}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return &UnavailableError{Provider: provider, StatusCode: resp.StatusCode, Message: message}
+	default:
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, message)
+	}
+}
+
+// Retry-After as a time, either form the header allows, zero when it's missing
+func retryAfter(resp *http.Response) time.Time {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return time.Time{}
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at
+	}
+	return time.Time{}
+}
@@ -0,0 +1,435 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+)
+
+const (
+	openMeteoForecastURL  = "https://api.open-meteo.com"
+	openMeteoGeocodingURL = "https://geocoding-api.open-meteo.com"
+
+	openMeteoCurrentFields = "temperature_2m,relative_humidity_2m,apparent_temperature,precipitation,rain,snowfall," +
+		"weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,wind_gusts_10m,visibility,uv_index,dew_point_2m"
+	openMeteoHourlyFields = "temperature_2m,relative_humidity_2m,apparent_temperature,precipitation_probability,rain,snowfall," +
+		"weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,wind_gusts_10m,visibility,uv_index,dew_point_2m"
+	openMeteoDailyFields = "weather_code,temperature_2m_max,temperature_2m_min,apparent_temperature_max,sunrise,sunset," +
+		"uv_index_max,precipitation_probability_max,rain_sum,snowfall_sum,wind_speed_10m_max,wind_gusts_10m_max,wind_direction_10m_dominant"
+)
+
+// free, keyless backend - no alerts and no minutely data
+type OpenMeteoProvider struct {
+	forecastURL  string
+	geocodingURL string
+	client       *http.Client
+	// open-meteo only reports codes, their descriptions come from gust's own catalog in this language
+	Lang string
+}
+
+func NewOpenMeteoProvider() *OpenMeteoProvider {
+	return &OpenMeteoProvider{
+		forecastURL:  openMeteoForecastURL,
+		geocodingURL: openMeteoGeocodingURL,
+		client:       &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+type openMeteoGeocodingResponse struct {
+	Results []struct {
+		Name        string  `json:"name"`
+		Latitude    float64 `json:"latitude"`
+		Longitude   float64 `json:"longitude"`
+		CountryCode string  `json:"country_code"`
+		Admin1      string  `json:"admin1"`
+	} `json:"results"`
+}
+
+type openMeteoCurrent struct {
+	Time                int64   `json:"time"`
+	Temperature         float64 `json:"temperature_2m"`
+	RelativeHumidity    float64 `json:"relative_humidity_2m"`
+	ApparentTemperature float64 `json:"apparent_temperature"`
+	Rain                float64 `json:"rain"`
+	Snowfall            float64 `json:"snowfall"`
+	WeatherCode         int     `json:"weather_code"`
+	CloudCover          float64 `json:"cloud_cover"`
+	PressureMSL         float64 `json:"pressure_msl"`
+	WindSpeed           float64 `json:"wind_speed_10m"`
+	WindDirection       float64 `json:"wind_direction_10m"`
+	WindGusts           float64 `json:"wind_gusts_10m"`
+	Visibility          float64 `json:"visibility"`
+	UVIndex             float64 `json:"uv_index"`
+	DewPoint            float64 `json:"dew_point_2m"`
+}
+
+type openMeteoHourly struct {
+	Time                     []int64   `json:"time"`
+	Temperature              []float64 `json:"temperature_2m"`
+	RelativeHumidity         []float64 `json:"relative_humidity_2m"`
+	ApparentTemperature      []float64 `json:"apparent_temperature"`
+	PrecipitationProbability []float64 `json:"precipitation_probability"`
+	Rain                     []float64 `json:"rain"`
+	Snowfall                 []float64 `json:"snowfall"`
+	WeatherCode              []int     `json:"weather_code"`
+	CloudCover               []float64 `json:"cloud_cover"`
+	PressureMSL              []float64 `json:"pressure_msl"`
+	WindSpeed                []float64 `json:"wind_speed_10m"`
+	WindDirection            []float64 `json:"wind_direction_10m"`
+	WindGusts                []float64 `json:"wind_gusts_10m"`
+	Visibility               []float64 `json:"visibility"`
+	UVIndex                  []float64 `json:"uv_index"`
+	DewPoint                 []float64 `json:"dew_point_2m"`
+}
+
+type openMeteoDaily struct {
+	Time                        []int64   `json:"time"`
+	WeatherCode                 []int     `json:"weather_code"`
+	TemperatureMax              []float64 `json:"temperature_2m_max"`
+	TemperatureMin              []float64 `json:"temperature_2m_min"`
+	ApparentTemperatureMax      []float64 `json:"apparent_temperature_max"`
+	Sunrise                     []int64   `json:"sunrise"`
+	Sunset                      []int64   `json:"sunset"`
+	UVIndexMax                  []float64 `json:"uv_index_max"`
+	PrecipitationProbabilityMax []float64 `json:"precipitation_probability_max"`
+	RainSum                     []float64 `json:"rain_sum"`
+	SnowfallSum                 []float64 `json:"snowfall_sum"`
+	WindSpeedMax                []float64 `json:"wind_speed_10m_max"`
+	WindGustsMax                []float64 `json:"wind_gusts_10m_max"`
+	WindDirectionDominant       []float64 `json:"wind_direction_10m_dominant"`
+}
+
+type openMeteoForecastResponse struct {
+	Latitude         float64          `json:"latitude"`
+	Longitude        float64          `json:"longitude"`
+	Timezone         string           `json:"timezone"`
+	UTCOffsetSeconds int              `json:"utc_offset_seconds"`
+	Current          openMeteoCurrent `json:"current"`
+	Hourly           openMeteoHourly  `json:"hourly"`
+	Daily            openMeteoDaily   `json:"daily"`
+}
+
+func (p *OpenMeteoProvider) GetWeather(cityName string) (*WeatherResponse, error) {
+	return p.GetWeatherContext(context.Background(), cityName)
+}
+
+func (p *OpenMeteoProvider) GetWeatherContext(ctx context.Context, cityName string) (*WeatherResponse, error) {
+	cities, err := p.geocode(ctx, cityName, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(cities) == 0 {
+		return nil, &CityNotFoundError{City: cityName}
+	}
+
+	return p.forecast(ctx, cities[0])
+}
+
+// open-meteo has no reverse geocoding, so the coordinates double as the name
+func (p *OpenMeteoProvider) GetWeatherByCoords(coords models.Coordinates) (*WeatherResponse, error) {
+	return p.GetWeatherByCoordsContext(context.Background(), coords)
+}
+
+func (p *OpenMeteoProvider) GetWeatherByCoordsContext(ctx context.Context, coords models.Coordinates) (*WeatherResponse, error) {
+	return p.forecast(ctx, models.City{Name: coords.String(), Lat: coords.Lat, Lon: coords.Lon})
+}
+
+func (p *OpenMeteoProvider) forecast(ctx context.Context, city models.City) (*WeatherResponse, error) {
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%f", city.Lat))
+	params.Set("longitude", fmt.Sprintf("%f", city.Lon))
+	params.Set("current", openMeteoCurrentFields)
+	params.Set("hourly", openMeteoHourlyFields)
+	params.Set("daily", openMeteoDailyFields)
+	params.Set("timezone", "auto")
+	params.Set("timeformat", "unixtime")
+	params.Set("forecast_days", "8")
+	// celsius and mm are the defaults, wind has to be asked for in m/s
+	params.Set("wind_speed_unit", "ms")
+
+	var forecast openMeteoForecastResponse
+	if err := p.get(ctx, p.forecastURL+"/v1/forecast", params, &forecast); err != nil {
+		return nil, err
+	}
+
+	return &WeatherResponse{City: &city, Weather: forecast.toOneCall(p.Lang)}, nil
+}
+
+func (p *OpenMeteoProvider) SearchCities(query string) ([]models.City, error) {
+	return p.SearchCitiesContext(context.Background(), query)
+}
+
+func (p *OpenMeteoProvider) SearchCitiesContext(ctx context.Context, query string) ([]models.City, error) {
+	return p.geocode(ctx, query, 5)
+}
+
+func (p *OpenMeteoProvider) geocode(ctx context.Context, query string, count int) ([]models.City, error) {
+	// open-meteo searches by name only, so drop a trailing ",GB" style country code
+	name, country, _ := strings.Cut(query, ",")
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	params := url.Values{}
+	params.Set("name", strings.TrimSpace(name))
+	params.Set("count", fmt.Sprintf("%d", count*4))
+	if p.Lang != "" {
+		params.Set("language", p.Lang)
+	}
+
+	var response openMeteoGeocodingResponse
+	if err := p.get(ctx, p.geocodingURL+"/v1/search", params, &response); err != nil {
+		return nil, err
+	}
+
+	cities := []models.City{}
+	for _, result := range response.Results {
+		if country != "" && result.CountryCode != country {
+			continue
+		}
+		cities = append(cities, models.City{
+			Name:    result.Name,
+			Lat:     result.Latitude,
+			Lon:     result.Longitude,
+			Country: result.CountryCode,
+			State:   result.Admin1,
+		})
+		if len(cities) == count {
+			break
+		}
+	}
+	return cities, nil
+}
+
+func (p *OpenMeteoProvider) get(ctx context.Context, endpoint string, params url.Values, target any) error {
+	resp, err := getContext(ctx, p.client, fmt.Sprintf("%s?%s", endpoint, params.Encode()), nil)
+	if err != nil {
+		return &UnavailableError{Provider: "Open-Meteo", Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError("Open-Meteo", resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+func (f *openMeteoForecastResponse) toOneCall(lang string) *models.OneCallResponse {
+	condition := func(code int) models.WeatherCondition {
+		c := wmoCondition(code)
+		c.Description = i18n.In(lang, c.Description)
+		return c
+	}
+
+	// open-meteo reports snowfall in cm, one call uses mm
+	snow := func(cm float64) *models.SnowData {
+		if cm <= 0 {
+			return nil
+		}
+		return &models.SnowData{OneHour: cm * 10}
+	}
+	rain := func(mm float64) *models.RainData {
+		if mm <= 0 {
+			return nil
+		}
+		return &models.RainData{OneHour: mm}
+	}
+
+	weather := &models.OneCallResponse{
+		Lat:            f.Latitude,
+		Lon:            f.Longitude,
+		Timezone:       f.Timezone,
+		TimezoneOffset: f.UTCOffsetSeconds,
+	}
+
+	c := f.Current
+	weather.Current = models.CurrentWeather{
+		Dt:         c.Time,
+		Temp:       c.Temperature,
+		FeelsLike:  c.ApparentTemperature,
+		Pressure:   int(c.PressureMSL),
+		Humidity:   int(c.RelativeHumidity),
+		DewPoint:   c.DewPoint,
+		UVI:        c.UVIndex,
+		Clouds:     int(c.CloudCover),
+		Visibility: int(c.Visibility),
+		WindSpeed:  c.WindSpeed,
+		WindGust:   c.WindGusts,
+		WindDeg:    int(c.WindDirection),
+		Rain:       rain(c.Rain),
+		Snow:       snow(c.Snowfall),
+		Weather:    []models.WeatherCondition{condition(c.WeatherCode)},
+	}
+	if len(f.Daily.Sunrise) > 0 && len(f.Daily.Sunset) > 0 {
+		weather.Current.Sunrise = f.Daily.Sunrise[0]
+		weather.Current.Sunset = f.Daily.Sunset[0]
+	}
+
+	h := f.Hourly
+	for i, dt := range h.Time {
+		// the forecast starts at local midnight, one call starts at the current hour
+		if dt+3600 <= c.Time {
+			continue
+		}
+		weather.Hourly = append(weather.Hourly, models.HourData{
+			Dt:         dt,
+			Temp:       at(h.Temperature, i),
+			FeelsLike:  at(h.ApparentTemperature, i),
+			Pressure:   int(at(h.PressureMSL, i)),
+			Humidity:   int(at(h.RelativeHumidity, i)),
+			DewPoint:   at(h.DewPoint, i),
+			UVI:        at(h.UVIndex, i),
+			Clouds:     int(at(h.CloudCover, i)),
+			Visibility: int(at(h.Visibility, i)),
+			WindSpeed:  at(h.WindSpeed, i),
+			WindGust:   at(h.WindGusts, i),
+			WindDeg:    int(at(h.WindDirection, i)),
+			Pop:        at(h.PrecipitationProbability, i) / 100,
+			Rain:       rain(at(h.Rain, i)),
+			Snow:       snow(at(h.Snowfall, i)),
+			Weather:    []models.WeatherCondition{condition(atInt(h.WeatherCode, i))},
+		})
+	}
+
+	d := f.Daily
+	for i, dt := range d.Time {
+		dayCondition := condition(atInt(d.WeatherCode, i))
+		day := models.DayData{
+			Dt:        dt,
+			Sunrise:   atInt64(d.Sunrise, i),
+			Sunset:    atInt64(d.Sunset, i),
+			Summary:   capitalize(dayCondition.Description),
+			Temp:      f.dayTemps(dt, at(d.TemperatureMin, i), at(d.TemperatureMax, i)),
+			WindSpeed: at(d.WindSpeedMax, i),
+			WindGust:  at(d.WindGustsMax, i),
+			WindDeg:   int(at(d.WindDirectionDominant, i)),
+			UVI:       at(d.UVIndexMax, i),
+			Pop:       at(d.PrecipitationProbabilityMax, i) / 100,
+			Rain:      at(d.RainSum, i),
+			Snow:      at(d.SnowfallSum, i) * 10,
+			Weather:   []models.WeatherCondition{dayCondition},
+		}
+		day.FeelsLike.Day = at(d.ApparentTemperatureMax, i)
+		weather.Daily = append(weather.Daily, day)
+	}
+
+	return weather
+}
+
+// one call splits each day into morning/day/evening/night, sample the hourly series to match
+func (f *openMeteoForecastResponse) dayTemps(dayStart int64, min, max float64) models.TempData {
+	temps := models.TempData{Min: min, Max: max, Morn: min, Day: max, Eve: max, Night: min}
+	offset := time.Duration(f.UTCOffsetSeconds) * time.Second
+
+	for i, dt := range f.Hourly.Time {
+		if dt < dayStart || dt >= dayStart+24*3600 {
+			continue
+		}
+		value := at(f.Hourly.Temperature, i)
+		switch time.Unix(dt, 0).UTC().Add(offset).Hour() {
+		case 6:
+			temps.Morn = value
+		case 12:
+			temps.Day = value
+		case 18:
+			temps.Eve = value
+		case 23:
+			temps.Night = value
+		}
+	}
+	return temps
+}
+
+// descriptions are lowercase, summaries read as a sentence
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func at(values []float64, i int) float64 {
+	if i < len(values) {
+		return values[i]
+	}
+	return 0
+}
+
+func atInt(values []int, i int) int {
+	if i < len(values) {
+		return values[i]
+	}
+	return 0
+}
+
+func atInt64(values []int64, i int) int64 {
+	if i < len(values) {
+		return values[i]
+	}
+	return 0
+}
+
+// maps WMO weather interpretation codes onto the closest openweathermap condition
+func wmoCondition(code int) models.WeatherCondition {
+	switch code {
+	case 0:
+		return models.WeatherCondition{ID: 800, Main: "Clear", Description: "clear sky"}
+	case 1:
+		return models.WeatherCondition{ID: 801, Main: "Clouds", Description: "mainly clear"}
+	case 2:
+		return models.WeatherCondition{ID: 802, Main: "Clouds", Description: "partly cloudy"}
+	case 3:
+		return models.WeatherCondition{ID: 804, Main: "Clouds", Description: "overcast"}
+	case 45, 48:
+		return models.WeatherCondition{ID: 741, Main: "Fog", Description: "fog"}
+	case 51:
+		return models.WeatherCondition{ID: 300, Main: "Drizzle", Description: "light drizzle"}
+	case 53:
+		return models.WeatherCondition{ID: 301, Main: "Drizzle", Description: "drizzle"}
+	case 55:
+		return models.WeatherCondition{ID: 302, Main: "Drizzle", Description: "heavy drizzle"}
+	case 56, 57:
+		return models.WeatherCondition{ID: 311, Main: "Drizzle", Description: "freezing drizzle"}
+	case 61:
+		return models.WeatherCondition{ID: 500, Main: "Rain", Description: "light rain"}
+	case 63:
+		return models.WeatherCondition{ID: 501, Main: "Rain", Description: "moderate rain"}
+	case 65:
+		return models.WeatherCondition{ID: 502, Main: "Rain", Description: "heavy rain"}
+	case 66, 67:
+		return models.WeatherCondition{ID: 511, Main: "Rain", Description: "freezing rain"}
+	case 71:
+		return models.WeatherCondition{ID: 600, Main: "Snow", Description: "light snow"}
+	case 73:
+		return models.WeatherCondition{ID: 601, Main: "Snow", Description: "snow"}
+	case 75:
+		return models.WeatherCondition{ID: 602, Main: "Snow", Description: "heavy snow"}
+	case 77:
+		return models.WeatherCondition{ID: 600, Main: "Snow", Description: "snow grains"}
+	case 80:
+		return models.WeatherCondition{ID: 520, Main: "Rain", Description: "light shower rain"}
+	case 81:
+		return models.WeatherCondition{ID: 521, Main: "Rain", Description: "shower rain"}
+	case 82:
+		return models.WeatherCondition{ID: 522, Main: "Rain", Description: "heavy shower rain"}
+	case 85:
+		return models.WeatherCondition{ID: 620, Main: "Snow", Description: "light shower snow"}
+	case 86:
+		return models.WeatherCondition{ID: 621, Main: "Snow", Description: "shower snow"}
+	case 95:
+		return models.WeatherCondition{ID: 211, Main: "Thunderstorm", Description: "thunderstorm"}
+	case 96, 99:
+		return models.WeatherCondition{ID: 202, Main: "Thunderstorm", Description: "thunderstorm with hail"}
+	default:
+		return models.WeatherCondition{ID: 0, Main: "Unknown", Description: "unknown"}
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/josephburgess/gust/internal/models"
+)
+
+const openMeteoForecastFixture = `{
+	"latitude": 52.52,
+	"longitude": 13.41,
+	"timezone": "Europe/Berlin",
+	"utc_offset_seconds": 3600,
+	"current": {
+		"time": 1700000000,
+		"temperature_2m": 10.5,
+		"apparent_temperature": 8.0,
+		"relative_humidity_2m": 70,
+		"rain": 0.4,
+		"snowfall": 0,
+		"weather_code": 61,
+		"wind_speed_10m": 4.2,
+		"wind_direction_10m": 270
+	},
+	"hourly": {
+		"time": [1699992000, 1699999200, 1700002800],
+		"temperature_2m": [9.0, 10.0, 11.0],
+		"precipitation_probability": [10, 50, 80],
+		"snowfall": [0, 0, 0.2],
+		"weather_code": [3, 61, 71]
+	},
+	"daily": {
+		"time": [1699916400],
+		"weather_code": [2],
+		"temperature_2m_max": [12.0],
+		"temperature_2m_min": [4.0],
+		"sunrise": [1699943000],
+		"sunset": [1699976000],
+		"precipitation_probability_max": [80],
+		"rain_sum": [3.2],
+		"snowfall_sum": [0.5]
+	}
+}`
+
+func newOpenMeteoTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/search":
+			if name := r.URL.Query().Get("name"); name != "Berlin" {
+				t.Errorf("Expected name=Berlin, got %s", name)
+			}
+			w.Write([]byte(`{"results": [
+				{"name": "Berlin", "latitude": 39.79, "longitude": -89.64, "country_code": "US", "admin1": "Illinois"},
+				{"name": "Berlin", "latitude": 52.52, "longitude": 13.41, "country_code": "DE", "admin1": "Land Berlin"}
+			]}`))
+		case "/v1/forecast":
+			if unit := r.URL.Query().Get("wind_speed_unit"); unit != "ms" {
+				t.Errorf("Expected wind_speed_unit=ms, got %s", unit)
+			}
+			if unit := r.URL.Query().Get("temperature_unit"); unit != "" {
+				t.Errorf("Expected the default celsius, got temperature_unit=%s", unit)
+			}
+			w.Write([]byte(openMeteoForecastFixture))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestOpenMeteoSearchCitiesCountryFilter(t *testing.T) {
+	server := newOpenMeteoTestServer(t)
+	defer server.Close()
+
+	provider := NewOpenMeteoProvider()
+	provider.geocodingURL = server.URL
+
+	cities, err := provider.SearchCities("Berlin, de")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(cities) != 1 || cities[0].Country != "DE" {
+		t.Fatalf("Expected only the German Berlin, got %+v", cities)
+	}
+}
+
+func TestOpenMeteoGetWeather(t *testing.T) {
+	server := newOpenMeteoTestServer(t)
+	defer server.Close()
+
+	provider := NewOpenMeteoProvider()
+	provider.forecastURL = server.URL
+	provider.geocodingURL = server.URL
+
+	resp, err := provider.GetWeather("Berlin")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	weather := resp.Weather
+	if weather.Timezone != "Europe/Berlin" || weather.TimezoneOffset != 3600 {
+		t.Errorf("Unexpected timezone %s (%d)", weather.Timezone, weather.TimezoneOffset)
+	}
+
+	if weather.Current.Temp != 10.5 {
+		t.Errorf("Expected celsius temp %f, got %f", 10.5, weather.Current.Temp)
+	}
+
+	if len(weather.Current.Weather) == 0 || weather.Current.Weather[0].ID != 500 {
+		t.Errorf("Expected WMO 61 to map to condition 500, got %+v", weather.Current.Weather)
+	}
+
+	if weather.Current.Rain == nil || weather.Current.Rain.OneHour != 0.4 {
+		t.Errorf("Expected current rain 0.4, got %+v", weather.Current.Rain)
+	}
+
+	if weather.Current.Sunrise != 1699943000 {
+		t.Errorf("Expected sunrise from the first daily entry, got %d", weather.Current.Sunrise)
+	}
+
+	// the first hour ended before the current time and is dropped
+	if len(weather.Hourly) != 2 {
+		t.Fatalf("Expected 2 hourly entries, got %d", len(weather.Hourly))
+	}
+
+	if weather.Hourly[0].Pop != 0.5 {
+		t.Errorf("Expected pop 0.5, got %f", weather.Hourly[0].Pop)
+	}
+
+	if weather.Hourly[1].Snow == nil || weather.Hourly[1].Snow.OneHour != 2 {
+		t.Errorf("Expected snowfall converted to 2 mm, got %+v", weather.Hourly[1].Snow)
+	}
+
+	if len(weather.Daily) != 1 || weather.Daily[0].Snow != 5 || weather.Daily[0].Pop != 0.8 {
+		t.Errorf("Unexpected daily data %+v", weather.Daily)
+	}
+}
+
+func TestOpenMeteoGetWeatherLocalized(t *testing.T) {
+	server := newOpenMeteoTestServer(t)
+	defer server.Close()
+
+	provider := NewOpenMeteoProvider()
+	provider.Lang = "de"
+	provider.forecastURL = server.URL
+	provider.geocodingURL = server.URL
+
+	resp, err := provider.GetWeather("Berlin")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := resp.Weather.Current.Weather[0].Description; got != "leichter Regen" {
+		t.Errorf("Expected a german description, got %q", got)
+	}
+
+	if got := resp.Weather.Current.Weather[0].Main; got != "Rain" {
+		t.Errorf("Expected main to stay untranslated for the tips engine, got %q", got)
+	}
+}
+
+func TestOpenMeteoGetWeatherByCoords(t *testing.T) {
+	server := newOpenMeteoTestServer(t)
+	defer server.Close()
+
+	provider := NewOpenMeteoProvider()
+	provider.forecastURL = server.URL
+	provider.geocodingURL = server.URL
+
+	resp, err := provider.GetWeatherByCoords(models.Coordinates{Lat: 52.52, Lon: 13.41})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.City.Name != "52.5200, 13.4100" || resp.City.Lat != 52.52 {
+		t.Errorf("Expected the coordinates to be used as the name, got %+v", resp.City)
+	}
+
+	if resp.Weather.Current.Temp != 10.5 {
+		t.Errorf("Expected temp 10.5, got %f", resp.Weather.Current.Temp)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	testCases := []struct {
+		name      string
+		apiKey    string
+		expectErr bool
+	}{
+		{"", "key", false},
+		{ProviderBreeze, "key", false},
+		{ProviderOpenWeatherMap, "key", false},
+		{ProviderOpenWeatherMap, "", true},
+		{ProviderOpenMeteo, "", false},
+		{"darksky", "key", true},
+	}
+
+	for _, tc := range testCases {
+		provider, err := NewProvider(tc.name, "https://example.com", tc.apiKey, "en", 0)
+		if tc.expectErr && err == nil {
+			t.Errorf("NewProvider(%q) expected error", tc.name)
+		}
+		if !tc.expectErr && (err != nil || provider == nil) {
+			t.Errorf("NewProvider(%q) unexpected error: %v", tc.name, err)
+		}
+	}
+}
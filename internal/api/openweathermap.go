@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/redact"
+)
+
+const openWeatherMapURL = "https://api.openweathermap.org"
+
+// talks to One Call 3.0 directly with a user supplied key, bypassing breeze
+type OpenWeatherMapProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+	// condition descriptions come back in this language, english when empty
+	Lang string
+}
+
+func NewOpenWeatherMapProvider(apiKey string) *OpenWeatherMapProvider {
+	// One Call only takes the key as appid in the query, so it's the errors that have to hide it
+	redact.Secret(apiKey)
+	return &OpenWeatherMapProvider{
+		baseURL: openWeatherMapURL,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+func (p *OpenWeatherMapProvider) GetWeather(cityName string) (*WeatherResponse, error) {
+	return p.GetWeatherContext(context.Background(), cityName)
+}
+
+func (p *OpenWeatherMapProvider) GetWeatherContext(ctx context.Context, cityName string) (*WeatherResponse, error) {
+	cities, err := p.geocode(ctx, cityName, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(cities) == 0 {
+		return nil, &CityNotFoundError{City: cityName}
+	}
+
+	return p.oneCall(ctx, cities[0])
+}
+
+// reverse geocoding is only for the display name, a failed lookup falls back to the coordinates
+func (p *OpenWeatherMapProvider) GetWeatherByCoords(coords models.Coordinates) (*WeatherResponse, error) {
+	return p.GetWeatherByCoordsContext(context.Background(), coords)
+}
+
+func (p *OpenWeatherMapProvider) GetWeatherByCoordsContext(ctx context.Context, coords models.Coordinates) (*WeatherResponse, error) {
+	city := models.City{Name: coords.String(), Lat: coords.Lat, Lon: coords.Lon}
+
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%f", coords.Lat))
+	params.Set("lon", fmt.Sprintf("%f", coords.Lon))
+	params.Set("limit", "1")
+	params.Set("appid", p.apiKey)
+
+	var cities []models.City
+	if err := p.get(ctx, "/geo/1.0/reverse", params, &cities); err == nil && len(cities) > 0 {
+		city.Name, city.Country, city.State = cities[0].Name, cities[0].Country, cities[0].State
+	}
+
+	return p.oneCall(ctx, city)
+}
+
+func (p *OpenWeatherMapProvider) oneCall(ctx context.Context, city models.City) (*WeatherResponse, error) {
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%f", city.Lat))
+	params.Set("lon", fmt.Sprintf("%f", city.Lon))
+	params.Set("appid", p.apiKey)
+	params.Set("units", CanonicalUnits)
+	if p.Lang != "" {
+		params.Set("lang", p.Lang)
+	}
+
+	var weather models.OneCallResponse
+	if err := p.get(ctx, "/data/3.0/onecall", params, &weather); err != nil {
+		return nil, err
+	}
+
+	return &WeatherResponse{City: &city, Weather: &weather}, nil
+}
+
+func (p *OpenWeatherMapProvider) SearchCities(query string) ([]models.City, error) {
+	return p.SearchCitiesContext(context.Background(), query)
+}
+
+func (p *OpenWeatherMapProvider) SearchCitiesContext(ctx context.Context, query string) ([]models.City, error) {
+	return p.geocode(ctx, query, 5)
+}
+
+func (p *OpenWeatherMapProvider) geocode(ctx context.Context, query string, limit int) ([]models.City, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("appid", p.apiKey)
+
+	// the geocoding response shares field names with models.City
+	var cities []models.City
+	if err := p.get(ctx, "/geo/1.0/direct", params, &cities); err != nil {
+		return nil, err
+	}
+	return cities, nil
+}
+
+func (p *OpenWeatherMapProvider) get(ctx context.Context, path string, params url.Values, target any) error {
+	resp, err := getContext(ctx, p.client, fmt.Sprintf("%s%s?%s", p.baseURL, path, params.Encode()), nil)
+	if err != nil {
+		return &UnavailableError{Provider: "OpenWeatherMap", Err: err}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{Provider: "OpenWeatherMap", Message: "invalid API key or no One Call 3.0 subscription"}
+	default:
+		return responseError("OpenWeatherMap", resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
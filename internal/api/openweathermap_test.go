@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/josephburgess/gust/internal/models"
+)
+
+func TestOpenWeatherMapGetWeather(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.URL.Query().Get("appid"); key != "owm-key" {
+			t.Errorf("Expected appid=owm-key, got %s", key)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/geo/1.0/direct":
+			if q := r.URL.Query().Get("q"); q != "London" {
+				t.Errorf("Expected q=London, got %s", q)
+			}
+			w.Write([]byte(`[{"name": "London", "lat": 51.5074, "lon": -0.1278, "country": "GB", "state": "England"}]`))
+		case "/data/3.0/onecall":
+			if lat := r.URL.Query().Get("lat"); lat != "51.507400" {
+				t.Errorf("Expected lat=51.507400, got %s", lat)
+			}
+			if units := r.URL.Query().Get("units"); units != "metric" {
+				t.Errorf("Expected units=metric, got %s", units)
+			}
+			if lang := r.URL.Query().Get("lang"); lang != "de" {
+				t.Errorf("Expected lang=de, got %s", lang)
+			}
+			w.Write([]byte(`{"timezone": "Europe/London", "current": {"temp": 11.5, "weather": [{"id": 800, "description": "clear sky"}]}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewOpenWeatherMapProvider("owm-key")
+	provider.baseURL = server.URL
+	provider.Lang = "de"
+
+	resp, err := provider.GetWeather("London")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.City.Name != "London" || resp.City.State != "England" {
+		t.Errorf("Unexpected city %+v", resp.City)
+	}
+
+	if resp.Weather.Current.Temp != 11.5 {
+		t.Errorf("Expected temp 11.5, got %f", resp.Weather.Current.Temp)
+	}
+}
+
+func TestOpenWeatherMapGetWeatherByCoords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/geo/1.0/reverse":
+			w.Write([]byte(`[{"name": "Springfield", "lat": 39.8, "lon": -89.64, "country": "US", "state": "Illinois"}]`))
+		case "/data/3.0/onecall":
+			if lat := r.URL.Query().Get("lat"); lat != "39.800000" {
+				t.Errorf("Expected the requested lat 39.800000, got %s", lat)
+			}
+			w.Write([]byte(`{"current": {"temp": 20}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewOpenWeatherMapProvider("owm-key")
+	provider.baseURL = server.URL
+
+	resp, err := provider.GetWeatherByCoords(models.Coordinates{Lat: 39.8, Lon: -89.64})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.City.Name != "Springfield" || resp.City.State != "Illinois" || resp.City.Lat != 39.8 {
+		t.Errorf("Unexpected city %+v", resp.City)
+	}
+}
+
+func TestOpenWeatherMapCityNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenWeatherMapProvider("owm-key")
+	provider.baseURL = server.URL
+
+	if _, err := provider.GetWeather("Atlantis"); err == nil {
+		t.Error("Expected error for unknown city, got nil")
+	}
+}
+
+func TestOpenWeatherMapUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewOpenWeatherMapProvider("bad-key")
+	provider.baseURL = server.URL
+
+	if _, err := provider.SearchCities("London"); err == nil {
+		t.Error("Expected error for invalid key, got nil")
+	}
+}
+
+func TestOpenWeatherMapInvalidKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewOpenWeatherMapProvider("bad-key")
+	provider.baseURL = server.URL
+
+	_, err := provider.GetWeather("London")
+
+	var unauthorized *UnauthorizedError
+	if !errors.As(err, &unauthorized) || unauthorized.Provider != "OpenWeatherMap" {
+		t.Errorf("Expected an UnauthorizedError, got %v", err)
+	}
+}
+
+func TestOpenWeatherMapUnreachableHidesKey(t *testing.T) {
+	useFastRetries(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	provider := NewOpenWeatherMapProvider("secret-owm-key")
+	provider.baseURL = server.URL
+
+	_, err := provider.GetWeather("London")
+	if err == nil {
+		t.Fatal("Expected an error from a closed server")
+	}
+	if strings.Contains(err.Error(), "secret-owm-key") {
+		t.Errorf("The key leaked into the error: %v", err)
+	}
+	if !strings.Contains(err.Error(), "appid=****") {
+		t.Errorf("Expected the url with the key masked, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/josephburgess/gust/internal/models"
+)
+
+const (
+	ProviderBreeze         = "breeze"
+	ProviderOpenWeatherMap = "openweathermap"
+	ProviderOpenMeteo      = "open-meteo"
+)
+
+// every provider fetches metric data (celsius, m/s, hPa, mm, metres),
+// converting for display is up to the renderers so cached weather suits any units
+const CanonicalUnits = "metric"
+
+// how long a request may take, headers and body included, unless the config says otherwise
+const DefaultTimeout = 10 * time.Second
+
+// a weather backend - every implementation maps its data into models.OneCallResponse.
+// The Context variants give up when ctx is done, the others run with context.Background
+type Provider interface {
+	GetWeather(cityName string) (*WeatherResponse, error)
+	GetWeatherContext(ctx context.Context, cityName string) (*WeatherResponse, error)
+	GetWeatherByCoords(coords models.Coordinates) (*WeatherResponse, error)
+	GetWeatherByCoordsContext(ctx context.Context, coords models.Coordinates) (*WeatherResponse, error)
+	SearchCities(query string) ([]models.City, error)
+	SearchCitiesContext(ctx context.Context, query string) ([]models.City, error)
+}
+
+// lang is the language condition descriptions should be in, e.g. "de",
+// and timeout caps each request, DefaultTimeout when it's zero
+func NewProvider(name, baseURL, apiKey, lang string, timeout time.Duration) (Provider, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	switch name {
+	case "", ProviderBreeze:
+		client := NewClient(baseURL, apiKey)
+		client.Lang = lang
+		client.client.Timeout = timeout
+		return client, nil
+	case ProviderOpenWeatherMap:
+		if apiKey == "" {
+			return nil, fmt.Errorf("the %s provider needs an OpenWeatherMap API key", ProviderOpenWeatherMap)
+		}
+		provider := NewOpenWeatherMapProvider(apiKey)
+		provider.Lang = lang
+		provider.client.Timeout = timeout
+		return provider, nil
+	case ProviderOpenMeteo:
+		provider := NewOpenMeteoProvider()
+		provider.Lang = lang
+		provider.client.Timeout = timeout
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unknown weather provider %q", name)
+	}
+}
+
+func IsValidProvider(name string) bool {
+	switch name {
+	case ProviderBreeze, ProviderOpenWeatherMap, ProviderOpenMeteo:
+		return true
+	}
+	return false
+}
+
+// open-meteo is keyless, everything else needs gust auth or an owm key
+func ProviderRequiresKey(name string) bool {
+	return name != ProviderOpenMeteo
+}
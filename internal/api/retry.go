@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/josephburgess/gust/internal/redact"
+)
+
+// how requests that failed on the way, or with a 502, 503 or 504, are tried again
+type retryPolicy struct {
+	// attempts in total, so 1 never retries
+	MaxAttempts int
+	// the first wait, doubling each retry up to MaxDelay
+	BaseDelay time.Duration
+	// also the longest Retry-After worth waiting for, anything later fails straight away
+	MaxDelay time.Duration
+}
+
+// for tests
+var retries = retryPolicy{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// how long to wait before the next attempt, false when the response or error should be returned as it is
+func (p retryPolicy) delay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if err != nil {
+		// a timeout has already waited as long as the user allowed
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if reset := retryAfter(resp); !reset.IsZero() {
+		wait := time.Until(reset)
+		if wait > p.MaxDelay {
+			return 0, false
+		}
+		return max(wait, 0), true
+	}
+	// a rate limit without a reset could last the rest of the hour
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return 0, false
+	}
+	return p.backoff(attempt), true
+}
+
+// exponential, with jitter so clients that failed together don't retry together
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	wait := min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+type retryNotifyKey struct{}
+
+// WithRetryNotify returns a ctx whose requests call notify before each retry,
+// with the attempt about to be made and the most there will be
+func WithRetryNotify(ctx context.Context, notify func(attempt, maxAttempts int)) context.Context {
+	return context.WithValue(ctx, retryNotifyKey{}, notify)
+}
+
+// a GET that gives up when ctx is done, retrying transient failures as retries allows.
+// Errors never include a key from the url
+func getContext(ctx context.Context, client *http.Client, endpoint string, header http.Header) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, redact.Error(err)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+
+		resp, err := client.Do(req)
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redact.String(urlErr.URL)
+		}
+		wait, retry := retries.delay(attempt, resp, err)
+		if !retry || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			// drained so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if notify, ok := ctx.Value(retryNotifyKey{}).(func(attempt, maxAttempts int)); ok {
+			notify(attempt+1, retries.MaxAttempts)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
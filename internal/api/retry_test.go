@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// retries without the real waits
+func useFastRetries(t *testing.T) {
+	original := retries
+	t.Cleanup(func() { retries = original })
+	retries = retryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+}
+
+// fails with status until the given number of requests have been made
+func newFlakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"city":{"name":"London"},"weather":{}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestGetWeatherRetries(t *testing.T) {
+	useFastRetries(t)
+
+	testCases := []struct {
+		name      string
+		failures  int32
+		status    int
+		header    http.Header
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"bad gateway then success", 2, http.StatusBadGateway, nil, 3, false},
+		{"gateway timeout then success", 1, http.StatusGatewayTimeout, nil, 2, false},
+		{"gives up after max attempts", 5, http.StatusServiceUnavailable, nil, 3, true},
+		{"short retry after is waited for", 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"0"}}, 2, false},
+		{"long retry after fails straight away", 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"3600"}}, 1, true},
+		{"rate limit with a short reset", 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, 2, false},
+		{"rate limit without a reset", 1, http.StatusTooManyRequests, nil, 1, true},
+		{"client errors aren't retried", 1, http.StatusBadRequest, nil, 1, true},
+		{"server errors other than gateway ones aren't retried", 1, http.StatusInternalServerError, nil, 1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, calls := newFlakyServer(t, tc.failures, tc.status, tc.header)
+
+			_, err := NewClient(server.URL, "test-api-key").GetWeather("London")
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if got := atomic.LoadInt32(calls); got != tc.wantCalls {
+				t.Errorf("Expected %d requests, got %d", tc.wantCalls, got)
+			}
+		})
+	}
+}
+
+func TestGetWeatherRetriesDroppedConnections(t *testing.T) {
+	useFastRetries(t)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// hang up without answering, like a reset connection
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"city":{"name":"London"},"weather":{}}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, "test-api-key").GetWeather("London"); err != nil {
+		t.Errorf("Expected the retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls)
+	}
+}
+
+func TestRetryNotify(t *testing.T) {
+	useFastRetries(t)
+	server, _ := newFlakyServer(t, 2, http.StatusServiceUnavailable, nil)
+
+	var attempts []string
+	ctx := WithRetryNotify(context.Background(), func(attempt, maxAttempts int) {
+		attempts = append(attempts, strconv.Itoa(attempt)+"/"+strconv.Itoa(maxAttempts))
+	})
+	if _, err := NewClient(server.URL, "test-api-key").GetWeatherContext(ctx, "London"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(attempts) != 2 || attempts[0] != "2/3" || attempts[1] != "3/3" {
+		t.Errorf("Expected to hear about attempts 2/3 and 3/3, got %v", attempts)
+	}
+}
+
+func TestRetryWaitCancelled(t *testing.T) {
+	original := retries
+	t.Cleanup(func() { retries = original })
+	retries = retryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	server, _ := newFlakyServer(t, 1, http.StatusServiceUnavailable, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = WithRetryNotify(ctx, func(int, int) { cancel() })
+
+	_, err := NewClient(server.URL, "test-api-key").GetWeatherContext(ctx, "London")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the wait to be cut short, got %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := retryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	for attempt, ceiling := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 4: 300 * time.Millisecond} {
+		for i := 0; i < 20; i++ {
+			if wait := policy.backoff(attempt); wait < ceiling/2 || wait > ceiling {
+				t.Errorf("attempt %d: expected a wait between %v and %v, got %v", attempt, ceiling/2, ceiling, wait)
+			}
+		}
+	}
+}
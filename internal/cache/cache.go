@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/i18n"
+)
+
+// responses are always metric, so one entry serves every display unit
+type Entry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	City      string    `json:"city"`
+	Units     string    `json:"units"`
+	// condition descriptions are in this language
+	Lang string `json:"lang,omitempty"`
+	// the backend that answered, e.g. "open-meteo"
+	Provider string               `json:"provider,omitempty"`
+	Response *api.WeatherResponse `json:"response"`
+}
+
+type GetCacheDirFunc func() (string, error)
+
+// for tests
+var GetCacheDir GetCacheDirFunc = defaultGetCacheDir
+
+func defaultGetCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user cache directory: %w", err)
+	}
+
+	dir := filepath.Join(cacheDir, "gust", "weather")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create cache directory: %w", err)
+	}
+
+	return dir, nil
+}
+
+// "  New   York " and "new york" share an entry
+func Key(city string) string {
+	return strings.Join(strings.Fields(strings.ToLower(city)), " ")
+}
+
+func entryPath(city string) (string, error) {
+	dir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(Key(city)))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16])+".json"), nil
+}
+
+// returns nil, nil when nothing has been cached for city by provider
+func Load(city, provider string) (*Entry, error) {
+	path, err := entryPath(city)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open cache entry: %w", err)
+	}
+	defer file.Close()
+
+	var entry Entry
+	if err := json.NewDecoder(file).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("could not decode cache entry: %w", err)
+	}
+
+	// entries fetched in other units before everything was metric are treated as missing,
+	// as are ones described in another language or from another provider
+	if entry.Units != api.CanonicalUnits || entry.lang() != i18n.Language() || entry.provider() != providerName(provider) ||
+		entry.Response == nil || entry.Response.City == nil || entry.Response.Weather == nil {
+		return nil, nil
+	}
+
+	return &entry, nil
+}
+
+func Save(city, provider string, response *api.WeatherResponse) error {
+	path, err := entryPath(city)
+	if err != nil {
+		return err
+	}
+
+	entry := Entry{
+		FetchedAt: time.Now(),
+		City:      city,
+		Units:     api.CanonicalUnits,
+		Lang:      i18n.Language(),
+		Provider:  providerName(provider),
+		Response:  response,
+	}
+
+	// write then rename so a concurrent reader never sees a half written file
+	tmp, err := os.CreateTemp(filepath.Dir(path), "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(entry); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not encode cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// entries from before languages were recorded are english
+func (e *Entry) lang() string {
+	if e.Lang == "" {
+		return i18n.DefaultLanguage
+	}
+	return e.Lang
+}
+
+// entries from before providers were recorded came from breeze
+func (e *Entry) provider() string {
+	return providerName(e.Provider)
+}
+
+// an unset provider is breeze
+func providerName(provider string) string {
+	if provider == "" {
+		return api.ProviderBreeze
+	}
+	return provider
+}
+
+func (e *Entry) Age() time.Duration {
+	return time.Since(e.FetchedAt)
+}
+
+func (e *Entry) IsFresh(ttl time.Duration) bool {
+	return ttl > 0 && e.Age() < ttl
+}
+
+// how long a server is remembered as having no coordinate lookups, it may be upgraded
+const noCoordinatesTTL = 24 * time.Hour
+
+func noCoordinatesPath(provider, baseURL string) (string, error) {
+	dir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(providerName(provider) + " " + baseURL))
+	return filepath.Join(dir, "no-coordinates-"+hex.EncodeToString(sum[:8])), nil
+}
+
+// records that provider at baseURL can't look weather up by coordinates, so later runs
+// go straight to the name rather than paying for a request that will fail
+func MarkNoCoordinates(provider, baseURL string) error {
+	path, err := noCoordinatesPath(provider, baseURL)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		return fmt.Errorf("could not write cache entry: %w", err)
+	}
+	return nil
+}
+
+// whether provider at baseURL was marked in the last day
+func NoCoordinates(provider, baseURL string) bool {
+	path, err := noCoordinatesPath(provider, baseURL)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && time.Since(info.ModTime()) < noCoordinatesTTL
+}
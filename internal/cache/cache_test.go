@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTempCacheDir(t *testing.T) string {
+	tempDir := t.TempDir()
+	original := GetCacheDir
+	t.Cleanup(func() { GetCacheDir = original })
+	GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+	return tempDir
+}
+
+func testResponse() *api.WeatherResponse {
+	return &api.WeatherResponse{
+		City:    &models.City{Name: "London", Country: "GB"},
+		Weather: &models.OneCallResponse{Current: models.CurrentWeather{Temp: 12.5}},
+	}
+}
+
+func TestKeyNormalization(t *testing.T) {
+	assert.Equal(t, Key("new york"), Key("  New   York "))
+	assert.NotEqual(t, Key("london"), Key("paris"))
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	useTempCacheDir(t)
+
+	require.NoError(t, Save("London", "", testResponse()))
+
+	entry, err := Load("london", "")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+
+	assert.Equal(t, "London", entry.Response.City.Name)
+	assert.Equal(t, 12.5, entry.Response.Weather.Current.Temp)
+	assert.True(t, entry.IsFresh(time.Minute))
+}
+
+func TestLoadMiss(t *testing.T) {
+	useTempCacheDir(t)
+
+	entry, err := Load("Paris", "")
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestLoadIgnoresNonMetricEntry(t *testing.T) {
+	useTempCacheDir(t)
+
+	path, err := entryPath("Oslo")
+	require.NoError(t, err)
+	old := `{"fetched_at":"2024-01-01T00:00:00Z","city":"Oslo","units":"imperial","response":{"city":{"name":"Oslo"},"weather":{}}}`
+	require.NoError(t, os.WriteFile(path, []byte(old), 0644))
+
+	entry, err := Load("Oslo", "")
+	assert.NoError(t, err)
+	assert.Nil(t, entry, "entries fetched in other units should be refetched")
+}
+
+func TestLoadIgnoresOtherLanguages(t *testing.T) {
+	useTempCacheDir(t)
+	t.Cleanup(func() { i18n.SetLanguage(i18n.DefaultLanguage) })
+
+	require.NoError(t, Save("Madrid", "", &api.WeatherResponse{City: &models.City{Name: "Madrid"}, Weather: &models.OneCallResponse{}}))
+
+	i18n.SetLanguage("es")
+	entry, err := Load("Madrid", "")
+	assert.NoError(t, err)
+	assert.Nil(t, entry, "descriptions cached in english should be refetched in spanish")
+
+	i18n.SetLanguage(i18n.DefaultLanguage)
+	entry, err = Load("Madrid", "")
+	assert.NoError(t, err)
+	assert.NotNil(t, entry)
+}
+
+func TestLoadIgnoresOtherProviders(t *testing.T) {
+	useTempCacheDir(t)
+
+	require.NoError(t, Save("Lima", "", testResponse()))
+
+	entry, err := Load("Lima", api.ProviderOpenMeteo)
+	assert.NoError(t, err)
+	assert.Nil(t, entry, "weather from breeze shouldn't be served after switching provider")
+
+	entry, err = Load("Lima", api.ProviderBreeze)
+	assert.NoError(t, err)
+	assert.NotNil(t, entry, "an unset provider is breeze")
+}
+
+func TestLoadCorruptEntry(t *testing.T) {
+	dir := useTempCacheDir(t)
+
+	path, err := entryPath("Berlin")
+	require.NoError(t, err)
+	require.Equal(t, dir, filepath.Dir(path))
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+
+	entry, err := Load("Berlin", "")
+	assert.Error(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestIsFresh(t *testing.T) {
+	entry := &Entry{FetchedAt: time.Now().Add(-5 * time.Minute)}
+
+	assert.True(t, entry.IsFresh(10*time.Minute))
+	assert.False(t, entry.IsFresh(time.Minute))
+	assert.False(t, entry.IsFresh(0), "a zero ttl disables the cache")
+}
+
+func TestNoCoordinates(t *testing.T) {
+	dir := useTempCacheDir(t)
+
+	assert.False(t, NoCoordinates("", "https://breeze.example"))
+	require.NoError(t, MarkNoCoordinates("", "https://breeze.example"))
+	assert.True(t, NoCoordinates(api.ProviderBreeze, "https://breeze.example"))
+	assert.False(t, NoCoordinates("", "https://other.example"), "only the server that failed is remembered")
+
+	// a server may have been upgraded since
+	matches, _ := filepath.Glob(filepath.Join(dir, "no-coordinates-*"))
+	require.Len(t, matches, 1)
+	old := time.Now().Add(-2 * noCoordinatesTTL)
+	require.NoError(t, os.Chtimes(matches[0], old, old))
+	assert.False(t, NoCoordinates("", "https://breeze.example"))
+}
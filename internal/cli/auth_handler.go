@@ -1,14 +1,18 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/ui/output"
 )
 
 func handleLogin(apiURL string) error {
-	output.PrintInfo("Starting GitHub authentication...")
+	output.PrintInfo(i18n.T("Starting GitHub authentication..."))
 	authConfig, err := config.Authenticate(apiURL)
 	if err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
@@ -18,12 +22,100 @@ func handleLogin(apiURL string) error {
 		return fmt.Errorf("failed to save authentication: %w", err)
 	}
 
-	output.PrintSuccess(fmt.Sprintf("Successfully authenticated as %s\n", authConfig.GithubUser))
+	output.PrintSuccess(i18n.T("Successfully authenticated as %s\n", authConfig.GithubUser))
 	return nil
 }
 
+func handleLogout() error {
+	err := config.DeleteAuthConfig()
+	if errors.Is(err, config.ErrKeyringEntryLeft) {
+		output.PrintWarning(i18n.T("%v, remove the \"gust\" entry from your keyring yourself", err))
+	} else if err != nil {
+		return fmt.Errorf("failed to remove authentication: %w", err)
+	}
+
+	output.PrintSuccess(i18n.T("Logged out, stored credentials removed."))
+	return nil
+}
+
+func handleAuthStatus(cfg *config.Config) error {
+	authConfig, err := config.LoadAuthConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load authentication: %w", err)
+	}
+
+	provider := cfg.Provider
+	if provider == "" {
+		provider = api.ProviderBreeze
+	}
+	fmt.Println(i18n.T("Provider: %s", provider))
+
+	if authConfig == nil {
+		if api.ProviderRequiresKey(cfg.Provider) {
+			output.PrintWarning(i18n.T("Not authenticated. Run 'gust auth login' or 'gust auth key <api-key>'."))
+		} else {
+			fmt.Println(i18n.T("No api key needed for this provider."))
+		}
+		return nil
+	}
+
+	if authConfig.GithubUser != "" {
+		fmt.Println(i18n.T("User: %s", authConfig.GithubUser))
+	}
+	fmt.Println(i18n.T("Api key: %s", maskKey(authConfig.APIKey)))
+	if authConfig.InKeyring {
+		fmt.Println(i18n.T("Stored in: system keyring"))
+	} else if path, err := config.GetAuthConfigPath(); err == nil {
+		fmt.Println(i18n.T("Stored in: %s", path))
+	}
+	if !authConfig.LastAuth.IsZero() {
+		fmt.Println(i18n.T("Last authenticated: %s", authConfig.LastAuth.Format(time.RFC1123)))
+	}
+	return nil
+}
+
+func handleApiKey(cfg *config.Config, apiKey string) error {
+	authConfig, _ := config.LoadAuthConfig()
+
+	newAuthConfig := &config.AuthConfig{
+		APIKey:     apiKey,
+		ServerURL:  cfg.ApiUrl,
+		LastAuth:   time.Now(),
+		GithubUser: "",
+	}
+
+	if authConfig != nil {
+		newAuthConfig.GithubUser = authConfig.GithubUser
+	}
+
+	if err := config.SaveAuthConfig(newAuthConfig); err != nil {
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	output.PrintSuccess(i18n.T("API key updated."))
+	return nil
+}
+
+// a keyring that won't hand the key over is an error of its own, treating it as logged out
+// would push the user to log in and store a second key. Other load failures mean logged out
+func loadAuthConfig() (*config.AuthConfig, error) {
+	authConfig, err := config.LoadAuthConfig()
+	if errors.Is(err, config.ErrKeyringUnreadable) {
+		return nil, err
+	}
+	return authConfig, nil
+}
+
 func handleMissingAuth() error {
-	output.PrintError("You need to authenticate with GitHub before using Gust.")
-	output.PrintInfo("Run 'gust --login' to authenticate or 'gust --setup' to run the setup wizard.")
-	return fmt.Errorf("authentication required")
+	output.PrintError(i18n.T("You need to authenticate with GitHub before using Gust."))
+	output.PrintInfo(i18n.T("Run 'gust auth login' to authenticate or 'gust setup' to run the setup wizard."))
+	return errors.New(i18n.T("authentication required"))
+}
+
+// only the last four characters are shown
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
 }
@@ -1,8 +1,16 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/josephburgess/gust/internal/cache"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/components"
+	"github.com/josephburgess/gust/internal/ui/output"
 )
 
 func determineCityName(cityFlag string, args []string, defaultCity string) string {
@@ -15,10 +23,146 @@ func determineCityName(cityFlag string, args []string, defaultCity string) strin
 	return defaultCity
 }
 
+// --lat/--lon as a geo URI, so coordinates flow through the same paths as a city name
+func coordinatesFromFlags(lat, lon *float64) (string, error) {
+	if lat == nil && lon == nil {
+		return "", nil
+	}
+	if lat == nil || lon == nil {
+		return "", errors.New(i18n.T("--lat and --lon must be used together"))
+	}
+
+	coords, err := models.NewCoordinates(*lat, *lon)
+	if err != nil {
+		return "", err
+	}
+	return coords.GeoURI(), nil
+}
+
+// "london + paris+tokyo" -> [london paris tokyo], a plain city comes back on its own
+func splitCities(name string) []string {
+	var cities []string
+	for _, city := range strings.Split(name, "+") {
+		if city = strings.TrimSpace(city); city != "" {
+			cities = append(cities, city)
+		}
+	}
+	return cities
+}
+
+// "@home" must be a saved alias, a bare name only resolves when it matches one exactly
+func resolveCity(name string, cfg *config.Config) (string, error) {
+	if strings.HasPrefix(name, "@") {
+		location, ok := cfg.FindLocation(name)
+		if !ok {
+			return "", fmt.Errorf(i18n.T("no saved location called %q - add it with 'gust locations add %s <city>'"), name, strings.TrimPrefix(name, "@"))
+		}
+		return location.City, nil
+	}
+
+	if location, ok := cfg.FindLocation(name); ok {
+		return location.City, nil
+	}
+	return name, nil
+}
+
 func handleMissingCity() error {
-	fmt.Println("No city specified and no default city set.")
-	fmt.Println("Specify a city: gust [city name]")
-	fmt.Println("Or set a default city: gust --default \"London\"")
-	fmt.Println("Or run the setup wizard: gust --setup")
-	return fmt.Errorf("no city provided")
+	fmt.Println(i18n.T("No city specified and no default city set."))
+	fmt.Println(i18n.T("Specify a city: gust [city name]"))
+	fmt.Println(i18n.T("Or set a default city: gust config set city London"))
+	fmt.Println(i18n.T("Or run the setup wizard: gust setup"))
+	return errors.New(i18n.T("no city provided"))
+}
+
+// only a name typed on the command line is disambiguated. The default city and saved locations
+// were set on purpose, and a status bar or script using them has no terminal to pick with
+func isTypedCity(command string, cli *CLI, cfg *config.Config) bool {
+	typed := determineCityName(cli.City, cli.cityArgs(command), "")
+	if typed == "" {
+		return false
+	}
+	_, alias := cfg.FindLocation(typed)
+	return !alias
+}
+
+// an ambiguous name becomes the place the user meant, nil means fetch the name as is.
+// the extra search is skipped whenever the cache can answer, which includes an earlier pick
+// for the same name, unless --pick asks for a specific match. A failed search falls back to the name
+func pickCity(city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*models.City, error) {
+	if models.IsGeoURI(city) || cli.Offline {
+		return nil, nil
+	}
+	if cached, err := cache.Load(city, cfg.Provider); err == nil && cached != nil && !cli.Refresh && cli.Pick == 0 && cached.IsFresh(cfg.CacheDuration()) {
+		return nil, nil
+	}
+
+	apiKey := ""
+	if authConfig != nil {
+		apiKey = authConfig.APIKey
+	}
+	provider, err := newProvider(cfg.Provider, cfg.ApiUrl, apiKey, i18n.Language(), cfg.RequestTimeout())
+	if err != nil {
+		output.PrintDebug(fmt.Sprintf("not searching for %q: %v", city, err))
+		return nil, nil
+	}
+
+	matches, err := provider.SearchCities(city)
+	if err != nil {
+		output.PrintDebug(fmt.Sprintf("searching for %q failed, using the name as is: %v", city, err))
+		return nil, nil
+	}
+
+	return chooseCity(city, distinctCities(matches), cli.Pick, cli.Output != "json" && output.IsInteractive(), components.RunCityPicker)
+}
+
+func chooseCity(
+	city string,
+	candidates []models.City,
+	pick int,
+	interactive bool,
+	runPicker func(title string, cities []models.City) (*models.City, error),
+) (*models.City, error) {
+	if pick > 0 {
+		if pick > len(candidates) {
+			return nil, fmt.Errorf(i18n.T("--pick %d is out of range, %q has %d matches"), pick, city, len(candidates))
+		}
+		return &candidates[pick-1], nil
+	}
+
+	if len(candidates) < 2 {
+		return nil, nil
+	}
+
+	if interactive {
+		selected, err := runPicker(i18n.T("Which %s did you mean? 🏙️", city), candidates)
+		if err != nil {
+			return nil, err
+		}
+		if selected == nil {
+			return nil, errors.New(i18n.T("no city selected"))
+		}
+		return selected, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T("%q matches %d places, re-run with --pick N to choose one:", city, len(candidates)))
+	for i, candidate := range candidates {
+		fmt.Fprintf(&sb, "\n  %d. %s", i+1, components.CityLabel(candidate))
+	}
+	return nil, fmt.Errorf("%s", sb.String())
+}
+
+// search results often repeat the same place with slightly different coordinates
+func distinctCities(cities []models.City) []models.City {
+	seen := make(map[string]bool)
+	var distinct []models.City
+	for _, city := range cities {
+		key := strings.ToLower(city.Name + "|" + city.State + "|" + city.Country)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		distinct = append(distinct, city)
+	}
+	return distinct
 }
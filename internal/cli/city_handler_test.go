@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/cache"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDetermineCityName(t *testing.T) {
@@ -58,3 +65,192 @@ func TestHandleMissingCity(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "no city provided", err.Error())
 }
+
+func TestResolveCity(t *testing.T) {
+	cfg := &config.Config{Locations: []config.Location{
+		{Alias: "home", City: "London"},
+		{Alias: "work", City: "Berlin"},
+	}}
+
+	testCases := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{name: "alias with @", input: "@home", expected: "London"},
+		{name: "bare alias", input: "work", expected: "Berlin"},
+		{name: "alias is case insensitive", input: "@Work", expected: "Berlin"},
+		{name: "plain city passes through", input: "Paris", expected: "Paris"},
+		{name: "unknown @ alias", input: "@gym", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			city, err := resolveCity(tc.input, cfg)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, city)
+		})
+	}
+}
+
+func TestSplitCities(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"London", []string{"London"}},
+		{"New York", []string{"New York"}},
+		{"london + paris+tokyo", []string{"london", "paris", "tokyo"}},
+		{"@home + Berlin", []string{"@home", "Berlin"}},
+		{"london + ", []string{"london"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitCities(tc.input))
+		})
+	}
+}
+
+func TestCoordinatesFromFlags(t *testing.T) {
+	lat, lon, tooFar := 51.5, -0.12, 200.0
+
+	coords, err := coordinatesFromFlags(nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, coords, "no flags should fall through to the city name")
+
+	coords, err = coordinatesFromFlags(&lat, &lon)
+	assert.NoError(t, err)
+	assert.Equal(t, "geo:51.5000,-0.1200", coords)
+
+	_, err = coordinatesFromFlags(&lat, nil)
+	assert.Error(t, err, "--lat on its own should be rejected")
+
+	_, err = coordinatesFromFlags(&lat, &tooFar)
+	assert.Error(t, err)
+}
+
+func TestChooseCity(t *testing.T) {
+	springfields := []models.City{
+		{Name: "Springfield", State: "Illinois", Country: "US", Lat: 39.8, Lon: -89.6},
+		{Name: "Springfield", State: "Missouri", Country: "US", Lat: 37.2, Lon: -93.3},
+	}
+	noPicker := func(string, []models.City) (*models.City, error) {
+		t.Fatal("picker should not be shown")
+		return nil, nil
+	}
+
+	t.Run("single match passes through", func(t *testing.T) {
+		picked, err := chooseCity("london", springfields[:1], 0, true, noPicker)
+		assert.NoError(t, err)
+		assert.Nil(t, picked)
+	})
+
+	t.Run("--pick chooses without prompting", func(t *testing.T) {
+		picked, err := chooseCity("springfield", springfields, 2, true, noPicker)
+		assert.NoError(t, err)
+		assert.Equal(t, "Missouri", picked.State)
+	})
+
+	t.Run("--pick out of range", func(t *testing.T) {
+		_, err := chooseCity("springfield", springfields, 3, false, noPicker)
+		assert.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("interactive uses the picker", func(t *testing.T) {
+		picker := func(title string, cities []models.City) (*models.City, error) {
+			assert.Contains(t, title, "springfield")
+			return &cities[1], nil
+		}
+		picked, err := chooseCity("springfield", springfields, 0, true, picker)
+		assert.NoError(t, err)
+		assert.Equal(t, "Missouri", picked.State)
+	})
+
+	t.Run("cancelled picker", func(t *testing.T) {
+		picker := func(string, []models.City) (*models.City, error) { return nil, nil }
+		_, err := chooseCity("springfield", springfields, 0, true, picker)
+		assert.ErrorContains(t, err, "no city selected")
+	})
+
+	t.Run("non interactive lists the candidates", func(t *testing.T) {
+		_, err := chooseCity("springfield", springfields, 0, false, noPicker)
+		assert.ErrorContains(t, err, "--pick N")
+		assert.ErrorContains(t, err, "1. Springfield - Illinois, US")
+		assert.ErrorContains(t, err, "2. Springfield - Missouri, US")
+	})
+}
+
+func TestDistinctCities(t *testing.T) {
+	cities := []models.City{
+		{Name: "London", Country: "GB", Lat: 51.5},
+		{Name: "London", Country: "GB", Lat: 51.51},
+		{Name: "London", State: "Ontario", Country: "CA"},
+	}
+
+	distinct := distinctCities(cities)
+	assert.Len(t, distinct, 2)
+	assert.Equal(t, 51.5, distinct[0].Lat, "the first result should be kept")
+}
+
+func TestIsTypedCity(t *testing.T) {
+	cfg := &config.Config{DefaultCity: "London", Locations: []config.Location{{Alias: "home", City: "Leeds"}}}
+
+	typed := &CLI{}
+	typed.Weather.Args = []string{"springfield"}
+	assert.True(t, isTypedCity("", typed, cfg))
+	assert.True(t, isTypedCity("", &CLI{City: "springfield"}, cfg))
+
+	assert.False(t, isTypedCity("", &CLI{}, cfg), "the default city isn't picked")
+	alias := &CLI{}
+	alias.Weather.Args = []string{"@home"}
+	assert.False(t, isTypedCity("", alias, cfg), "saved locations aren't picked")
+	assert.False(t, isTypedCity("", &CLI{City: "home"}, cfg))
+}
+
+func TestPickCity_RemembersPick(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetCacheDir := cache.GetCacheDir
+	defer func() { cache.GetCacheDir = originalGetCacheDir }()
+	cache.GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	illinois := models.City{Name: "Springfield", Country: "US", State: "Illinois", Lat: 39.8017, Lon: -89.6436}
+	missouri := models.City{Name: "Springfield", Country: "US", State: "Missouri", Lat: 37.2153, Lon: -93.2982}
+
+	client := new(MockWeatherClient)
+	client.On("SearchCities", "springfield").Return([]models.City{illinois, missouri}, nil).Once()
+	client.On("GetWeatherByCoords", models.Coordinates{Lat: missouri.Lat, Lon: missouri.Lon}).
+		Return(&api.WeatherResponse{City: &models.City{Name: "37.2153, -93.2982"}, Weather: createTestWeather()}, nil)
+
+	originalNewProvider := newProvider
+	defer func() { newProvider = originalNewProvider }()
+	newProvider = func(name, baseURL, apiKey, lang string, timeout time.Duration) (api.Provider, error) {
+		return client, nil
+	}
+
+	cfg := &config.Config{}
+	picked, err := pickCity("springfield", cfg, nil, &CLI{Pick: 2, Output: "json"})
+	require.NoError(t, err)
+	require.NotNil(t, picked)
+	assert.Equal(t, "Missouri", picked.State)
+
+	_, err = loadPickedCity(context.Background(), "springfield", picked, loadWeatherQuietly, cfg, nil, &CLI{})
+	require.NoError(t, err)
+
+	// a second search would list the matches and fail without --pick
+	picked, err = pickCity("springfield", cfg, nil, &CLI{Output: "json"})
+	assert.NoError(t, err)
+	assert.Nil(t, picked)
+
+	weather, err := loadWeatherQuietly(context.Background(), "springfield", cfg, nil, &CLI{})
+	require.NoError(t, err)
+	assert.Equal(t, "Missouri", weather.City.State)
+	client.AssertExpectations(t)
+}
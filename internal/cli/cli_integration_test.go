@@ -77,11 +77,11 @@ func TestWeatherFlowIntegration(t *testing.T) {
 			mockRenderer.On("RenderCompactWeather", cityData, weatherData, mockConfig).Return()
 
 			cli := &CLI{
-				City: tc.cityFlag,
-				Args: tc.args,
+				City:    tc.cityFlag,
+				Weather: WeatherCmd{Args: tc.args},
 			}
 
-			city := determineCityName(cli.City, cli.Args, tc.defaultCity)
+			city := determineCityName(cli.City, cli.Weather.Args, tc.defaultCity)
 
 			assert.Equal(t, tc.expectedCity, city)
 
@@ -91,17 +91,17 @@ func TestWeatherFlowIntegration(t *testing.T) {
 
 			testRenderWeatherView := func(cli *CLI, renderer renderer.WeatherRenderer, city *models.City, weather *models.OneCallResponse, defaultView string, cfg *config.Config) {
 				switch {
-				case cli.Alerts:
+				case cli.Weather.Alerts:
 					renderer.RenderAlerts(city, weather, cfg)
-				case cli.Hourly:
+				case cli.Weather.Hourly:
 					renderer.RenderHourlyForecast(city, weather, cfg)
-				case cli.Daily:
+				case cli.Weather.Daily:
 					renderer.RenderDailyForecast(city, weather, cfg)
-				case cli.Full:
+				case cli.Weather.Full:
 					renderer.RenderFullWeather(city, weather, cfg)
-				case cli.Compact:
+				case cli.Weather.Compact:
 					renderer.RenderCompactWeather(city, weather, cfg)
-				case cli.Detailed:
+				case cli.Weather.Detailed:
 					renderer.RenderCurrentWeather(city, weather, cfg)
 				default:
 					switch defaultView {
@@ -140,13 +140,13 @@ func TestViewSelectionIntegration(t *testing.T) {
 	}{
 		{
 			name:           "cli flag overrides defaults",
-			cli:            &CLI{Hourly: true},
+			cli:            &CLI{Weather: WeatherCmd{Hourly: true}},
 			defaultView:    "compact",
 			expectedMethod: "RenderHourlyForecast",
 		},
 		{
 			name:           "multiple flags follow prio",
-			cli:            &CLI{Hourly: true, Daily: true, Compact: true},
+			cli:            &CLI{Weather: WeatherCmd{Hourly: true, Daily: true, Compact: true}},
 			defaultView:    "full",
 			expectedMethod: "RenderHourlyForecast",
 		},
@@ -203,17 +203,17 @@ func TestViewSelectionIntegration(t *testing.T) {
 				realConfig := &config.Config{ShowTips: true}
 
 				switch {
-				case cli.Alerts:
+				case cli.Weather.Alerts:
 					renderer.RenderAlerts(city, weather, realConfig)
-				case cli.Hourly:
+				case cli.Weather.Hourly:
 					renderer.RenderHourlyForecast(city, weather, realConfig)
-				case cli.Daily:
+				case cli.Weather.Daily:
 					renderer.RenderDailyForecast(city, weather, realConfig)
-				case cli.Full:
+				case cli.Weather.Full:
 					renderer.RenderFullWeather(city, weather, realConfig)
-				case cli.Compact:
+				case cli.Weather.Compact:
 					renderer.RenderCompactWeather(city, weather, realConfig)
-				case cli.Detailed:
+				case cli.Weather.Detailed:
 					renderer.RenderCurrentWeather(city, weather, realConfig)
 				default:
 					switch defaultView {
@@ -286,11 +286,11 @@ func TestCityDeterminationIntegration(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cli := &CLI{
-				City: tc.cityFlag,
-				Args: tc.args,
+				City:    tc.cityFlag,
+				Weather: WeatherCmd{Args: tc.args},
 			}
 
-			city := determineCityName(cli.City, cli.Args, tc.defaultCity)
+			city := determineCityName(cli.City, cli.Weather.Args, tc.defaultCity)
 
 			assert.Equal(t, tc.expectedCity, city)
 		})
@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"context"
 	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/renderer"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -36,6 +38,18 @@ func (m *MockWeatherRenderer) RenderCompactWeather(city *models.City, weather *m
 	m.Called(city, weather, cfg)
 }
 
+func (m *MockWeatherRenderer) RenderHourlyGraph(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	m.Called(city, weather, cfg)
+}
+
+func (m *MockWeatherRenderer) RenderNowcast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	m.Called(city, weather, cfg)
+}
+
+func (m *MockWeatherRenderer) RenderSummary(locations []renderer.LocationWeather, cfg *config.Config) {
+	m.Called(locations, cfg)
+}
+
 // auth config handling
 type MockAuthConfig struct {
 	mock.Mock
@@ -86,12 +100,33 @@ func (m *MockWeatherClient) GetWeather(cityName string) (*api.WeatherResponse, e
 	return args.Get(0).(*api.WeatherResponse), args.Error(1)
 }
 
+func (m *MockWeatherClient) GetWeatherByCoords(coords models.Coordinates) (*api.WeatherResponse, error) {
+	args := m.Called(coords)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.WeatherResponse), args.Error(1)
+}
+
 func (m *MockWeatherClient) SearchCities(query string) ([]models.City, error) {
 	args := m.Called(query)
 	cities, _ := args.Get(0).([]models.City)
 	return cities, args.Error(1)
 }
 
+// the context variants share the plain methods' expectations
+func (m *MockWeatherClient) GetWeatherContext(ctx context.Context, cityName string) (*api.WeatherResponse, error) {
+	return m.GetWeather(cityName)
+}
+
+func (m *MockWeatherClient) GetWeatherByCoordsContext(ctx context.Context, coords models.Coordinates) (*api.WeatherResponse, error) {
+	return m.GetWeatherByCoords(coords)
+}
+
+func (m *MockWeatherClient) SearchCitiesContext(ctx context.Context, query string) ([]models.City, error) {
+	return m.SearchCities(query)
+}
+
 // create a test models.City
 func createTestCity() *models.City {
 	return &models.City{
@@ -1,32 +1,137 @@
 package cli
 
 import (
+	"strings"
+	"time"
+
 	"github.com/alecthomas/kong"
 )
 
 type CLI struct {
-	// config flags
-	City    string `name:"city" short:"C" help:"City name"`
-	Default string `name:"default" short:"D" help:"Set a new default city"`
-	ApiUrl  string `name:"api" short:"A" help:"Set custom API server URL"`
-	Login   bool   `name:"login" short:"L" help:"Authenticate with GitHub"`
-	Setup   bool   `name:"setup" short:"S" help:"Run the setup wizard"`
-	Units   string `name:"units" short:"U" help:"Temperature units (metric, imperial, standard)"`
-	ApiKey  string `name:"api-key" short:"K" help:"Set your api key (either gust or openweathermap)"`
-
-	// display flags
+	// global flags
+	City    string   `name:"city" short:"C" help:"City name"`
+	Lat     *float64 `name:"lat" help:"Latitude, use with --lon instead of a city name"`
+	Lon     *float64 `name:"lon" help:"Longitude, use with --lat instead of a city name (negative values need --lon=-0.12)"`
+	Pick    int      `name:"pick" placeholder:"N" help:"Choose match N when a city name is ambiguous"`
+	Output  string   `name:"output" short:"o" enum:"terminal,json" default:"terminal" help:"Output format (terminal, json)"`
+	Refresh bool     `name:"refresh" short:"R" help:"Ignore cached weather and fetch fresh data"`
+	Offline bool     `name:"offline" short:"O" help:"Only use cached weather, even if it is stale"`
+	All     bool     `name:"all" help:"Show a compact row for every saved location"`
+	Pretty  bool     `name:"pretty" short:"p" help:"Open a live full-screen dashboard"`
+
+	LocalTime bool `name:"local-time" help:"Show times in this machine's timezone instead of the location's"`
+	NoColor   bool `name:"no-color" help:"Print without color, same as setting NO_COLOR"`
+	Plain     bool `name:"plain" help:"Print ASCII instead of emoji, without color, e.g. for logs"`
+
+	// deprecated flags from before the subcommands, hidden but kept so existing scripts keep working
+	Default  string `name:"default" short:"D" hidden:"" help:"Set a new default city (use 'gust config set city')"`
+	ApiUrl   string `name:"api" short:"A" hidden:"" help:"Set custom API server URL (use 'gust config set api_url')"`
+	Login    bool   `name:"login" short:"L" hidden:"" help:"Authenticate with GitHub (use 'gust auth login')"`
+	RunSetup bool   `name:"setup" short:"S" hidden:"" help:"Run the setup wizard (use 'gust setup')"`
+	Units    string `name:"units" short:"U" hidden:"" help:"Temperature units (use 'gust config set units')"`
+	ApiKey   string `name:"api-key" short:"K" hidden:"" help:"Set your api key (use 'gust auth key')"`
+
+	// weather commands
+	Weather WeatherCmd `cmd:"" default:"withargs" help:"Show weather in your default view (used when no command is given)"`
+	Now     ViewCmd    `cmd:"" help:"Show today's detailed weather"`
+	Compact ViewCmd    `cmd:"" help:"Show today's compact weather"`
+	Hourly  ViewCmd    `cmd:"" help:"Show 24-hour (hourly) forecast"`
+	Graph   ViewCmd    `cmd:"" help:"Chart temperature and precipitation over the next 48 hours"`
+	Nowcast ViewCmd    `cmd:"" help:"Show when rain starts or stops in the next hour"`
+	Daily   ViewCmd    `cmd:"" help:"Show 5-day forecast"`
+	Alerts  ViewCmd    `cmd:"" help:"Show weather alerts"`
+	Full    ViewCmd    `cmd:"" help:"Show today, 5-day and weather alert forecasts"`
+	Watch   WatchCmd   `cmd:"" help:"Watch saved locations and notify about new weather alerts"`
+	Check   ViewCmd    `cmd:"" help:"Check your rules against the forecast, exits with status 2 when one matches"`
+
+	// settings commands
+	Config    ConfigCmd    `cmd:"" help:"View or change configuration"`
+	Locations LocationsCmd `cmd:"" help:"Manage saved locations"`
+	Rules     RulesCmd     `cmd:"" help:"Manage weather rules for check and watch"`
+	Auth      AuthCmd      `cmd:"" help:"Manage authentication"`
+	Setup     SetupCmd     `cmd:"" help:"Run the setup wizard"`
+}
+
+// bare `gust [city]`, display flags are kept so existing aliases keep working
+type WeatherCmd struct {
 	Compact  bool `name:"compact" short:"c" help:"Show today's compact weather view"`
 	Detailed bool `name:"detailed" short:"d" help:"Show today's detailed weather view"`
 	Full     bool `name:"full" short:"f" help:"Show today, 5-day and weather alert forecasts"`
 	Daily    bool `name:"daily" short:"y" help:"Show 5-day forecast"`
 	Hourly   bool `name:"hourly" short:"r" help:"Show 24-hour (hourly) forecast"`
+	Graph    bool `name:"graph" short:"g" help:"Chart temperature and precipitation over the next 48 hours"`
+	Nowcast  bool `name:"nowcast" short:"n" help:"Show when rain starts or stops in the next hour"`
 	Alerts   bool `name:"alerts" short:"a" help:"Show weather alerts"`
-	Pretty   bool `name:"pretty" short:"p" hidden:"" help:"Use the pretty UI - tbc"` // TODO: not implemented yet but including it here to keep me motivated
 
-	// args (city name)
 	Args []string `arg:"" optional:"" help:"City name (can be multiple words)"`
 }
 
+type ViewCmd struct {
+	Args []string `arg:"" optional:"" help:"City name (can be multiple words)"`
+}
+
+type WatchCmd struct {
+	Interval time.Duration `name:"interval" help:"Time between checks, e.g. 15m (default: the cache ttl, spread out for many locations)"`
+	Once     bool          `name:"once" help:"Check once and exit, e.g. from cron"`
+}
+
+type ConfigCmd struct {
+	Set  ConfigSetCmd `cmd:"" help:"Set a configuration value"`
+	Get  ConfigGetCmd `cmd:"" help:"Print a configuration value"`
+	List struct{}     `cmd:"" help:"List all configuration values"`
+}
+
+type ConfigSetCmd struct {
+	Key   string   `arg:"" help:"Configuration key (see 'gust config list')"`
+	Value []string `arg:"" help:"New value (can be multiple words)"`
+}
+
+type ConfigGetCmd struct {
+	Key string `arg:"" help:"Configuration key (see 'gust config list')"`
+}
+
+type LocationsCmd struct {
+	Add    LocationsAddCmd    `cmd:"" help:"Save a city under an alias"`
+	Remove LocationsRemoveCmd `cmd:"" help:"Remove a saved location"`
+	List   struct{}           `cmd:"" help:"List saved locations"`
+}
+
+type LocationsAddCmd struct {
+	Alias string   `arg:"" help:"Alias to save the city under, e.g. home"`
+	City  []string `arg:"" help:"City name (can be multiple words)"`
+}
+
+type LocationsRemoveCmd struct {
+	Alias string `arg:"" help:"Alias of the location to remove"`
+}
+
+type RulesCmd struct {
+	Add    RulesAddCmd    `cmd:"" help:"Add a rule, e.g. gust rules add temp below 0 in the next 12h"`
+	Remove RulesRemoveCmd `cmd:"" help:"Remove a rule by its number in 'gust rules list'"`
+	List   struct{}       `cmd:"" help:"List rules"`
+}
+
+type RulesAddCmd struct {
+	Rule []string `arg:"" help:"The rule (can be multiple words)"`
+}
+
+type RulesRemoveCmd struct {
+	Number int `arg:"" help:"Number of the rule to remove"`
+}
+
+type AuthCmd struct {
+	Login  struct{}   `cmd:"" help:"Authenticate with GitHub"`
+	Logout struct{}   `cmd:"" help:"Remove stored credentials"`
+	Status struct{}   `cmd:"" help:"Show authentication status"`
+	Key    AuthKeyCmd `cmd:"" help:"Store your own api key (either gust or openweathermap)"`
+}
+
+type AuthKeyCmd struct {
+	ApiKey string `arg:"" name:"api-key" help:"The api key to store"`
+}
+
+type SetupCmd struct{}
+
 func NewApp() (*kong.Kong, *CLI) {
 	cli := &CLI{}
 	parser := kong.Must(cli,
@@ -40,3 +145,72 @@ func NewApp() (*kong.Kong, *CLI) {
 	)
 	return parser, cli
 }
+
+// "config set <key> <value>" -> "config set"
+func commandPath(command string) string {
+	var parts []string
+	for _, part := range strings.Fields(command) {
+		if !strings.HasPrefix(part, "<") {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// positional city args for whichever weather command was selected
+func (c *CLI) cityArgs(command string) []string {
+	switch command {
+	case "now":
+		return c.Now.Args
+	case "compact":
+		return c.Compact.Args
+	case "hourly":
+		return c.Hourly.Args
+	case "graph":
+		return c.Graph.Args
+	case "nowcast":
+		return c.Nowcast.Args
+	case "daily":
+		return c.Daily.Args
+	case "alerts":
+		return c.Alerts.Args
+	case "full":
+		return c.Full.Args
+	case "check":
+		return c.Check.Args
+	default:
+		return c.Weather.Args
+	}
+}
+
+// the view a command asks for, empty means fall back to the configured default
+func (c *CLI) selectedView(command string) string {
+	switch command {
+	case "now":
+		return "detailed"
+	case "compact", "hourly", "graph", "nowcast", "daily", "alerts", "full":
+		return command
+	}
+
+	flags := c.Weather
+	switch {
+	case flags.Alerts:
+		return "alerts"
+	case flags.Hourly:
+		return "hourly"
+	case flags.Graph:
+		return "graph"
+	case flags.Nowcast:
+		return "nowcast"
+	case flags.Daily:
+		return "daily"
+	case flags.Full:
+		return "full"
+	case flags.Compact:
+		return "compact"
+	case flags.Detailed:
+		return "detailed"
+	default:
+		return ""
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewApp(t *testing.T) {
@@ -17,18 +18,15 @@ func TestNewApp(t *testing.T) {
 		value any
 	}{
 		{"City", cli.City},
-		{"Default", cli.Default},
-		{"ApiUrl", cli.ApiUrl},
-		{"Login", cli.Login},
-		{"Setup", cli.Setup},
-		{"Compact", cli.Compact},
-		{"Detailed", cli.Detailed},
-		{"Full", cli.Full},
-		{"Daily", cli.Daily},
-		{"Hourly", cli.Hourly},
-		{"Alerts", cli.Alerts},
-		{"Units", cli.Units},
+		{"Refresh", cli.Refresh},
+		{"Offline", cli.Offline},
 		{"Pretty", cli.Pretty},
+		{"Weather.Compact", cli.Weather.Compact},
+		{"Weather.Detailed", cli.Weather.Detailed},
+		{"Weather.Full", cli.Weather.Full},
+		{"Weather.Daily", cli.Weather.Daily},
+		{"Weather.Hourly", cli.Weather.Hourly},
+		{"Weather.Alerts", cli.Weather.Alerts},
 	}
 
 	for _, field := range fields {
@@ -40,5 +38,184 @@ func TestNewApp(t *testing.T) {
 		}
 	}
 
-	assert.Empty(t, cli.Args)
+	assert.Empty(t, cli.Weather.Args)
+}
+
+func TestParseCommands(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedPath string
+		expectedView string
+		expectedCity []string
+	}{
+		{
+			name:         "bare gust",
+			args:         []string{},
+			expectedPath: "weather",
+			expectedView: "",
+		},
+		{
+			name:         "bare city",
+			args:         []string{"new", "york"},
+			expectedPath: "weather",
+			expectedView: "",
+			expectedCity: []string{"new", "york"},
+		},
+		{
+			name:         "legacy display flag",
+			args:         []string{"--compact", "paris"},
+			expectedPath: "weather",
+			expectedView: "compact",
+			expectedCity: []string{"paris"},
+		},
+		{
+			name:         "now subcommand",
+			args:         []string{"now", "london"},
+			expectedPath: "now",
+			expectedView: "detailed",
+			expectedCity: []string{"london"},
+		},
+		{
+			name:         "daily subcommand without city",
+			args:         []string{"daily"},
+			expectedPath: "daily",
+			expectedView: "daily",
+		},
+		{
+			name:         "hourly subcommand with global flag",
+			args:         []string{"hourly", "tokyo", "--refresh"},
+			expectedPath: "hourly",
+			expectedView: "hourly",
+			expectedCity: []string{"tokyo"},
+		},
+		{
+			name:         "config set",
+			args:         []string{"config", "set", "city", "new", "york"},
+			expectedPath: "config set",
+		},
+		{
+			name:         "saved location alias",
+			args:         []string{"@home"},
+			expectedPath: "weather",
+			expectedView: "",
+			expectedCity: []string{"@home"},
+		},
+		{
+			name:         "graph flag",
+			args:         []string{"-g", "leeds"},
+			expectedPath: "weather",
+			expectedView: "graph",
+			expectedCity: []string{"leeds"},
+		},
+		{
+			name:         "graph subcommand",
+			args:         []string{"graph"},
+			expectedPath: "graph",
+			expectedView: "graph",
+		},
+		{
+			name:         "nowcast flag",
+			args:         []string{"--nowcast"},
+			expectedPath: "weather",
+			expectedView: "nowcast",
+		},
+		{
+			name:         "geo uri",
+			args:         []string{"hourly", "geo:51.5,-0.12"},
+			expectedPath: "hourly",
+			expectedView: "hourly",
+			expectedCity: []string{"geo:51.5,-0.12"},
+		},
+		{
+			name:         "all saved locations",
+			args:         []string{"--all"},
+			expectedPath: "weather",
+			expectedView: "",
+		},
+		{
+			name:         "locations add",
+			args:         []string{"locations", "add", "home", "new", "york"},
+			expectedPath: "locations add",
+		},
+		{
+			name:         "check with city",
+			args:         []string{"check", "@home"},
+			expectedPath: "check",
+			expectedView: "",
+			expectedCity: []string{"@home"},
+		},
+		{
+			name:         "rules add",
+			args:         []string{"rules", "add", "temp", "below", "0", "tomorrow"},
+			expectedPath: "rules add",
+		},
+		{
+			name:         "auth status",
+			args:         []string{"auth", "status"},
+			expectedPath: "auth status",
+		},
+		{
+			name:         "setup",
+			args:         []string{"setup"},
+			expectedPath: "setup",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app, cli := NewApp()
+			ctx, err := app.Parse(tc.args)
+			require.NoError(t, err)
+
+			command := commandPath(ctx.Command())
+			assert.Equal(t, tc.expectedPath, command)
+			assert.Equal(t, tc.expectedView, cli.selectedView(command))
+			if tc.expectedCity != nil {
+				assert.Equal(t, tc.expectedCity, cli.cityArgs(command))
+			}
+		})
+	}
+}
+
+func TestParseCoordinateFlags(t *testing.T) {
+	app, cli := NewApp()
+	_, err := app.Parse([]string{"--lat", "51.5", "--lon=-0.12", "daily"})
+	require.NoError(t, err)
+
+	require.NotNil(t, cli.Lat)
+	require.NotNil(t, cli.Lon)
+	assert.Equal(t, 51.5, *cli.Lat)
+	assert.Equal(t, -0.12, *cli.Lon)
+}
+
+func TestParseConfigSetValue(t *testing.T) {
+	app, cli := NewApp()
+	_, err := app.Parse([]string{"config", "set", "city", "New", "York"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "city", cli.Config.Set.Key)
+	assert.Equal(t, []string{"New", "York"}, cli.Config.Set.Value)
+}
+
+func TestParseDeprecatedFlags(t *testing.T) {
+	// the flags from before the subcommands still parse so existing scripts keep working
+	app, cli := NewApp()
+	ctx, err := app.Parse([]string{"--units", "imperial", "-D", "leeds", "--api", "https://api.example.com", "--api-key", "abc", "--login", "--setup", "london"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "weather", commandPath(ctx.Command()))
+	assert.Equal(t, "imperial", cli.Units)
+	assert.Equal(t, "leeds", cli.Default)
+	assert.Equal(t, "https://api.example.com", cli.ApiUrl)
+	assert.Equal(t, "abc", cli.ApiKey)
+	assert.True(t, cli.Login)
+	assert.True(t, cli.RunSetup)
+	assert.Equal(t, []string{"london"}, cli.cityArgs("weather"))
+}
+
+func TestCommandPath(t *testing.T) {
+	assert.Equal(t, "config set", commandPath("config set <key> <value>"))
+	assert.Equal(t, "weather", commandPath("weather <args>"))
+	assert.Equal(t, "auth status", commandPath("auth status"))
 }
@@ -2,64 +2,312 @@ package cli
 
 import (
 	"fmt"
-	"os"
-	"time"
+	"strconv"
+	"strings"
 
+	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/styles"
+	"github.com/josephburgess/gust/internal/units"
 )
 
-func handleConfigUpdates(cli *CLI, cfg *config.Config) (bool, error) {
-	updated := false
+// a setting that can be changed with `gust config set`
+type configKey struct {
+	name string
+	help string
+	get  func(cfg *config.Config) string
+	set  func(cfg *config.Config, value string) error
+}
 
-	if cli.ApiUrl != "" {
-		cfg.ApiUrl = cli.ApiUrl
-		updated = true
+// built on each call so the help follows the language picked at startup
+func configKeys() []configKey {
+	return []configKey{
+		{
+			name: "city",
+			help: i18n.T("Default city"),
+			get:  func(cfg *config.Config) string { return cfg.DefaultCity },
+			set: func(cfg *config.Config, value string) error {
+				cfg.DefaultCity = value
+				// coordinates picked for the old city would otherwise win
+				cfg.DefaultCoords = nil
+				cfg.DefaultCountry = ""
+				return nil
+			},
+		},
+		{
+			name: "coords",
+			help: i18n.T("Default coordinates as geo:<lat>,<lon>, empty to use the city name"),
+			get: func(cfg *config.Config) string {
+				if cfg.DefaultCoords == nil {
+					return ""
+				}
+				return cfg.DefaultCoords.GeoURI()
+			},
+			set: func(cfg *config.Config, value string) error {
+				if value == "" {
+					cfg.DefaultCoords = nil
+					return nil
+				}
+				if !models.IsGeoURI(value) {
+					value = "geo:" + value
+				}
+				coords, err := models.ParseGeoURI(value)
+				if err != nil {
+					return err
+				}
+				cfg.DefaultCoords = &coords
+				return nil
+			},
+		},
+		{
+			name: "units",
+			help: i18n.T("Units (metric, imperial, standard)"),
+			get:  func(cfg *config.Config) string { return cfg.Units },
+			set: func(cfg *config.Config, value string) error {
+				if !isValidUnit(value) {
+					return fmt.Errorf(i18n.T("invalid units %q, must be one of: metric, imperial, standard"), value)
+				}
+				cfg.Units = value
+				return nil
+			},
+		},
+		unitKey("temperature_unit", i18n.T("Temperature unit"), units.Temperatures,
+			func(cfg *config.Config) *string { return &cfg.TemperatureUnit },
+			func(units config.DisplayUnits) string { return units.Temperature }),
+		unitKey("wind_unit", i18n.T("Wind speed unit"), units.WindSpeeds,
+			func(cfg *config.Config) *string { return &cfg.WindUnit },
+			func(units config.DisplayUnits) string { return units.Wind }),
+		unitKey("pressure_unit", i18n.T("Pressure unit"), units.Pressures,
+			func(cfg *config.Config) *string { return &cfg.PressureUnit },
+			func(units config.DisplayUnits) string { return units.Pressure }),
+		unitKey("precipitation_unit", i18n.T("Rain and snow unit"), units.Precipitations,
+			func(cfg *config.Config) *string { return &cfg.PrecipitationUnit },
+			func(units config.DisplayUnits) string { return units.Precipitation }),
+		unitKey("visibility_unit", i18n.T("Visibility unit"), units.Distances,
+			func(cfg *config.Config) *string { return &cfg.VisibilityUnit },
+			func(units config.DisplayUnits) string { return units.Visibility }),
+		{
+			name: "view",
+			help: i18n.T("Default view (default, compact, daily, hourly, graph, nowcast, full)"),
+			get:  func(cfg *config.Config) string { return cfg.DefaultView },
+			set: func(cfg *config.Config, value string) error {
+				if !isValidView(value) {
+					return fmt.Errorf(i18n.T("invalid view %q, must be one of: default, compact, daily, hourly, graph, nowcast, full"), value)
+				}
+				cfg.DefaultView = value
+				return nil
+			},
+		},
+		{
+			name: "tips",
+			help: i18n.T("Show weather tips (true, false)"),
+			get:  func(cfg *config.Config) string { return strconv.FormatBool(cfg.ShowTips) },
+			set: func(cfg *config.Config, value string) error {
+				enabled, err := parseBool(value)
+				if err != nil {
+					return err
+				}
+				cfg.ShowTips = enabled
+				return nil
+			},
+		},
+		{
+			name: "max_tips",
+			help: i18n.T("Most tips shown under the detailed view (0 for the default)"),
+			get:  func(cfg *config.Config) string { return strconv.Itoa(cfg.TipLimit()) },
+			set: func(cfg *config.Config, value string) error {
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 0 {
+					return fmt.Errorf(i18n.T("invalid max tips %q, must be a whole number"), value)
+				}
+				cfg.MaxTips = n
+				return nil
+			},
+		},
+		{
+			name: "local_time",
+			help: i18n.T("Show times in this machine's timezone instead of the location's (true, false)"),
+			get:  func(cfg *config.Config) string { return strconv.FormatBool(cfg.LocalTime) },
+			set: func(cfg *config.Config, value string) error {
+				enabled, err := parseBool(value)
+				if err != nil {
+					return err
+				}
+				cfg.LocalTime = enabled
+				return nil
+			},
+		},
+		{
+			name: "provider",
+			help: i18n.T("Weather provider (breeze, openweathermap, open-meteo)"),
+			get: func(cfg *config.Config) string {
+				if cfg.Provider == "" {
+					return api.ProviderBreeze
+				}
+				return cfg.Provider
+			},
+			set: func(cfg *config.Config, value string) error {
+				if !api.IsValidProvider(value) {
+					return fmt.Errorf(i18n.T("invalid provider %q, must be one of: breeze, openweathermap, open-meteo"), value)
+				}
+				cfg.Provider = value
+				return nil
+			},
+		},
+		{
+			name: "language",
+			help: i18n.T("Language for everything gust prints (%s), empty to follow LANG", strings.Join(i18n.Supported(), ", ")),
+			get:  func(cfg *config.Config) string { return cfg.Language },
+			set: func(cfg *config.Config, value string) error {
+				value = strings.ToLower(value)
+				if value != "" && !i18n.IsSupported(value) {
+					return fmt.Errorf(i18n.T("invalid language %q, must be one of: %s"), value, strings.Join(i18n.Supported(), ", "))
+				}
+				cfg.Language = value
+				return nil
+			},
+		},
+		{
+			name: "theme",
+			help: i18n.T("Color theme (%s) or the name of a file in ~/.config/gust/themes", strings.Join(styles.ThemeNames(), ", ")),
+			get: func(cfg *config.Config) string {
+				if cfg.Theme == "" {
+					return styles.DefaultTheme
+				}
+				return cfg.Theme
+			},
+			set: func(cfg *config.Config, value string) error {
+				if _, err := loadTheme(value); err != nil {
+					return err
+				}
+				cfg.Theme = value
+				return nil
+			},
+		},
+		{
+			name: "api_url",
+			help: i18n.T("Custom API server URL (mostly for development)"),
+			get:  func(cfg *config.Config) string { return cfg.ApiUrl },
+			set: func(cfg *config.Config, value string) error {
+				cfg.ApiUrl = value
+				return nil
+			},
+		},
+		{
+			name: "cache_ttl",
+			help: i18n.T("Minutes to cache weather for (0 for the default, negative to disable)"),
+			get:  func(cfg *config.Config) string { return cfg.CacheDuration().String() },
+			set: func(cfg *config.Config, value string) error {
+				minutes, err := strconv.Atoi(value)
+				if err != nil {
+					return fmt.Errorf(i18n.T("invalid cache ttl %q, must be a whole number of minutes"), value)
+				}
+				cfg.CacheTTL = minutes
+				return nil
+			},
+		},
+		{
+			name: "timeout",
+			help: i18n.T("Seconds a weather request may take before giving up (0 for the default)"),
+			get: func(cfg *config.Config) string {
+				if timeout := cfg.RequestTimeout(); timeout > 0 {
+					return timeout.String()
+				}
+				return api.DefaultTimeout.String()
+			},
+			set: func(cfg *config.Config, value string) error {
+				seconds, err := strconv.Atoi(value)
+				if err != nil || seconds < 0 {
+					return fmt.Errorf(i18n.T("invalid timeout %q, must be a whole number of seconds"), value)
+				}
+				cfg.Timeout = seconds
+				return nil
+			},
+		},
+		{
+			name: "notify_command",
+			help: i18n.T("Command gust watch runs for new alerts, empty for desktop notifications"),
+			get:  func(cfg *config.Config) string { return cfg.NotifyCommand },
+			set: func(cfg *config.Config, value string) error {
+				cfg.NotifyCommand = value
+				return nil
+			},
+		},
 	}
+}
 
-	if cli.ApiKey != "" {
-		authConfig, _ := config.LoadAuthConfig()
-
-		newAuthConfig := &config.AuthConfig{
-			APIKey:     cli.ApiKey,
-			ServerURL:  cfg.ApiUrl,
-			LastAuth:   time.Now(),
-			GithubUser: "",
-		}
+// overrides a single quantity, "default" goes back to following units
+func unitKey(name, label string, options []string, field func(cfg *config.Config) *string, resolved func(units config.DisplayUnits) string) configKey {
+	return configKey{
+		name: name,
+		help: i18n.T("%s (%s, or default to follow units)", label, strings.Join(options, ", ")),
+		get:  func(cfg *config.Config) string { return resolved(cfg.DisplayUnits()) },
+		set: func(cfg *config.Config, value string) error {
+			if value == "" || strings.EqualFold(value, "default") {
+				*field(cfg) = ""
+				return nil
+			}
+			unit, err := units.Normalize(options, value)
+			if err != nil {
+				return err
+			}
+			*field(cfg) = unit
+			return nil
+		},
+	}
+}
 
-		if authConfig != nil {
-			newAuthConfig.GithubUser = authConfig.GithubUser
+func findConfigKey(name string) (configKey, error) {
+	keys := configKeys()
+	for _, key := range keys {
+		if key.name == name {
+			return key, nil
 		}
+	}
 
-		if err := config.SaveAuthConfig(newAuthConfig); err != nil {
-			return false, fmt.Errorf("failed to save API key: %w", err)
-		}
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.name
+	}
+	return configKey{}, fmt.Errorf(i18n.T("unknown config key %q, must be one of: %s"), name, strings.Join(names, ", "))
+}
 
-		fmt.Println("API key updated.")
-		updated = true
+func handleConfigSet(cfg *config.Config, name, value string) error {
+	key, err := findConfigKey(name)
+	if err != nil {
+		return err
 	}
 
-	if cli.Units != "" {
-		if !isValidUnit(cli.Units) {
-			fmt.Println("Invalid units. Must be one of: metric, imperial, standard")
-			os.Exit(1)
-		}
-		cfg.Units = cli.Units
-		updated = true
+	if err := key.set(cfg, value); err != nil {
+		return err
 	}
 
-	if cli.Default != "" {
-		cfg.DefaultCity = cli.Default
-		updated = true
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf(i18n.T("failed to save config: %w"), err)
 	}
 
-	if updated {
-		if err := cfg.Save(); err != nil {
-			return false, fmt.Errorf("failed to save config: %w", err)
-		}
-		fmt.Println("Configuration updated.")
+	output.PrintSuccess(i18n.T("%s set to %s", key.name, key.get(cfg)))
+	return nil
+}
+
+func handleConfigGet(cfg *config.Config, name string) error {
+	key, err := findConfigKey(name)
+	if err != nil {
+		return err
 	}
 
-	return updated, nil
+	fmt.Println(key.get(cfg))
+	return nil
+}
+
+func handleConfigList(cfg *config.Config) error {
+	for _, key := range configKeys() {
+		fmt.Printf("%-18s %-20s %s\n", key.name, key.get(cfg), styles.HintStyle.Render(key.help))
+	}
+	return nil
 }
 
 func isValidUnit(unit string) bool {
@@ -71,3 +319,27 @@ func isValidUnit(unit string) bool {
 
 	return validUnits[unit]
 }
+
+func isValidView(view string) bool {
+	validViews := map[string]bool{
+		"default": true,
+		"compact": true,
+		"daily":   true,
+		"hourly":  true,
+		"graph":   true,
+		"nowcast": true,
+		"full":    true,
+	}
+
+	return validViews[view]
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on", "1":
+		return true, nil
+	case "false", "no", "off", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf(i18n.T("invalid value %q, must be true or false"), value)
+}
@@ -1,11 +1,15 @@
 package cli
 
 import (
-	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsValidUnit(t *testing.T) {
@@ -25,94 +29,265 @@ func TestIsValidUnit(t *testing.T) {
 	}
 }
 
-func TestHandleConfigUpdates(t *testing.T) {
+func useTempConfigPath(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetConfigPath := config.GetConfigPath
+	t.Cleanup(func() { config.GetConfigPath = originalGetConfigPath })
+	config.GetConfigPath = func() (string, error) {
+		return filepath.Join(tempDir, "config.json"), nil
+	}
+}
+
+func useTempAuthConfigPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	original := config.GetAuthConfigPath
+	t.Cleanup(func() { config.GetAuthConfigPath = original })
+	config.GetAuthConfigPath = func() (string, error) { return path, nil }
+}
+
+func TestHandleConfigSet(t *testing.T) {
 	createConfig := func() *config.Config {
 		return &config.Config{
 			ApiUrl:      "https://api.example.com",
 			Units:       "metric",
 			DefaultCity: "London",
+			DefaultView: "default",
 		}
 	}
 
 	testCases := []struct {
-		name            string
-		cli             *CLI
-		expectedUpdated bool
-		configMutator   func(*config.Config)
+		name          string
+		key           string
+		value         string
+		expectError   bool
+		configMutator func(*config.Config)
 	}{
 		{
-			name: "update api url",
-			cli: &CLI{
-				ApiUrl: "https://test-api.example.com",
-			},
-			expectedUpdated: true,
+			name:  "update api url",
+			key:   "api_url",
+			value: "https://test-api.example.com",
 			configMutator: func(c *config.Config) {
 				c.ApiUrl = "https://test-api.example.com"
 			},
 		},
 		{
-			name: "update units",
-			cli: &CLI{
-				Units: "imperial",
-			},
-			expectedUpdated: true,
+			name:  "update units",
+			key:   "units",
+			value: "imperial",
 			configMutator: func(c *config.Config) {
 				c.Units = "imperial"
 			},
 		},
 		{
-			name: "update default city",
-			cli: &CLI{
-				Default: "Paris",
+			name:  "update default city",
+			key:   "city",
+			value: "New York",
+			configMutator: func(c *config.Config) {
+				c.DefaultCity = "New York"
+			},
+		},
+		{
+			name:  "enable tips",
+			key:   "tips",
+			value: "yes",
+			configMutator: func(c *config.Config) {
+				c.ShowTips = true
+			},
+		},
+		{
+			name:  "use local time",
+			key:   "local_time",
+			value: "true",
+			configMutator: func(c *config.Config) {
+				c.LocalTime = true
+			},
+		},
+		{
+			name:  "update provider",
+			key:   "provider",
+			value: "open-meteo",
+			configMutator: func(c *config.Config) {
+				c.Provider = "open-meteo"
+			},
+		},
+		{
+			name:  "update cache ttl",
+			key:   "cache_ttl",
+			value: "-1",
+			configMutator: func(c *config.Config) {
+				c.CacheTTL = -1
+			},
+		},
+		{
+			name:  "update timeout",
+			key:   "timeout",
+			value: "30",
+			configMutator: func(c *config.Config) {
+				c.Timeout = 30
+			},
+		},
+		{
+			name:  "update default coords",
+			key:   "coords",
+			value: "51.5,-0.12",
+			configMutator: func(c *config.Config) {
+				c.DefaultCoords = &models.Coordinates{Lat: 51.5, Lon: -0.12}
+			},
+		},
+		{
+			name:  "wind in knots",
+			key:   "wind_unit",
+			value: "Knots",
+			configMutator: func(c *config.Config) {
+				c.WindUnit = "kn"
+			},
+		},
+		{
+			name:  "rain in inches",
+			key:   "precipitation_unit",
+			value: "IN",
+			configMutator: func(c *config.Config) {
+				c.PrecipitationUnit = "in"
+			},
+		},
+		{
+			name:  "german",
+			key:   "language",
+			value: "DE",
+			configMutator: func(c *config.Config) {
+				c.Language = "de"
 			},
-			expectedUpdated: true,
+		},
+		{
+			name:          "unsupported language",
+			key:           "language",
+			value:         "fr",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:  "light theme",
+			key:   "theme",
+			value: "rose-pine-dawn",
 			configMutator: func(c *config.Config) {
-				c.DefaultCity = "Paris"
+				c.Theme = "rose-pine-dawn"
 			},
 		},
 		{
-			name:            "no updates",
-			cli:             &CLI{},
-			expectedUpdated: false,
-			configMutator:   func(c *config.Config) {},
+			name:          "missing theme file",
+			key:           "theme",
+			value:         "neon",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:          "invalid pressure unit",
+			key:           "pressure_unit",
+			value:         "psi",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:          "invalid coords",
+			key:           "coords",
+			value:         "geo:100,0",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:          "invalid units",
+			key:           "units",
+			value:         "celsius",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:          "negative timeout",
+			key:           "timeout",
+			value:         "-5",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:          "invalid view",
+			key:           "view",
+			value:         "sideways",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
+		},
+		{
+			name:          "unknown key",
+			key:           "colour",
+			value:         "blue",
+			expectError:   true,
+			configMutator: func(c *config.Config) {},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			useTempConfigPath(t)
+
 			initialConfig := createConfig()
 			expectedConfig := createConfig()
 			tc.configMutator(expectedConfig)
 
-			testHandleConfigUpdates := func(cli *CLI, cfg *config.Config) (bool, error) {
-				updated := false
+			err := handleConfigSet(initialConfig, tc.key, tc.value)
 
-				if cli.ApiUrl != "" {
-					cfg.ApiUrl = cli.ApiUrl
-					updated = true
-				}
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
 
-				if cli.Units != "" {
-					if !isValidUnit(cli.Units) {
-						return false, fmt.Errorf("invalid units")
-					}
-					cfg.Units = cli.Units
-					updated = true
-				}
+				saved, err := config.Load()
+				require.NoError(t, err)
+				assert.Equal(t, expectedConfig, saved)
+			}
+			assert.Equal(t, expectedConfig, initialConfig)
+		})
+	}
+}
 
-				if cli.Default != "" {
-					cfg.DefaultCity = cli.Default
-					updated = true
-				}
+func TestHandleConfigSetUserTheme(t *testing.T) {
+	useTempConfigPath(t)
+	dir, err := config.ThemesDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "neon.json"), []byte(`{"foam": "#00ff00"}`), 0644))
 
-				return updated, nil
-			}
+	cfg := &config.Config{}
+	require.NoError(t, handleConfigSet(cfg, "theme", "neon"))
+	assert.Equal(t, "neon", cfg.Theme)
+}
 
-			updated, err := testHandleConfigUpdates(tc.cli, initialConfig)
+func TestHandleConfigGet(t *testing.T) {
+	cfg := &config.Config{DefaultCity: "Paris"}
 
-			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedUpdated, updated)
-			assert.Equal(t, expectedConfig, initialConfig)
+	assert.NoError(t, handleConfigGet(cfg, "city"))
+	assert.Error(t, handleConfigGet(cfg, "nope"))
+}
+
+func TestConfigKeysRoundTrip(t *testing.T) {
+	useTempConfigPath(t)
+	cfg := &config.Config{Units: "metric", DefaultView: "default", CacheTTL: 5}
+
+	for _, key := range configKeys() {
+		t.Run(key.name, func(t *testing.T) {
+			assert.NotEmpty(t, key.help)
+			value := key.get(cfg)
+			if key.name == "cache_ttl" || key.name == "timeout" {
+				value = "5"
+			}
+			assert.NoError(t, key.set(cfg, value), "current value should be accepted by set")
 		})
 	}
 }
+
+func TestConfigKeyHelpIsTranslated(t *testing.T) {
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage(i18n.DefaultLanguage) })
+
+	key, err := findConfigKey("temperature_unit")
+	require.NoError(t, err)
+	assert.Equal(t, "Temperatureinheit (C, F, K, oder default um den Einheiten zu folgen)", key.help)
+}
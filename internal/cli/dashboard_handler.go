@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/dashboard"
+	"github.com/josephburgess/gust/internal/ui/output"
+)
+
+func checkDashboardSupported(cli *CLI) error {
+	if cli.Output == "json" {
+		return errors.New(i18n.T("--pretty can't be combined with --output json"))
+	}
+	if cli.Plain {
+		return errors.New(i18n.T("--pretty can't be combined with --plain"))
+	}
+	if cli.All {
+		return errors.New(i18n.T("--pretty shows a single city and can't be combined with --all"))
+	}
+	if !output.IsInteractive() {
+		return errors.New(i18n.T("--pretty needs an interactive terminal"))
+	}
+	return nil
+}
+
+// timed refreshes go through the cache, so the dashboard costs no more requests than running gust
+// on a timer. Pressing r skips it the way --refresh does
+func runDashboard(city string, picked *models.City, view string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) error {
+	fetch := func(refresh bool) (*api.WeatherResponse, error) {
+		return loadWeatherQuietly(context.Background(), city, cfg, authConfig, refreshed(cli, refresh))
+	}
+
+	if picked != nil {
+		fetch = func(refresh bool) (*api.WeatherResponse, error) {
+			return loadPickedCity(context.Background(), city, picked, loadWeatherQuietly, cfg, authConfig, refreshed(cli, refresh))
+		}
+	}
+
+	model := dashboard.NewModel(cfg, city, fetch)
+	model.Tab = dashboard.TabForView(view)
+	return dashboard.Run(model)
+}
+
+// cli with --refresh set for one fetch, leaving the flags the user passed alone
+func refreshed(cli *CLI, refresh bool) *CLI {
+	if !refresh || cli.Refresh {
+		return cli
+	}
+	copied := *cli
+	copied.Refresh = true
+	return &copied
+}
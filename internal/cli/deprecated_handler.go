@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/ui/output"
+)
+
+// runs the old top-level flags through their subcommands, true when one was given.
+// --setup isn't handled here, it runs the wizard and then shows the weather like it used to
+func handleDeprecatedFlags(cli *CLI, cfg *config.Config) (bool, error) {
+	updated := false
+
+	if cli.ApiUrl != "" {
+		deprecatedFlag("--api", "gust config set api_url")
+		if err := handleConfigSet(cfg, "api_url", cli.ApiUrl); err != nil {
+			return true, err
+		}
+		updated = true
+	}
+
+	if cli.ApiKey != "" {
+		deprecatedFlag("--api-key", "gust auth key")
+		if err := handleApiKey(cfg, cli.ApiKey); err != nil {
+			return true, err
+		}
+		updated = true
+	}
+
+	if cli.Units != "" {
+		deprecatedFlag("--units", "gust config set units")
+		if err := handleConfigSet(cfg, "units", cli.Units); err != nil {
+			return true, err
+		}
+		updated = true
+	}
+
+	if cli.Default != "" {
+		deprecatedFlag("--default", "gust config set city")
+		if err := handleConfigSet(cfg, "city", cli.Default); err != nil {
+			return true, err
+		}
+		updated = true
+	}
+
+	if cli.Login {
+		deprecatedFlag("--login", "gust auth login")
+		return true, handleLogin(cfg.ApiUrl)
+	}
+
+	if cli.RunSetup {
+		deprecatedFlag("--setup", "gust setup")
+	}
+
+	return updated, nil
+}
+
+// on stderr so the notice doesn't end up in piped or json output
+func deprecatedFlag(flag, command string) {
+	output.PrintStderrWarning(i18n.T("%s is deprecated, use '%s' instead", flag, command))
+}
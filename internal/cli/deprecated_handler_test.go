@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleDeprecatedFlags(t *testing.T) {
+	t.Run("config flags are saved", func(t *testing.T) {
+		useTempConfigPath(t)
+		cfg := &config.Config{DefaultCity: "London", Units: "metric"}
+
+		handled, err := handleDeprecatedFlags(&CLI{Units: "imperial", Default: "Leeds", ApiUrl: "https://api.example.com"}, cfg)
+		require.NoError(t, err)
+		assert.True(t, handled)
+
+		saved, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "imperial", saved.Units)
+		assert.Equal(t, "Leeds", saved.DefaultCity)
+		assert.Equal(t, "https://api.example.com", saved.ApiUrl)
+	})
+
+	t.Run("invalid units", func(t *testing.T) {
+		useTempConfigPath(t)
+		handled, err := handleDeprecatedFlags(&CLI{Units: "kelvin"}, &config.Config{})
+		assert.Error(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("setup flag falls through to the weather", func(t *testing.T) {
+		handled, err := handleDeprecatedFlags(&CLI{RunSetup: true}, &config.Config{})
+		assert.NoError(t, err)
+		assert.False(t, handled)
+	})
+
+	t.Run("no deprecated flags", func(t *testing.T) {
+		handled, err := handleDeprecatedFlags(&CLI{}, &config.Config{})
+		assert.NoError(t, err)
+		assert.False(t, handled)
+	})
+}
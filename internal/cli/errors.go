@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/ui/components"
+)
+
+// exit statuses for api failures so scripts can tell a typo from an outage,
+// 1 is any other failure and 2 is ExitRulesMatched
+const (
+	ExitRateLimited  = 3
+	ExitUnauthorized = 4
+	ExitCityNotFound = 5
+	ExitUnavailable  = 6
+	ExitBadResponse  = 7
+	// ctrl+c, the usual 128 + SIGINT
+	ExitCancelled = 130
+)
+
+// the exit status for an error returned by Run
+func ExitCode(err error) int {
+	var (
+		rateLimited  *api.RateLimitError
+		unauthorized *api.UnauthorizedError
+		notFound     *api.CityNotFoundError
+		unavailable  *api.UnavailableError
+		badResponse  *api.DecodeError
+	)
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, ErrRulesMatched):
+		return ExitRulesMatched
+	case errors.Is(err, components.ErrCancelled), errors.Is(err, context.Canceled):
+		return ExitCancelled
+	case errors.As(err, &rateLimited):
+		return ExitRateLimited
+	case errors.As(err, &unauthorized):
+		return ExitUnauthorized
+	case errors.As(err, &notFound):
+		return ExitCityNotFound
+	case errors.As(err, &unavailable):
+		return ExitUnavailable
+	case errors.As(err, &badResponse):
+		return ExitBadResponse
+	default:
+		return 1
+	}
+}
+
+// what to do about err, empty when the error says it all
+func ErrorHint(err error) string {
+	var (
+		rateLimited  *api.RateLimitError
+		unauthorized *api.UnauthorizedError
+		notFound     *api.CityNotFoundError
+		unavailable  *api.UnavailableError
+		badResponse  *api.DecodeError
+		netErr       net.Error
+	)
+
+	switch {
+	case errors.As(err, &rateLimited):
+		return rateLimitHint(rateLimited.ResetTime, time.Now())
+	case errors.As(err, &unauthorized):
+		return i18n.T("Check your api key with 'gust auth status', or log in again with 'gust auth login'.")
+	case errors.Is(err, config.ErrKeyringUnreadable):
+		return i18n.T("Unlock your system keyring and try again. Only log in again if the key is gone from it.")
+	case errors.As(err, &notFound):
+		return i18n.T("Check the spelling or add a country code, e.g. London,GB.")
+	case errors.As(err, &unavailable) && errors.As(err, &netErr) && netErr.Timeout():
+		return i18n.T("The weather service took too long to answer, try again later or allow it longer with 'gust config set timeout <seconds>'.")
+	case errors.As(err, &unavailable):
+		return i18n.T("The weather service can't be reached right now, try again later or use --offline for cached weather.")
+	case errors.As(err, &badResponse):
+		return i18n.T("The weather service sent a response gust couldn't read, try again later.")
+	default:
+		return ""
+	}
+}
+
+func rateLimitHint(reset, now time.Time) string {
+	if reset.IsZero() || !reset.After(now) {
+		return i18n.T("Rate limit reached, please try again later.")
+	}
+
+	minutes := int(reset.Sub(now).Minutes()) + 1
+	if minutes >= 60 {
+		return i18n.T("Please try again in about %d hour(s) and %d minute(s) when your rate limit resets.", minutes/60, minutes%60)
+	}
+	return i18n.T("Please try again in about %d minute(s) when your rate limit resets.", minutes)
+}
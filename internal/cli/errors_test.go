@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/ui/components"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	wrap := func(err error) error { return fmt.Errorf("failed to get weather data: %w", err) }
+
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(errors.New("no city provided")))
+	assert.Equal(t, ExitRulesMatched, ExitCode(ErrRulesMatched))
+	assert.Equal(t, ExitRateLimited, ExitCode(wrap(&api.RateLimitError{})))
+	assert.Equal(t, ExitUnauthorized, ExitCode(wrap(&api.UnauthorizedError{})))
+	assert.Equal(t, ExitCityNotFound, ExitCode(wrap(&api.CityNotFoundError{City: "Atlantis"})))
+	assert.Equal(t, ExitUnavailable, ExitCode(wrap(&api.UnavailableError{StatusCode: 503})))
+	assert.Equal(t, ExitBadResponse, ExitCode(wrap(&api.DecodeError{Err: errors.New("unexpected EOF")})))
+	assert.Equal(t, ExitCancelled, ExitCode(wrap(components.ErrCancelled)))
+	assert.Equal(t, ExitCancelled, ExitCode(wrap(&api.UnavailableError{Err: context.Canceled})), "a cancelled request isn't an outage")
+}
+
+func TestErrorHint(t *testing.T) {
+	assert.Empty(t, ErrorHint(errors.New("no city provided")))
+	assert.Contains(t, ErrorHint(&api.CityNotFoundError{City: "Atlantis"}), "country code")
+	assert.Contains(t, ErrorHint(&api.UnauthorizedError{}), "gust auth login")
+	assert.Contains(t, ErrorHint(&api.UnavailableError{StatusCode: 503}), "--offline")
+	assert.Contains(t, ErrorHint(fmt.Errorf("%w: keyring is locked", config.ErrKeyringUnreadable)), "Unlock your system keyring")
+
+	timedOut := &api.UnavailableError{Err: &url.Error{Op: "Get", URL: "https://example.com", Err: os.ErrDeadlineExceeded}}
+	assert.Contains(t, ErrorHint(timedOut), "gust config set timeout")
+}
+
+func TestRateLimitHint(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Rate limit reached, please try again later.", rateLimitHint(time.Time{}, now))
+	assert.Equal(t, "Rate limit reached, please try again later.", rateLimitHint(now.Add(-time.Minute), now))
+	assert.Equal(t, "Please try again in about 11 minute(s) when your rate limit resets.", rateLimitHint(now.Add(10*time.Minute), now))
+	assert.Equal(t, "Please try again in about 1 hour(s) and 31 minute(s) when your rate limit resets.", rateLimitHint(now.Add(90*time.Minute), now))
+}
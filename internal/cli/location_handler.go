@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/ui/output"
+)
+
+func handleLocationAdd(cfg *config.Config, alias, city string) error {
+	alias = strings.TrimPrefix(strings.TrimSpace(alias), "@")
+	city = strings.TrimSpace(city)
+	if alias == "" || city == "" {
+		return errors.New(i18n.T("both an alias and a city are required, e.g. gust locations add home London"))
+	}
+	if strings.ContainsAny(alias, " +") {
+		return fmt.Errorf(i18n.T("invalid alias %q, aliases can't contain spaces or '+'"), alias)
+	}
+
+	cfg.AddLocation(alias, city)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf(i18n.T("failed to save config: %w"), err)
+	}
+
+	output.PrintSuccess(i18n.T("Saved @%s as %s", alias, city))
+	return nil
+}
+
+func handleLocationRemove(cfg *config.Config, alias string) error {
+	if !cfg.RemoveLocation(alias) {
+		return fmt.Errorf(i18n.T("no saved location called %q"), alias)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf(i18n.T("failed to save config: %w"), err)
+	}
+
+	output.PrintSuccess(i18n.T("Removed @%s", strings.TrimPrefix(alias, "@")))
+	return nil
+}
+
+func handleLocationList(cfg *config.Config) error {
+	if len(cfg.Locations) == 0 {
+		output.PrintInfo(i18n.T("No saved locations yet, add one with: gust locations add home London"))
+		return nil
+	}
+
+	for _, location := range cfg.Locations {
+		fmt.Printf("@%-15s %s\n", location.Alias, location.City)
+	}
+	return nil
+}
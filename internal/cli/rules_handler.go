@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/rules"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/renderer"
+)
+
+// exit status of `gust check` when a rule matched, see ExitCode for the others
+const ExitRulesMatched = 2
+
+// matches are already printed, main only has to set the exit status
+var ErrRulesMatched = errors.New("a rule matched")
+
+const exampleRule = "gust rules add temp below 0 in the next 12h"
+
+func handleRuleAdd(cfg *config.Config, text string) error {
+	rule, err := rules.Parse(text)
+	if err != nil {
+		return err
+	}
+
+	cfg.Rules = append(cfg.Rules, rule.Text)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf(i18n.T("failed to save config: %w"), err)
+	}
+
+	output.PrintSuccess(i18n.T("Added rule %d: %s", len(cfg.Rules), rule.Text))
+	return nil
+}
+
+func handleRuleRemove(cfg *config.Config, n int) error {
+	removed, ok := cfg.RemoveRule(n)
+	if !ok {
+		return fmt.Errorf(i18n.T("no rule number %d, see 'gust rules list'"), n)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf(i18n.T("failed to save config: %w"), err)
+	}
+
+	output.PrintSuccess(i18n.T("Removed rule: %s", removed))
+	return nil
+}
+
+func handleRuleList(cfg *config.Config) error {
+	if len(cfg.Rules) == 0 {
+		output.PrintInfo(i18n.T("No rules yet, add one with: ") + exampleRule)
+		return nil
+	}
+
+	for i, rule := range cfg.Rules {
+		fmt.Printf("%2d. %s\n", i+1, rule)
+	}
+	return nil
+}
+
+// prints every rule the forecast breaks, for cron jobs and CI
+func handleCheck(cfg *config.Config, cli *CLI) error {
+	ruleSet, err := rules.ParseAll(cfg.Rules)
+	if err != nil {
+		return err
+	}
+	if len(ruleSet) == 0 {
+		return fmt.Errorf(i18n.T("no rules to check - add one with '%s'"), exampleRule)
+	}
+
+	authConfig, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	if authConfig == nil && api.ProviderRequiresKey(cfg.Provider) {
+		return handleMissingAuth()
+	}
+
+	coords, err := coordinatesFromFlags(cli.Lat, cli.Lon)
+	if err != nil {
+		return err
+	}
+	label := determineCityName(cli.City, cli.cityArgs("check"), cfg.DefaultLocation())
+	if coords != "" {
+		label = coords
+	}
+	if label == "" {
+		return handleMissingCity()
+	}
+
+	city, err := resolveCity(label, cfg)
+	if err != nil {
+		return err
+	}
+
+	weather, err := loadWeatherQuietly(context.Background(), city, cfg, authConfig, cli)
+	if err != nil {
+		return err
+	}
+
+	matches := checkRules(ruleSet, weather, cfg, time.Now())
+	if len(matches) == 0 {
+		output.PrintSuccess(i18n.T("No rules matched for %s", weather.City.Name))
+		return nil
+	}
+
+	for _, match := range matches {
+		output.PrintWarning(fmt.Sprintf("%s: %s", match.Rule.Text, match.Describe()))
+	}
+	return ErrRulesMatched
+}
+
+func checkRules(ruleSet []rules.Rule, weather *api.WeatherResponse, cfg *config.Config, now time.Time) []rules.Match {
+	loc := renderer.DisplayZone(weather.Weather, cfg)
+	display := cfg.DisplayUnits()
+
+	var matches []rules.Match
+	for _, rule := range ruleSet {
+		if match, ok := rule.Evaluate(weather.Weather, display, now, loc); ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
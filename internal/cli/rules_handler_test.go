@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/cache"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRules(t *testing.T) {
+	useTempConfigPath(t)
+	cfg := &config.Config{}
+
+	assert.Error(t, handleRuleAdd(cfg, "cold tomorrow"))
+	assert.Empty(t, cfg.Rules)
+
+	require.NoError(t, handleRuleAdd(cfg, "temp  below 0   tomorrow"))
+	require.NoError(t, handleRuleAdd(cfg, "wind above 40 km/h"))
+	assert.Equal(t, []string{"temp below 0 tomorrow", "wind above 40 km/h"}, cfg.Rules)
+
+	assert.Error(t, handleRuleRemove(cfg, 3))
+	require.NoError(t, handleRuleRemove(cfg, 1))
+
+	saved, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wind above 40 km/h"}, saved.Rules)
+}
+
+func TestHandleCheck(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetCacheDir := cache.GetCacheDir
+	defer func() { cache.GetCacheDir = originalGetCacheDir }()
+	cache.GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	weather := createTestWeather()
+	weather.Hourly = []models.HourData{{Dt: time.Now().Unix(), Temp: -3, WindSpeed: 2}}
+	require.NoError(t, cache.Save("TestCity", api.ProviderOpenMeteo, &api.WeatherResponse{City: createTestCity(), Weather: weather}))
+
+	// open-meteo needs no key, and the fresh cache entry means no request is made
+	cfg := &config.Config{Provider: api.ProviderOpenMeteo, DefaultCity: "TestCity"}
+
+	t.Run("no rules", func(t *testing.T) {
+		assert.ErrorContains(t, handleCheck(cfg, &CLI{}), "no rules")
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		cfg.Rules = []string{"wind above 50 km/h"}
+		assert.NoError(t, handleCheck(cfg, &CLI{}))
+	})
+
+	t.Run("a rule matches", func(t *testing.T) {
+		cfg.Rules = []string{"wind above 50 km/h", "temp below 0 in the next 12h"}
+		assert.ErrorIs(t, handleCheck(cfg, &CLI{}), ErrRulesMatched)
+	})
+}
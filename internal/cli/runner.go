@@ -1,48 +1,172 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/alecthomas/kong"
+	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
 	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
 func Run(ctx *kong.Context, cli *CLI) error {
+	// NO_COLOR and output that isn't a terminal are already left uncolored by lipgloss
+	if cli.NoColor || cli.Plain {
+		styles.DisableColor()
+	}
+	output.SetPlain(cli.Plain)
+
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	i18n.SetLanguage(i18n.Detect(cfg.Language))
+
+	theme, err := loadTheme(cfg.Theme)
+	if err != nil {
+		// a missing theme file shouldn't stop anyone checking the weather
+		output.PrintWarning(err.Error())
+	} else {
+		styles.Apply(theme)
+	}
 
-	if updated, err := handleConfigUpdates(cli, cfg); updated || err != nil {
+	if handled, err := handleDeprecatedFlags(cli, cfg); handled || err != nil {
 		return err
 	}
 
-	if cli.Login {
+	switch command := commandPath(ctx.Command()); command {
+	case "config set":
+		return handleConfigSet(cfg, cli.Config.Set.Key, strings.Join(cli.Config.Set.Value, " "))
+	case "config get":
+		return handleConfigGet(cfg, cli.Config.Get.Key)
+	case "config list":
+		return handleConfigList(cfg)
+	case "locations add":
+		return handleLocationAdd(cfg, cli.Locations.Add.Alias, strings.Join(cli.Locations.Add.City, " "))
+	case "locations remove":
+		return handleLocationRemove(cfg, cli.Locations.Remove.Alias)
+	case "locations list":
+		return handleLocationList(cfg)
+	case "auth login":
 		return handleLogin(cfg.ApiUrl)
+	case "auth logout":
+		return handleLogout()
+	case "auth status":
+		return handleAuthStatus(cfg)
+	case "auth key":
+		return handleApiKey(cfg, cli.Auth.Key.ApiKey)
+	case "rules add":
+		return handleRuleAdd(cfg, strings.Join(cli.Rules.Add.Rule, " "))
+	case "rules remove":
+		return handleRuleRemove(cfg, cli.Rules.Remove.Number)
+	case "rules list":
+		return handleRuleList(cfg)
+	case "check":
+		return handleCheck(cfg, cli)
+	case "watch":
+		return handleWatch(cfg, cli)
+	case "setup":
+		_, err := handleSetup(cfg)
+		return err
+	default:
+		return runWeather(command, cli, cfg)
 	}
+}
 
-	authConfig, _ := config.LoadAuthConfig()
-	needsAuth := authConfig == nil
+func runWeather(command string, cli *CLI, cfg *config.Config) error {
+	authConfig, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	needsAuth := authConfig == nil && api.ProviderRequiresKey(cfg.Provider)
 
 	if needsSetup(cli, cfg) {
-		output.PrintInfo("Defaults not set, running setup...")
+		output.PrintInfo(i18n.T("Defaults not set, running setup..."))
 		needsAuth, err = handleSetup(cfg)
 		if err != nil {
 			return err
 		}
 
-		authConfig, _ = config.LoadAuthConfig()
+		if authConfig, err = loadAuthConfig(); err != nil {
+			return err
+		}
+		if !api.ProviderRequiresKey(cfg.Provider) {
+			needsAuth = false
+		}
 	}
 
 	if needsAuth {
 		return handleMissingAuth()
 	}
 
-	city := determineCityName(cli.City, cli.Args, cfg.DefaultCity)
+	// for this run only, the config isn't saved after this point
+	if cli.LocalTime {
+		cfg.LocalTime = true
+	}
+
+	if cli.Pretty {
+		if err := checkDashboardSupported(cli); err != nil {
+			return err
+		}
+	}
+
+	if cli.All {
+		return fetchAndRenderSummary(cfg, authConfig, cli)
+	}
+
+	coords, err := coordinatesFromFlags(cli.Lat, cli.Lon)
+	if err != nil {
+		return err
+	}
+
+	city := determineCityName(cli.City, cli.cityArgs(command), cfg.DefaultLocation())
+	if coords != "" {
+		city = coords
+	}
 	if city == "" {
 		return handleMissingCity()
 	}
 
-	return fetchAndRenderWeather(city, cfg, authConfig, cli)
+	view := cli.selectedView(command)
+	if cities := splitCities(city); len(cities) > 1 {
+		if cli.Pretty {
+			return errors.New(i18n.T("--pretty shows a single city, drop the extra cities or --pretty"))
+		}
+		return fetchAndRenderCities(cities, view, cfg, authConfig, cli)
+	}
+
+	typed := coords == "" && isTypedCity(command, cli, cfg)
+	city, err = resolveCity(city, cfg)
+	if err != nil {
+		return err
+	}
+
+	var picked *models.City
+	if typed {
+		if picked, err = pickCity(city, cfg, authConfig, cli); err != nil {
+			return err
+		}
+	}
+	if cli.Pretty {
+		return runDashboard(city, picked, view, cfg, authConfig, cli)
+	}
+	if picked != nil {
+		return fetchAndRenderPickedCity(city, picked, view, cfg, authConfig, cli)
+	}
+
+	return fetchAndRenderWeather(city, view, cfg, authConfig, cli)
+}
+
+// a built in theme or one from the user's themes directory
+func loadTheme(name string) (styles.Theme, error) {
+	dir, err := config.ThemesDir()
+	if err != nil {
+		return styles.Theme{}, err
+	}
+	return styles.LoadTheme(name, dir)
 }
@@ -4,14 +4,13 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/alecthomas/kong"
 	"github.com/josephburgess/gust/internal/config"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRunMissingCity(t *testing.T) {
 	testFunc := func(cli *CLI, cfg *config.Config) error {
-		city := determineCityName(cli.City, cli.Args, cfg.DefaultCity)
+		city := determineCityName(cli.City, cli.Weather.Args, cfg.DefaultCity)
 		if city == "" {
 			return handleMissingCity()
 		}
@@ -38,7 +37,7 @@ func TestRunMissingCity(t *testing.T) {
 		},
 		{
 			name:        "city provided through args",
-			cli:         &CLI{Args: []string{"New", "York"}},
+			cli:         &CLI{Weather: WeatherCmd{Args: []string{"New", "York"}}},
 			defaultCity: "",
 			expectError: false,
 		},
@@ -106,7 +105,7 @@ func TestRunAuthRequired(t *testing.T) {
 
 // testable version of run with deps injected
 func createRunWithDeps() func(
-	ctx *kong.Context,
+	command string,
 	cli *CLI,
 	loadConfig func() (*config.Config, error),
 	authenticate func(string) (*config.AuthConfig, error),
@@ -116,7 +115,7 @@ func createRunWithDeps() func(
 	fetchWeather func(string, *config.Config, *config.AuthConfig, *CLI) error,
 ) error {
 	return func(
-		ctx *kong.Context,
+		command string,
 		cli *CLI,
 		loadConfig func() (*config.Config, error),
 		authenticate func(string) (*config.AuthConfig, error),
@@ -130,7 +129,7 @@ func createRunWithDeps() func(
 			return fmt.Errorf("failed to load configuration: %w", err)
 		}
 
-		if cli.Login {
+		if command == "auth login" {
 			authConfig, err := authenticate(cfg.ApiUrl)
 			if err != nil {
 				return fmt.Errorf("authentication failed: %w", err)
@@ -160,7 +159,7 @@ func createRunWithDeps() func(
 			return handleMissingAuth()
 		}
 
-		city := determineCityName(cli.City, cli.Args, cfg.DefaultCity)
+		city := determineCityName(cli.City, cli.cityArgs(command), cfg.DefaultCity)
 		if city == "" {
 			return handleMissingCity()
 		}
@@ -171,8 +170,8 @@ func createRunWithDeps() func(
 
 func TestRunLogin(t *testing.T) {
 	runWithDeps := createRunWithDeps()
-	ctx := &kong.Context{}
-	cli := &CLI{Login: true}
+	command := "auth login"
+	cli := &CLI{}
 	mockAuthConfig := &config.AuthConfig{
 		APIKey:     "testkey",
 		GithubUser: "testuser",
@@ -182,7 +181,7 @@ func TestRunLogin(t *testing.T) {
 		var loadConfigCalled, authenticateCalled, saveAuthCalled bool
 
 		err := runWithDeps(
-			ctx,
+			command,
 			cli,
 			func() (*config.Config, error) {
 				loadConfigCalled = true
@@ -209,7 +208,7 @@ func TestRunLogin(t *testing.T) {
 
 	t.Run("Authentication failure", func(t *testing.T) {
 		err := runWithDeps(
-			ctx,
+			command,
 			cli,
 			func() (*config.Config, error) {
 				return &config.Config{ApiUrl: "https://api.example.com"}, nil
@@ -3,21 +3,28 @@ package cli
 import (
 	"fmt"
 
+	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/setup"
 )
 
+var runSetupWizard = setup.RunSetup // for tests
+
 func needsSetup(cli *CLI, cfg *config.Config) bool {
-	return cfg.DefaultCity == "" || cli.Setup
+	return cfg.DefaultCity == "" || cli.RunSetup
 }
 
 func handleSetup(cfg *config.Config) (bool, error) {
-	output.PrintInfo("Running setup wizard...")
-	authConfig, _ := config.LoadAuthConfig()
+	output.PrintInfo(i18n.T("Running setup wizard..."))
+	authConfig, err := loadAuthConfig()
+	if err != nil {
+		return false, err
+	}
 	needsAuth := authConfig == nil
 
-	if err := setup.RunSetup(cfg, needsAuth); err != nil {
+	if err := runSetupWizard(cfg, needsAuth); err != nil {
 		return needsAuth, fmt.Errorf("setup failed: %w", err)
 	}
 
@@ -27,14 +34,15 @@ func handleSetup(cfg *config.Config) (bool, error) {
 	}
 
 	cfg.DefaultCity = newCfg.DefaultCity
+	cfg.Provider = newCfg.Provider
 
 	authConfig, err = config.LoadAuthConfig()
 	if err != nil {
 		return true, fmt.Errorf("failed to load auth config after setup: %w", err)
 	}
 
-	needsAuth = authConfig == nil
-	output.PrintSuccess("Setup complete! Run 'gust' to check the weather for your default city.")
+	needsAuth = authConfig == nil && api.ProviderRequiresKey(cfg.Provider)
+	output.PrintSuccess(i18n.T("Setup complete! Run 'gust' to check the weather for your default city."))
 
 	return needsAuth, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/josephburgess/gust/internal/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNeedsSetup(t *testing.T) {
@@ -22,7 +23,7 @@ func TestNeedsSetup(t *testing.T) {
 		},
 		{
 			name:     "setup flag set",
-			cli:      &CLI{Setup: true},
+			cli:      &CLI{RunSetup: true},
 			cfg:      &config.Config{DefaultCity: "London"},
 			expected: true,
 		},
@@ -34,7 +35,7 @@ func TestNeedsSetup(t *testing.T) {
 		},
 		{
 			name:     "empty default and setup flag",
-			cli:      &CLI{Setup: true},
+			cli:      &CLI{RunSetup: true},
 			cfg:      &config.Config{DefaultCity: ""},
 			expected: true,
 		},
@@ -47,3 +48,26 @@ func TestNeedsSetup(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupCommandRunsWizard(t *testing.T) {
+	// `gust setup` replaces --setup and runs the wizard even with a default city saved
+	useTempConfigPath(t)
+	useTempAuthConfigPath(t)
+	require.NoError(t, (&config.Config{DefaultCity: "London"}).Save())
+
+	var called bool
+	original := runSetupWizard
+	t.Cleanup(func() { runSetupWizard = original })
+	runSetupWizard = func(cfg *config.Config, needsAuth bool) error {
+		called = true
+		assert.Equal(t, "London", cfg.DefaultCity)
+		return nil
+	}
+
+	app, cli := NewApp()
+	ctx, err := app.Parse([]string{"setup"})
+	require.NoError(t, err)
+
+	require.NoError(t, Run(ctx, cli))
+	assert.True(t, called)
+}
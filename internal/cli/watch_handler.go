@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/notify"
+	"github.com/josephburgess/gust/internal/rules"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/renderer"
+	"github.com/josephburgess/gust/internal/watch"
+)
+
+const (
+	// never poll faster than this, whatever the cache ttl or --interval say
+	minWatchInterval = 5 * time.Minute
+	// backoff ceiling after rate limit errors
+	maxWatchInterval = time.Hour
+	// checks are spread out so watching many locations stays well inside the rate limit
+	watchRequestsPerHour = 30
+	// longest alert description put in a notification, in characters
+	maxNotificationBody = 300
+)
+
+// a place being watched, label is what the user calls it and the seen alerts key
+type watchTarget struct {
+	label string
+	city  string
+}
+
+// runs in the foreground until interrupted, which suits systemd --user too
+func handleWatch(cfg *config.Config, cli *CLI) error {
+	if cfg.Provider == api.ProviderOpenMeteo {
+		return fmt.Errorf(i18n.T("the %s provider doesn't report weather alerts, pick another with 'gust config set provider'"), api.ProviderOpenMeteo)
+	}
+
+	authConfig, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	if authConfig == nil && api.ProviderRequiresKey(cfg.Provider) {
+		return handleMissingAuth()
+	}
+
+	targets, err := watchTargets(cfg)
+	if err != nil {
+		return err
+	}
+
+	ruleSet, err := rules.ParseAll(cfg.Rules)
+	if err != nil {
+		return err
+	}
+
+	seen, err := watch.Load()
+	if err != nil {
+		// starting over only means alerts that are already out get announced again
+		output.PrintWarning(i18n.T("%v, starting with no seen alerts", err))
+		seen = &watch.Seen{Alerts: map[string]int64{}}
+	}
+
+	notifier := notify.New(cfg.NotifyCommand)
+	interval := watchInterval(cfg, len(targets), cli.Watch.Interval)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !cli.Watch.Once {
+		output.PrintInfo(i18n.T("Watching %d location(s) for weather alerts and %d rule(s) every %s, press Ctrl+C to stop", len(targets), len(ruleSet), interval))
+	}
+
+	delay := interval
+	for {
+		rateLimited := checkAlerts(ctx, targets, ruleSet, seen, notifier, cfg, authConfig, cli)
+		if err := seen.Save(); err != nil {
+			output.PrintError(err.Error())
+		}
+		if cli.Watch.Once {
+			return nil
+		}
+
+		delay = nextWatchDelay(delay, interval, rateLimited, time.Now())
+		if rateLimited != nil {
+			output.PrintWarning(i18n.T("Rate limited, checking again in %s", delay.Round(time.Second)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// waits for the rate limit to reset when the provider says when, otherwise keeps doubling for as long
+// as it rate limits. Back to interval once a check gets through
+func nextWatchDelay(delay, interval time.Duration, rateLimited *api.RateLimitError, now time.Time) time.Duration {
+	if rateLimited == nil {
+		return interval
+	}
+	if rateLimited.ResetTime.After(now) {
+		return rateLimited.ResetTime.Sub(now)
+	}
+	return min(delay*2, max(maxWatchInterval, interval))
+}
+
+// saved locations, or the default city when there are none
+func watchTargets(cfg *config.Config) ([]watchTarget, error) {
+	var targets []watchTarget
+	for _, location := range cfg.Locations {
+		targets = append(targets, watchTarget{label: "@" + location.Alias, city: location.City})
+	}
+	if len(targets) > 0 {
+		return targets, nil
+	}
+
+	if city := cfg.DefaultLocation(); city != "" {
+		label := cfg.DefaultCity
+		if label == "" {
+			label = city
+		}
+		return []watchTarget{{label: label, city: city}}, nil
+	}
+	return nil, errors.New(i18n.T("nothing to watch - add a location with 'gust locations add <alias> <city>' or set a default city"))
+}
+
+// as often as the cache goes stale, but never so often that the locations burn through the rate limit
+func watchInterval(cfg *config.Config, locations int, requested time.Duration) time.Duration {
+	if requested > 0 {
+		return max(requested, minWatchInterval)
+	}
+
+	interval := cfg.CacheDuration()
+	if interval <= 0 {
+		interval = config.DefaultCacheTTL
+	}
+	spread := time.Hour * time.Duration(locations) / watchRequestsPerHour
+	return max(interval, spread, minWatchInterval)
+}
+
+// notifies about every new alert and rule match, and returns the rate limit a fetch hit, if any.
+// With several, the one that resets last
+func checkAlerts(ctx context.Context, targets []watchTarget, ruleSet []rules.Rule, seen *watch.Seen, notifier notify.Notifier, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) *api.RateLimitError {
+	now := time.Now()
+	seen.Prune(now)
+
+	var rateLimited *api.RateLimitError
+	for _, target := range targets {
+		weather, err := loadWeatherQuietly(ctx, target.city, cfg, authConfig, cli)
+		if err != nil {
+			output.PrintError(fmt.Sprintf("%s: %v", target.label, err))
+			var limit *api.RateLimitError
+			if errors.As(err, &limit) && (rateLimited == nil || limit.ResetTime.After(rateLimited.ResetTime)) {
+				rateLimited = limit
+			}
+			continue
+		}
+
+		loc := renderer.DisplayZone(weather.Weather, cfg)
+		for _, alert := range seen.Unseen(target.label, weather.Weather.Alerts, now) {
+			if err := notifier.Notify(alertNotification(target.label, alert, loc)); err != nil {
+				// left unseen so the next check tries again
+				output.PrintError(fmt.Sprintf("%s: %v", target.label, err))
+				continue
+			}
+			seen.MarkSeen(target.label, alert)
+			output.PrintWarning(i18n.T("%s: %s from %s", target.label, alert.Event, alert.SenderName))
+		}
+
+		for _, match := range checkRules(ruleSet, weather, cfg, now) {
+			key := watch.RuleKey(target.label, match.Rule.Text, match.Time)
+			if seen.Has(key) {
+				continue
+			}
+			notification := notify.Notification{
+				Title:   i18n.T("⚠️ %s - %s", match.Rule.Text, target.label),
+				Body:    match.Describe(),
+				Urgency: notify.UrgencyNormal,
+			}
+			if err := notifier.Notify(notification); err != nil {
+				output.PrintError(fmt.Sprintf("%s: %v", target.label, err))
+				continue
+			}
+			// forgotten the day after it matched
+			seen.Mark(key, match.Time.AddDate(0, 0, 2).Unix())
+			output.PrintWarning(i18n.T("%s: %s (%s)", target.label, match.Rule.Text, match.Describe()))
+		}
+	}
+	return rateLimited
+}
+
+func alertNotification(label string, alert models.Alert, loc *time.Location) notify.Notification {
+	var body strings.Builder
+	start := time.Unix(alert.Start, 0).In(loc)
+	if alert.End > 0 {
+		end := time.Unix(alert.End, 0).In(loc)
+		body.WriteString(i18n.T("%s until %s (%s)", i18n.FormatTime(start, "Mon 15:04"), i18n.FormatTime(end, "Mon 15:04"), renderer.ZoneLabel(end)) + "\n")
+	} else {
+		body.WriteString(i18n.T("From %s (%s)", i18n.FormatTime(start, "Mon 15:04"), renderer.ZoneLabel(start)) + "\n")
+	}
+	if alert.SenderName != "" {
+		body.WriteString(i18n.T("Issued by %s", alert.SenderName) + "\n")
+	}
+	if description := strings.Join(strings.Fields(alert.Description), " "); description != "" {
+		if runes := []rune(description); len(runes) > maxNotificationBody {
+			description = strings.TrimSpace(string(runes[:maxNotificationBody])) + "…"
+		}
+		body.WriteString("\n" + description)
+	}
+
+	return notify.Notification{
+		Title:   i18n.T("⚠️ %s - %s", alert.Event, label),
+		Body:    strings.TrimSpace(body.String()),
+		Urgency: alertUrgency(alert),
+	}
+}
+
+// warnings and emergencies demand action, watches and advisories can wait
+func alertUrgency(alert models.Alert) notify.Urgency {
+	event := strings.ToLower(alert.Event)
+	if strings.Contains(event, "warning") || strings.Contains(event, "emergency") {
+		return notify.UrgencyCritical
+	}
+	for _, tag := range alert.Tags {
+		if strings.EqualFold(tag, "extreme") {
+			return notify.UrgencyCritical
+		}
+	}
+	return notify.UrgencyNormal
+}
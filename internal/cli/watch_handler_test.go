@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/cache"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/notify"
+	"github.com/josephburgess/gust/internal/rules"
+	"github.com/josephburgess/gust/internal/watch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	sent []notify.Notification
+	err  error
+}
+
+func (r *recordingNotifier) Notify(n notify.Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+func TestWatchInterval(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cfg       *config.Config
+		locations int
+		requested time.Duration
+		expected  time.Duration
+	}{
+		{"cache ttl", &config.Config{CacheTTL: 15}, 1, 0, 15 * time.Minute},
+		{"disabled cache uses the default ttl", &config.Config{CacheTTL: -1}, 1, 0, config.DefaultCacheTTL},
+		{"never faster than the minimum", &config.Config{CacheTTL: 1}, 1, 0, minWatchInterval},
+		{"spread out over many locations", &config.Config{}, 10, 0, 20 * time.Minute},
+		{"requested interval", &config.Config{}, 10, 7 * time.Minute, 7 * time.Minute},
+		{"requested interval is clamped", &config.Config{}, 1, time.Second, minWatchInterval},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, watchInterval(tc.cfg, tc.locations, tc.requested))
+		})
+	}
+}
+
+func TestNextWatchDelay(t *testing.T) {
+	interval := 10 * time.Minute
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	limited := &api.RateLimitError{}
+
+	delay := interval
+	var delays []time.Duration
+	for _, rateLimited := range []*api.RateLimitError{limited, limited, limited, limited, nil, limited} {
+		delay = nextWatchDelay(delay, interval, rateLimited, now)
+		delays = append(delays, delay)
+	}
+
+	assert.Equal(t, []time.Duration{20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour, interval, 20 * time.Minute}, delays)
+	assert.Equal(t, 2*time.Hour, nextWatchDelay(2*time.Hour, 2*time.Hour, limited, now), "never shorter than the interval")
+
+	reset := &api.RateLimitError{ResetTime: now.Add(7 * time.Minute)}
+	assert.Equal(t, 7*time.Minute, nextWatchDelay(interval, interval, reset, now), "waits for a known reset")
+	past := &api.RateLimitError{ResetTime: now.Add(-time.Minute)}
+	assert.Equal(t, 20*time.Minute, nextWatchDelay(interval, interval, past, now))
+}
+
+func TestWatchTargets(t *testing.T) {
+	cfg := &config.Config{DefaultCity: "London", Locations: []config.Location{{Alias: "home", City: "Leeds"}}}
+	targets, err := watchTargets(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []watchTarget{{label: "@home", city: "Leeds"}}, targets)
+
+	targets, err = watchTargets(&config.Config{DefaultCity: "London"})
+	require.NoError(t, err)
+	assert.Equal(t, []watchTarget{{label: "London", city: "London"}}, targets)
+
+	_, err = watchTargets(&config.Config{})
+	assert.Error(t, err)
+}
+
+func TestAlertUrgency(t *testing.T) {
+	assert.Equal(t, notify.UrgencyCritical, alertUrgency(models.Alert{Event: "Severe Thunderstorm Warning"}))
+	assert.Equal(t, notify.UrgencyCritical, alertUrgency(models.Alert{Event: "Heat", Tags: []string{"Extreme"}}))
+	assert.Equal(t, notify.UrgencyNormal, alertUrgency(models.Alert{Event: "Wind Advisory"}))
+}
+
+func TestAlertNotification(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, tokyo)
+	alert := models.Alert{
+		SenderName:  "JMA",
+		Event:       "Heavy Rain Warning",
+		Start:       start.Unix(),
+		End:         start.Add(9 * time.Hour).Unix(),
+		Description: "Heavy rain\nexpected   overnight.",
+	}
+
+	n := alertNotification("@tokyo", alert, tokyo)
+	assert.Equal(t, "⚠️ Heavy Rain Warning - @tokyo", n.Title)
+	assert.Equal(t, "Mon 09:00 until Mon 18:00 (JST)\nIssued by JMA\n\nHeavy rain expected overnight.", n.Body)
+	assert.Equal(t, notify.UrgencyCritical, n.Urgency)
+}
+
+func TestCheckAlerts(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetCacheDir := cache.GetCacheDir
+	defer func() { cache.GetCacheDir = originalGetCacheDir }()
+	cache.GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	now := time.Now()
+	weather := createTestWeather()
+	weather.Alerts = []models.Alert{
+		{SenderName: "Met Office", Event: "Flood Warning", Start: now.Unix(), End: now.Add(time.Hour).Unix()},
+	}
+	// a fresh cache entry means no network call
+	require.NoError(t, cache.Save("Leeds", "", &api.WeatherResponse{City: createTestCity(), Weather: weather}))
+
+	cfg := &config.Config{}
+	targets := []watchTarget{{label: "@home", city: "Leeds"}}
+	seen := &watch.Seen{Alerts: map[string]int64{}}
+
+	t.Run("failed notifications are retried", func(t *testing.T) {
+		notifier := &recordingNotifier{err: errors.New("no desktop")}
+		checkAlerts(context.Background(), targets, nil, seen, notifier, cfg, nil, &CLI{})
+		assert.Empty(t, seen.Alerts)
+	})
+
+	t.Run("new alerts notify once", func(t *testing.T) {
+		notifier := &recordingNotifier{}
+		assert.Nil(t, checkAlerts(context.Background(), targets, nil, seen, notifier, cfg, nil, &CLI{}))
+		assert.Nil(t, checkAlerts(context.Background(), targets, nil, seen, notifier, cfg, nil, &CLI{}))
+
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "⚠️ Flood Warning - @home", notifier.sent[0].Title)
+	})
+
+	t.Run("rule matches notify once", func(t *testing.T) {
+		ruleSet, err := rules.ParseAll([]string{"temp above 15 in the next 6h"})
+		require.NoError(t, err)
+		weather.Hourly = []models.HourData{{Dt: now.Unix(), Temp: 20.5}}
+		require.NoError(t, cache.Save("Leeds", "", &api.WeatherResponse{City: createTestCity(), Weather: weather}))
+
+		notifier := &recordingNotifier{}
+		checkAlerts(context.Background(), targets, ruleSet, seen, notifier, cfg, nil, &CLI{})
+		checkAlerts(context.Background(), targets, ruleSet, seen, notifier, cfg, nil, &CLI{})
+
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "⚠️ temp above 15 in the next 6h - @home", notifier.sent[0].Title)
+	})
+}
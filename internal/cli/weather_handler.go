@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
-	"time"
+	"os"
 
 	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/cache"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
 	"github.com/josephburgess/gust/internal/ui/components"
 	"github.com/josephburgess/gust/internal/ui/output"
@@ -14,89 +17,334 @@ import (
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
-func fetchAndRenderWeather(city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) error {
-	client := api.NewClient(cfg.ApiUrl, authConfig.APIKey, cfg.Units)
+// parallel fetches are capped so a long list doesn't hammer the provider
+const maxConcurrentFetches = 4
 
-	fetchFunc := func() (*api.WeatherResponse, error) {
-		weather, err := client.GetWeather(city)
-		if err != nil {
-			if strings.Contains(strings.ToLower(err.Error()), "rate limit") {
-				return nil, fmt.Errorf("rate limit reached: %w", err)
-			}
-			return nil, fmt.Errorf("failed to get weather data: %w", err)
+func fetchAndRenderWeather(city string, view string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) error {
+	weather, err := loadWeather(context.Background(), city, cfg, authConfig, cli)
+	if err != nil {
+		return err
+	}
+
+	weatherRenderer := renderer.NewWeatherRenderer(cli.Output, cfg)
+	renderWeatherView(view, weatherRenderer, weather.City, weather.Weather, cfg)
+
+	return nil
+}
+
+// fetched by coordinates, but labelled with the search result so it doesn't read "39.8017, -89.6436"
+func fetchAndRenderPickedCity(query string, picked *models.City, view string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) error {
+	weather, err := loadPickedCity(context.Background(), query, picked, loadWeather, cfg, authConfig, cli)
+	if err != nil {
+		return err
+	}
+
+	weatherRenderer := renderer.NewWeatherRenderer(cli.Output, cfg)
+	renderWeatherView(view, weatherRenderer, weather.City, weather.Weather, cfg)
+
+	return nil
+}
+
+// for tests
+var newProvider = api.NewProvider
+
+type weatherLoader func(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error)
+
+// by coordinates so the pick can't resolve to a different place, or by name and country
+// when the server can't look coordinates up. The result is labelled with the pick and
+// cached under the typed query too, so running the same name again skips the search
+func loadPickedCity(ctx context.Context, query string, picked *models.City, load weatherLoader, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error) {
+	coords := models.Coordinates{Lat: picked.Lat, Lon: picked.Lon}
+	weather, err := load(ctx, coords.GeoURI(), cfg, authConfig, cli)
+	if errors.Is(err, api.ErrCoordinatesUnsupported) {
+		weather, err = load(ctx, picked.Query(), cfg, authConfig, cli)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	city := *picked
+	weather = &api.WeatherResponse{City: &city, Weather: weather.Weather}
+	if cfg.CacheDuration() > 0 {
+		_ = cache.Save(query, cfg.Provider, weather)
+	}
+	return weather, nil
+}
+
+// cities "london + paris" each get the selected view, in the order they were asked for
+func fetchAndRenderCities(cities []string, view string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) error {
+	targets := make([]renderer.LocationWeather, len(cities))
+	for i, city := range cities {
+		targets[i] = renderer.LocationWeather{Label: city}
+	}
+
+	results := loadWeatherAll(context.Background(), targets, cfg, authConfig, cli)
+	weatherRenderer := renderer.NewWeatherRenderer(cli.Output, cfg)
+
+	for _, result := range results {
+		if result.Err != nil {
+			printLocationError(result, cli)
+			continue
+		}
+		renderWeatherView(view, weatherRenderer, result.City, result.Weather, cfg)
+	}
+
+	return loadFailure(results)
+}
+
+// ctrl+c wins, so a cancelled run exits the way a single city does, otherwise how many failed
+func loadFailure(results []renderer.LocationWeather) error {
+	failed := 0
+	for _, result := range results {
+		if errors.Is(result.Err, components.ErrCancelled) || errors.Is(result.Err, context.Canceled) {
+			return components.ErrCancelled
+		}
+		if result.Err != nil {
+			failed++
 		}
-		return weather, nil
 	}
 
-	message := fmt.Sprintf("Fetching weather for %s...", city)
-	weather, err := components.RunWithSpinner(message, components.WeatherEmojis, styles.Foam, fetchFunc)
+	if failed > 0 {
+		return errors.New(i18n.T("failed to load weather for %d of %d cities", failed, len(results)))
+	}
+	return nil
+}
+
+// one location failing doesn't stop the rest, its error is shown in its row instead
+func fetchAndRenderSummary(cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) error {
+	if len(cfg.Locations) == 0 {
+		return errors.New(i18n.T("no saved locations - add one with 'gust locations add <alias> <city>'"))
+	}
+
+	targets := make([]renderer.LocationWeather, len(cfg.Locations))
+	for i, location := range cfg.Locations {
+		targets[i] = renderer.LocationWeather{Label: "@" + location.Alias}
+	}
 
-	if client.RateLimitInfo != nil && client.RateLimitInfo.Limit > 0 {
-		if err != nil && strings.Contains(strings.ToLower(err.Error()), "rate limit") {
-			output.PrintRateLimitError(client.RateLimitInfo.Limit, client.RateLimitInfo.ResetTime)
+	weatherRenderer := renderer.NewWeatherRenderer(cli.Output, cfg)
+	results := loadWeatherAll(context.Background(), targets, cfg, authConfig, cli)
+	weatherRenderer.RenderSummary(results, cfg)
 
-			timeUntilReset := time.Until(client.RateLimitInfo.ResetTime)
-			if timeUntilReset > 0 {
-				minutesRemaining := int(timeUntilReset.Minutes()) + 1
-				hoursRemaining := minutesRemaining / 60
+	// a failed location already shows in its row
+	if errors.Is(loadFailure(results), components.ErrCancelled) {
+		return components.ErrCancelled
+	}
+	return nil
+}
 
-				if hoursRemaining > 0 {
-					remainingMinutes := minutesRemaining % 60
-					return fmt.Errorf("please try again in about %d hour(s) and %d minute(s) when your rate limit resets",
-						hoursRemaining, remainingMinutes)
-				} else {
-					return fmt.Errorf("please try again in about %d minute(s) when your rate limit resets",
-						minutesRemaining)
+// fetches every target in parallel, a location's city is looked up from its label
+func loadWeatherAll(ctx context.Context, targets []renderer.LocationWeather, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) []renderer.LocationWeather {
+	tasks := make([]components.Task[*api.WeatherResponse], len(targets))
+	for i, target := range targets {
+		city, err := resolveCity(target.Label, cfg)
+		tasks[i] = components.Task[*api.WeatherResponse]{
+			Label: target.Label,
+			Run: func(ctx context.Context) (*api.WeatherResponse, error) {
+				if err != nil {
+					return nil, err
 				}
-			}
-			return fmt.Errorf("rate limit reached, please try again later")
+				return loadWeatherQuietly(ctx, city, cfg, authConfig, cli)
+			},
+		}
+	}
+
+	var results []components.TaskResult[*api.WeatherResponse]
+	if cli.Output == "json" {
+		results = components.RunAll(ctx, tasks, maxConcurrentFetches)
+	} else {
+		var err error
+		results, err = components.RunAllWithSpinner(ctx, tasks, maxConcurrentFetches, components.WeatherEmojis, styles.Foam)
+		if err != nil {
+			// the terminal couldn't host the spinner, fetch without it
+			results = components.RunAll(ctx, tasks, maxConcurrentFetches)
+		}
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			targets[i].Err = result.Err
+			continue
+		}
+		targets[i].City = result.Value.City
+		targets[i].Weather = result.Value.Weather
+	}
+	return targets
+}
+
+func printLocationError(location renderer.LocationWeather, cli *CLI) {
+	message := fmt.Sprintf("%s: %v", location.Label, location.Err)
+	if cli.Output == "json" {
+		// keep stdout a clean stream of json documents
+		fmt.Fprintln(os.Stderr, message)
+		return
+	}
+	output.PrintError(message)
+}
+
+// serves from the cache when possible, --refresh skips it and --offline never touches the network
+func loadWeather(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error) {
+	return loadWeatherWith(ctx, city, cfg, authConfig, cli, cli.Output == "json")
+}
+
+// for parallel fetches, where a spinner or warning per city would fight over the terminal
+func loadWeatherQuietly(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error) {
+	return loadWeatherWith(ctx, city, cfg, authConfig, cli, true)
+}
+
+func loadWeatherWith(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI, quiet bool) (*api.WeatherResponse, error) {
+	ttl := cfg.CacheDuration()
+
+	cached, err := cache.Load(city, cfg.Provider)
+	if err != nil {
+		// a corrupt entry is treated as a miss and overwritten below
+		cached = nil
+	}
+
+	if cli.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf(i18n.T("no cached weather for %s - run without --offline to fetch it"), city)
+		}
+		if !cached.IsFresh(ttl) && !quiet {
+			output.PrintStaleDataWarning(cached.FetchedAt)
 		}
+		return cached.Response, nil
+	}
+
+	if cached != nil && !cli.Refresh && cached.IsFresh(ttl) {
+		return cached.Response, nil
+	}
 
-		if client.RateLimitInfo.Remaining <= 5 && client.RateLimitInfo.Remaining > 0 {
-			output.PrintRateLimitWarning(
-				client.RateLimitInfo.Remaining,
-				client.RateLimitInfo.Limit,
-				client.RateLimitInfo.ResetTime,
-			)
+	weather, err := fetchWeather(ctx, city, cfg, authConfig, quiet)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		// caching is best effort, a read-only cache dir shouldn't stop the forecast
+		_ = cache.Save(city, cfg.Provider, weather)
+	}
+
+	return weather, nil
+}
+
+// quiet skips the spinner and rate limit output, ctrl+c in the spinner cancels the request
+func fetchWeather(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, quiet bool) (*api.WeatherResponse, error) {
+	apiKey := ""
+	if authConfig != nil {
+		apiKey = authConfig.APIKey
+	}
+
+	provider, err := newProvider(cfg.Provider, cfg.ApiUrl, apiKey, i18n.Language(), cfg.RequestTimeout())
+	if err != nil {
+		return nil, err
+	}
+
+	fetchFunc := func(ctx context.Context) (*api.WeatherResponse, error) {
+		weather, err := getWeather(ctx, provider, city, cfg)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("failed to get weather data: %w"), err)
+		}
+		return weather, nil
+	}
+
+	var weather *api.WeatherResponse
+	if quiet {
+		// the spinner draws to stdout, which would corrupt piped json
+		weather, err = fetchFunc(ctx)
+	} else {
+		message := i18n.T("Fetching weather for %s...", city)
+		weather, err = components.RunWithSpinner(ctx, message, components.WeatherEmojis, styles.Foam, func(ctx context.Context) (*api.WeatherResponse, error) {
+			ctx = api.WithRetryNotify(ctx, func(attempt, maxAttempts int) {
+				components.SetSpinnerMessage(ctx, i18n.T("Fetching weather for %s... (attempt %d of %d)", city, attempt, maxAttempts))
+			})
+			return fetchFunc(ctx)
+		})
+	}
+
+	// only breeze reports rate limits
+	if client, isBreeze := provider.(*api.Client); isBreeze && !quiet {
+		rateLimit := client.RateLimit()
+		var rateLimited *api.RateLimitError
+		switch {
+		case rateLimit.Limit == 0:
+		case errors.As(err, &rateLimited):
+			output.PrintRateLimitError(rateLimit.Limit, rateLimit.ResetTime)
+		case err == nil && rateLimit.Remaining <= 5 && rateLimit.Remaining > 0:
+			output.PrintRateLimitWarning(rateLimit.Remaining, rateLimit.Limit, rateLimit.ResetTime)
 		}
 	}
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return weather, nil
+}
+
+// geo URIs go to the coordinate lookup, anything else is searched by name
+func getWeather(ctx context.Context, provider api.Provider, city string, cfg *config.Config) (*api.WeatherResponse, error) {
+	if !models.IsGeoURI(city) {
+		return provider.GetWeatherContext(ctx, city)
 	}
 
-	weatherRenderer := renderer.NewWeatherRenderer("terminal", cfg.Units)
-	renderWeatherView(cli, weatherRenderer, weather.City, weather.Weather, cfg)
+	coords, err := models.ParseGeoURI(city)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil
+	isDefault := cfg.DefaultCoords != nil && cfg.DefaultCity != "" && cfg.DefaultCoords.GeoURI() == coords.GeoURI()
+	weather, err := getWeatherByCoords(ctx, provider, coords, cfg)
+	if errors.Is(err, api.ErrCoordinatesUnsupported) && isDefault {
+		// with the wizard's country, the way a pick falls back
+		return provider.GetWeatherContext(ctx, models.City{Name: cfg.DefaultCity, Country: cfg.DefaultCountry}.Query())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// providers without reverse geocoding name the place after its coordinates,
+	// the wizard pick is a nicer label for the default location
+	if weather.City != nil && isDefault {
+		weather.City.Name = cfg.DefaultCity
+	}
+	return weather, nil
+}
+
+// a server known to have no coordinate lookups isn't asked again, each run would cost two requests
+func getWeatherByCoords(ctx context.Context, provider api.Provider, coords models.Coordinates, cfg *config.Config) (*api.WeatherResponse, error) {
+	if cache.NoCoordinates(cfg.Provider, cfg.ApiUrl) {
+		return nil, api.ErrCoordinatesUnsupported
+	}
+
+	weather, err := provider.GetWeatherByCoordsContext(ctx, coords)
+	if errors.Is(err, api.ErrCoordinatesUnsupported) {
+		_ = cache.MarkNoCoordinates(cfg.Provider, cfg.ApiUrl)
+	}
+	return weather, err
 }
 
-func renderWeatherView(cli *CLI, weatherRenderer renderer.WeatherRenderer, city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
-	switch {
-	case cli.Alerts:
+// an empty view falls back to the configured default
+func renderWeatherView(view string, weatherRenderer renderer.WeatherRenderer, city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	if view == "" {
+		view = cfg.DefaultView
+	}
+
+	switch view {
+	case "alerts":
 		weatherRenderer.RenderAlerts(city, weather, cfg)
-	case cli.Hourly:
+	case "hourly":
 		weatherRenderer.RenderHourlyForecast(city, weather, cfg)
-	case cli.Daily:
+	case "graph":
+		weatherRenderer.RenderHourlyGraph(city, weather, cfg)
+	case "nowcast":
+		weatherRenderer.RenderNowcast(city, weather, cfg)
+	case "daily":
 		weatherRenderer.RenderDailyForecast(city, weather, cfg)
-	case cli.Full:
+	case "full":
 		weatherRenderer.RenderFullWeather(city, weather, cfg)
-	case cli.Compact:
+	case "compact":
 		weatherRenderer.RenderCompactWeather(city, weather, cfg)
-	case cli.Detailed:
-		weatherRenderer.RenderCurrentWeather(city, weather, cfg)
 	default:
-		switch cfg.DefaultView {
-		case "compact":
-			weatherRenderer.RenderCompactWeather(city, weather, cfg)
-		case "daily":
-			weatherRenderer.RenderDailyForecast(city, weather, cfg)
-		case "hourly":
-			weatherRenderer.RenderHourlyForecast(city, weather, cfg)
-		case "full":
-			weatherRenderer.RenderFullWeather(city, weather, cfg)
-		default:
-			weatherRenderer.RenderCurrentWeather(city, weather, cfg)
-		}
+		weatherRenderer.RenderCurrentWeather(city, weather, cfg)
 	}
 }
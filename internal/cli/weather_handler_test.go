@@ -1,50 +1,68 @@
 package cli
 
 import (
+	"context"
 	"testing"
 
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/cache"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/components"
+	"github.com/josephburgess/gust/internal/ui/renderer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func TestRenderWeatherView_CLIFlags(t *testing.T) {
+func TestRenderWeatherView_Views(t *testing.T) {
 	mockCity := createTestCity()
 	mockWeather := createTestWeather()
 	mockConfig := &config.Config{DefaultView: "compact", ShowTips: false}
 
 	testCases := []struct {
 		name           string
-		cli            *CLI
+		view           string
 		expectedMethod string
 	}{
 		{
-			name:           "alerts flag",
-			cli:            &CLI{Alerts: true},
+			name:           "alerts view",
+			view:           "alerts",
 			expectedMethod: "RenderAlerts",
 		},
 		{
-			name:           "hourly flag",
-			cli:            &CLI{Hourly: true},
+			name:           "hourly view",
+			view:           "hourly",
 			expectedMethod: "RenderHourlyForecast",
 		},
 		{
-			name:           "daily flag",
-			cli:            &CLI{Daily: true},
+			name:           "graph view",
+			view:           "graph",
+			expectedMethod: "RenderHourlyGraph",
+		},
+		{
+			name:           "nowcast view",
+			view:           "nowcast",
+			expectedMethod: "RenderNowcast",
+		},
+		{
+			name:           "daily view",
+			view:           "daily",
 			expectedMethod: "RenderDailyForecast",
 		},
 		{
-			name:           "full flag",
-			cli:            &CLI{Full: true},
+			name:           "full view",
+			view:           "full",
 			expectedMethod: "RenderFullWeather",
 		},
 		{
-			name:           "compact flag",
-			cli:            &CLI{Compact: true},
+			name:           "compact view",
+			view:           "compact",
 			expectedMethod: "RenderCompactWeather",
 		},
 		{
-			name:           "detailed flag",
-			cli:            &CLI{Detailed: true},
+			name:           "detailed view",
+			view:           "detailed",
 			expectedMethod: "RenderCurrentWeather",
 		},
 	}
@@ -54,7 +72,7 @@ func TestRenderWeatherView_CLIFlags(t *testing.T) {
 			mockRenderer := new(MockWeatherRenderer)
 			mockRenderer.On(tc.expectedMethod, mockCity, mockWeather, mockConfig).Return()
 
-			renderWeatherView(tc.cli, mockRenderer, mockCity, mockWeather, mockConfig)
+			renderWeatherView(tc.view, mockRenderer, mockCity, mockWeather, mockConfig)
 
 			mockRenderer.AssertExpectations(t)
 		})
@@ -64,7 +82,6 @@ func TestRenderWeatherView_CLIFlags(t *testing.T) {
 func TestRenderWeatherView_DefaultView(t *testing.T) {
 	mockCity := createTestCity()
 	mockWeather := createTestWeather()
-	cli := &CLI{}
 
 	testCases := []struct {
 		name        string
@@ -109,7 +126,7 @@ func TestRenderWeatherView_DefaultView(t *testing.T) {
 			mockRenderer.On(tc.expectedFn, mockCity, mockWeather, mock.Anything).Return()
 
 			config := &config.Config{DefaultView: tc.defaultView}
-			renderWeatherView(cli, mockRenderer, mockCity, mockWeather, config)
+			renderWeatherView("", mockRenderer, mockCity, mockWeather, config)
 
 			mockRenderer.AssertExpectations(t)
 		})
@@ -123,31 +140,49 @@ func TestRenderWeatherView_FlagsAndPriority(t *testing.T) {
 
 	testCases := []struct {
 		name           string
+		command        string
 		cli            *CLI
 		expectedMethod string
 	}{
 		{
 			name:           "default falls back",
+			command:        "weather",
 			cli:            &CLI{},
 			expectedMethod: "RenderCompactWeather",
 		},
 		{
 			name:           "flag overrides default",
-			cli:            &CLI{Daily: true},
+			command:        "weather",
+			cli:            &CLI{Weather: WeatherCmd{Daily: true}},
 			expectedMethod: "RenderDailyForecast",
 		},
 		{
-			name: "multiple flags respect prio",
+			name:    "multiple flags respect prio",
+			command: "weather",
 			cli: &CLI{
-				Alerts:   true,
-				Hourly:   true,
-				Daily:    true,
-				Full:     true,
-				Compact:  true,
-				Detailed: true,
+				Weather: WeatherCmd{
+					Alerts:   true,
+					Hourly:   true,
+					Daily:    true,
+					Full:     true,
+					Compact:  true,
+					Detailed: true,
+				},
 			},
 			expectedMethod: "RenderAlerts",
 		},
+		{
+			name:           "subcommand overrides default",
+			command:        "hourly",
+			cli:            &CLI{},
+			expectedMethod: "RenderHourlyForecast",
+		},
+		{
+			name:           "now subcommand",
+			command:        "now",
+			cli:            &CLI{},
+			expectedMethod: "RenderCurrentWeather",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -155,9 +190,188 @@ func TestRenderWeatherView_FlagsAndPriority(t *testing.T) {
 			mockRenderer := new(MockWeatherRenderer)
 			mockRenderer.On(tc.expectedMethod, mockCity, mockWeather, mock.Anything).Return()
 
-			renderWeatherView(tc.cli, mockRenderer, mockCity, mockWeather, mockConfig)
+			renderWeatherView(tc.cli.selectedView(tc.command), mockRenderer, mockCity, mockWeather, mockConfig)
 
 			mockRenderer.AssertExpectations(t)
 		})
 	}
 }
+
+func TestLoadWeather_Cache(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetCacheDir := cache.GetCacheDir
+	defer func() { cache.GetCacheDir = originalGetCacheDir }()
+	cache.GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	cfg := &config.Config{Units: "metric"}
+	cachedResponse := &api.WeatherResponse{City: createTestCity(), Weather: createTestWeather()}
+
+	t.Run("offline without cached data", func(t *testing.T) {
+		weather, err := loadWeather(context.Background(), "TestCity", cfg, nil, &CLI{Offline: true})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no cached weather")
+		assert.Nil(t, weather)
+	})
+
+	assert.NoError(t, cache.Save("TestCity", "", cachedResponse))
+
+	t.Run("fresh cache avoids the network", func(t *testing.T) {
+		// no auth config - a network call would panic
+		weather, err := loadWeather(context.Background(), "testcity", cfg, nil, &CLI{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, cachedResponse.City.Name, weather.City.Name)
+	})
+
+	t.Run("offline serves cached data", func(t *testing.T) {
+		weather, err := loadWeather(context.Background(), "TestCity", cfg, nil, &CLI{Offline: true, Output: "json"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, cachedResponse.Weather.Current.Temp, weather.Weather.Current.Temp)
+	})
+}
+
+func TestGetWeather_Coordinates(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetCacheDir := cache.GetCacheDir
+	defer func() { cache.GetCacheDir = originalGetCacheDir }()
+	cache.GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	springfield := models.Coordinates{Lat: 39.8017, Lon: -89.6436}
+	cfg := &config.Config{DefaultCity: "Springfield", DefaultCountry: "US", DefaultCoords: &springfield}
+
+	t.Run("city names are searched", func(t *testing.T) {
+		client := new(MockWeatherClient)
+		client.On("GetWeather", "London").Return(&api.WeatherResponse{City: createTestCity()}, nil)
+
+		_, err := getWeather(context.Background(), client, "London", cfg)
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("default coordinates keep the wizard name", func(t *testing.T) {
+		client := new(MockWeatherClient)
+		client.On("GetWeatherByCoords", springfield).
+			Return(&api.WeatherResponse{City: &models.City{Name: springfield.String()}}, nil)
+
+		weather, err := getWeather(context.Background(), client, cfg.DefaultLocation(), cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "Springfield", weather.City.Name)
+	})
+
+	t.Run("other coordinates keep the provider name", func(t *testing.T) {
+		coords := models.Coordinates{Lat: 51.5, Lon: -0.12}
+		client := new(MockWeatherClient)
+		client.On("GetWeatherByCoords", coords).
+			Return(&api.WeatherResponse{City: &models.City{Name: coords.String()}}, nil)
+
+		weather, err := getWeather(context.Background(), client, "geo:51.5,-0.12", cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "51.5000, -0.1200", weather.City.Name)
+	})
+
+	t.Run("default coordinates fall back to the wizard name and country", func(t *testing.T) {
+		client := new(MockWeatherClient)
+		client.On("GetWeatherByCoords", springfield).Return(nil, api.ErrCoordinatesUnsupported).Once()
+		client.On("GetWeather", "Springfield,US").
+			Return(&api.WeatherResponse{City: &models.City{Name: "Springfield"}}, nil)
+
+		weather, err := getWeather(context.Background(), client, cfg.DefaultLocation(), cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "Springfield", weather.City.Name)
+
+		// remembered, so the next run makes one request rather than two
+		_, err = getWeather(context.Background(), client, cfg.DefaultLocation(), cfg)
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+		client.AssertNumberOfCalls(t, "GetWeatherByCoords", 1)
+	})
+
+	t.Run("other coordinates can't fall back", func(t *testing.T) {
+		coords := models.Coordinates{Lat: 51.5, Lon: -0.12}
+		client := new(MockWeatherClient)
+		client.On("GetWeatherByCoords", coords).Return(nil, api.ErrCoordinatesUnsupported)
+
+		_, err := getWeather(context.Background(), client, "geo:51.5,-0.12", cfg)
+		assert.ErrorIs(t, err, api.ErrCoordinatesUnsupported)
+	})
+
+	t.Run("invalid geo uri", func(t *testing.T) {
+		_, err := getWeather(context.Background(), new(MockWeatherClient), "geo:oops", cfg)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadPickedCity(t *testing.T) {
+	tempDir := t.TempDir()
+	originalGetCacheDir := cache.GetCacheDir
+	defer func() { cache.GetCacheDir = originalGetCacheDir }()
+	cache.GetCacheDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	picked := &models.City{Name: "Springfield", Country: "US", Lat: 39.8017, Lon: -89.6436}
+	cfg := &config.Config{}
+
+	t.Run("looked up by coordinates", func(t *testing.T) {
+		var queries []string
+		load := func(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error) {
+			queries = append(queries, city)
+			return &api.WeatherResponse{City: &models.City{Name: "39.8017, -89.6436"}}, nil
+		}
+
+		weather, err := loadPickedCity(context.Background(), "springfield", picked, load, cfg, nil, &CLI{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"geo:39.8017,-89.6436"}, queries)
+		assert.Equal(t, "Springfield", weather.City.Name)
+	})
+
+	t.Run("by name when coordinates are unsupported", func(t *testing.T) {
+		var queries []string
+		load := func(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error) {
+			queries = append(queries, city)
+			if models.IsGeoURI(city) {
+				return nil, api.ErrCoordinatesUnsupported
+			}
+			return &api.WeatherResponse{}, nil
+		}
+
+		_, err := loadPickedCity(context.Background(), "springfield", picked, load, cfg, nil, &CLI{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"geo:39.8017,-89.6436", "Springfield,US"}, queries)
+	})
+
+	t.Run("cached under the typed name", func(t *testing.T) {
+		load := func(ctx context.Context, city string, cfg *config.Config, authConfig *config.AuthConfig, cli *CLI) (*api.WeatherResponse, error) {
+			return &api.WeatherResponse{City: createTestCity(), Weather: createTestWeather()}, nil
+		}
+
+		_, err := loadPickedCity(context.Background(), "springfield", picked, load, cfg, nil, &CLI{})
+		require.NoError(t, err)
+
+		cached, err := cache.Load("springfield", cfg.Provider)
+		require.NoError(t, err)
+		require.NotNil(t, cached)
+		assert.Equal(t, "Springfield", cached.Response.City.Name)
+	})
+}
+
+func TestLoadFailure(t *testing.T) {
+	ok := renderer.LocationWeather{Label: "London"}
+	failed := renderer.LocationWeather{Label: "Atlantis", Err: &api.CityNotFoundError{City: "Atlantis"}}
+	cancelled := renderer.LocationWeather{Label: "Paris", Err: components.ErrCancelled}
+
+	assert.NoError(t, loadFailure([]renderer.LocationWeather{ok, ok}))
+
+	err := loadFailure([]renderer.LocationWeather{ok, failed})
+	assert.EqualError(t, err, "failed to load weather for 1 of 2 cities")
+	assert.Equal(t, 1, ExitCode(err))
+
+	err = loadFailure([]renderer.LocationWeather{failed, cancelled, ok})
+	assert.Equal(t, ExitCancelled, ExitCode(err), "ctrl+c exits like a single city")
+}
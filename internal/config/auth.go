@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,15 +14,23 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/redact"
 	"github.com/josephburgess/gust/internal/templates"
 	"github.com/josephburgess/gust/internal/ui/output"
 )
 
 type AuthConfig struct {
+	// empty in the file when the key is in the keyring
 	APIKey     string    `json:"api_key"`
 	ServerURL  string    `json:"server_url"`
 	LastAuth   time.Time `json:"last_auth"`
 	GithubUser string    `json:"github_user"`
+	// set by SaveAuthConfig and LoadAuthConfig, false means the key is in the file
+	InKeyring bool `json:"in_keyring,omitempty"`
+	// the keyring failed the last save, so loading doesn't keep trying to move the key there.
+	// Logging in again tries it once more
+	KeyringUnavailable bool `json:"keyring_unavailable,omitempty"`
 }
 
 type GetAuthConfigPathFunc func() (string, error)
@@ -197,6 +206,8 @@ func openBrowser(url string) error {
 	return cmd.Start()
 }
 
+// the key goes in the keyring when there is one, otherwise it stays in the file,
+// which only its owner can read either way
 func SaveAuthConfig(config *AuthConfig) error {
 	configPath, err := GetAuthConfigPath()
 	if err != nil {
@@ -204,22 +215,75 @@ func SaveAuthConfig(config *AuthConfig) error {
 	}
 
 	dir := filepath.Dir(configPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	file, err := os.Create(configPath)
+	stored := *config
+	stored.InKeyring = false
+	stored.KeyringUnavailable = false
+	if keyring != nil && config.APIKey != "" {
+		// e.g. no session bus over ssh, the file is the fallback
+		if err := keyring.Set(keyringAccount, config.APIKey); err == nil {
+			stored.APIKey = ""
+			stored.InKeyring = true
+		} else {
+			stored.KeyringUnavailable = true
+		}
+	}
+
+	// OpenFile only applies the mode to new files, so an older 0644 file is fixed with Chmod
+	file, err := os.OpenFile(configPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return fmt.Errorf("failed to create auth config file: %w", err)
 	}
 	defer file.Close()
 
+	if err := file.Chmod(0600); err != nil {
+		return fmt.Errorf("failed to restrict auth config file: %w", err)
+	}
+
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(config); err != nil {
+	if err := encoder.Encode(stored); err != nil {
 		return fmt.Errorf("failed to encode auth config: %w", err)
 	}
 
+	config.InKeyring = stored.InKeyring
+	config.KeyringUnavailable = stored.KeyringUnavailable
+	return nil
+}
+
+// returned by LoadAuthConfig when the key is in the keyring but it won't hand it over, e.g. it's
+// locked. Not the same as being logged out, logging in again would only store a second key
+var ErrKeyringUnreadable = errors.New("couldn't read the api key from the system keyring")
+
+// returned by DeleteAuthConfig when the file is gone but the keyring couldn't be cleared
+var ErrKeyringEntryLeft = errors.New("the api key may still be in the system keyring")
+
+// removing credentials that were never saved is not an error. The file always goes first,
+// so an unreachable keyring can't leave a plaintext key behind
+func DeleteAuthConfig() error {
+	configPath, err := GetAuthConfigPath()
+	if err != nil {
+		return err
+	}
+
+	var stored AuthConfig
+	if data, err := os.ReadFile(configPath); err == nil {
+		_ = json.Unmarshal(data, &stored)
+	}
+
+	if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove auth config file: %w", err)
+	}
+
+	if stored.InKeyring && keyring != nil {
+		if err := keyring.Delete(keyringAccount); err != nil && !errors.Is(err, errSecretNotFound) {
+			return fmt.Errorf("%w: %v", ErrKeyringEntryLeft, err)
+		}
+	}
+
 	return nil
 }
 
@@ -229,20 +293,44 @@ func LoadAuthConfig() (*AuthConfig, error) {
 		return nil, err
 	}
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	info, err := os.Stat(configPath)
+	if os.IsNotExist(err) {
 		return nil, nil
 	}
 
-	file, err := os.Open(configPath)
+	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open auth config file: %w", err)
 	}
-	defer file.Close()
 
 	var config AuthConfig
-	if err := json.NewDecoder(file).Decode(&config); err != nil {
+	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to decode auth config: %w", err)
 	}
 
+	if config.InKeyring {
+		if keyring == nil {
+			return nil, fmt.Errorf("%w: it isn't available - run 'gust auth login' or 'gust auth key <api-key>' again", ErrKeyringUnreadable)
+		}
+		if config.APIKey, err = keyring.Get(keyringAccount); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrKeyringUnreadable, err)
+		}
+	}
+	redact.Secret(config.APIKey)
+
+	// windows has no unix permissions, Go reports every file there as 0666
+	exposed := runtime.GOOS != "windows" && config.APIKey != "" && !config.InKeyring && info.Mode().Perm()&0077 != 0
+	if exposed {
+		output.PrintStderrWarning(i18n.T("%s was readable by other users, so your api key may have been copied. Consider replacing it with 'gust auth login' or 'gust auth key <api-key>'.", configPath))
+	}
+
+	// files from before the keyring, or with loose permissions, are moved over quietly.
+	// A keyring that failed before isn't tried again, it can take keyringTimeout to fail
+	if exposed || !config.InKeyring && !config.KeyringUnavailable && keyring != nil && config.APIKey != "" {
+		if err := SaveAuthConfig(&config); err != nil {
+			output.PrintStderrWarning(i18n.T("Couldn't secure %s: %v", configPath, err))
+		}
+	}
+
 	return &config, nil
 }
@@ -1,13 +1,94 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 )
 
+type fakeKeyring struct {
+	secrets map[string]string
+	err     error
+	sets    int
+}
+
+func (k *fakeKeyring) Get(account string) (string, error) {
+	secret, ok := k.secrets[account]
+	if !ok {
+		return "", errSecretNotFound
+	}
+	return secret, nil
+}
+
+func (k *fakeKeyring) Set(account, secret string) error {
+	k.sets++
+	if k.err != nil {
+		return k.err
+	}
+	k.secrets[account] = secret
+	return nil
+}
+
+func (k *fakeKeyring) Delete(account string) error {
+	if k.err != nil {
+		return k.err
+	}
+	if _, ok := k.secrets[account]; !ok {
+		return errSecretNotFound
+	}
+	delete(k.secrets, account)
+	return nil
+}
+
+// a keyring that won't unlock
+type lockedKeyring struct{}
+
+func (lockedKeyring) Get(account string) (string, error) { return "", errors.New("keyring is locked") }
+func (lockedKeyring) Set(account, secret string) error   { return errors.New("keyring is locked") }
+func (lockedKeyring) Delete(account string) error        { return errors.New("keyring is locked") }
+
+// nil for no keyring, tests must never reach the real one
+func useKeyring(t *testing.T, store secretStore) {
+	original := keyring
+	t.Cleanup(func() { keyring = original })
+	keyring = store
+}
+
+func useTempAuthPath(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	original := GetAuthConfigPath
+	t.Cleanup(func() { GetAuthConfigPath = original })
+	GetAuthConfigPath = func() (string, error) { return path, nil }
+	return path
+}
+
+func readStoredAuth(t *testing.T, path string) AuthConfig {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read auth file: %v", err)
+	}
+	var stored AuthConfig
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Failed to parse auth file: %v", err)
+	}
+	return stored
+}
+
+func assertOwnerOnly(t *testing.T, path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat auth file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected the auth file to be 0600, got %o", perm)
+	}
+}
+
 func TestSaveAndLoadAuthConfig(t *testing.T) {
+	useKeyring(t, nil)
 	tempDir, err := os.MkdirTemp("", "gust-auth-test-")
 	if err != nil {
 		t.Fatalf("Failed to create temp directory: %v", err)
@@ -111,3 +192,197 @@ func contains2(path, substr string) bool {
 	}
 	return false
 }
+
+func TestSaveAuthConfigFileFallback(t *testing.T) {
+	useKeyring(t, &fakeKeyring{secrets: map[string]string{}, err: errors.New("no session bus")})
+	path := useTempAuthPath(t)
+
+	// an older gust left it readable by everyone
+	if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveAuthConfig(&AuthConfig{APIKey: "file-api-key"}); err != nil {
+		t.Fatalf("Failed to save auth config: %v", err)
+	}
+
+	assertOwnerOnly(t, path)
+	if stored := readStoredAuth(t, path); stored.APIKey != "file-api-key" || stored.InKeyring {
+		t.Errorf("Expected the key in the file when the keyring fails, got %+v", stored)
+	}
+}
+
+func TestSaveAuthConfigKeyring(t *testing.T) {
+	store := &fakeKeyring{secrets: map[string]string{}}
+	useKeyring(t, store)
+	path := useTempAuthPath(t)
+
+	auth := &AuthConfig{APIKey: "keyring-api-key", GithubUser: "testuser"}
+	if err := SaveAuthConfig(auth); err != nil {
+		t.Fatalf("Failed to save auth config: %v", err)
+	}
+
+	if stored := readStoredAuth(t, path); stored.APIKey != "" || !stored.InKeyring || stored.GithubUser != "testuser" {
+		t.Errorf("Expected only the key to move to the keyring, got %+v", stored)
+	}
+	if store.secrets[keyringAccount] != "keyring-api-key" {
+		t.Errorf("Expected the key in the keyring, got %v", store.secrets)
+	}
+
+	loaded, err := LoadAuthConfig()
+	if err != nil {
+		t.Fatalf("Failed to load auth config: %v", err)
+	}
+	if loaded.APIKey != "keyring-api-key" || !loaded.InKeyring {
+		t.Errorf("Expected the key back from the keyring, got %+v", loaded)
+	}
+
+	if err := DeleteAuthConfig(); err != nil {
+		t.Fatalf("Failed to delete auth config: %v", err)
+	}
+	if _, ok := store.secrets[keyringAccount]; ok {
+		t.Error("Expected logging out to clear the keyring")
+	}
+}
+
+func TestLoadAuthConfigMigratesPlaintextFile(t *testing.T) {
+	store := &fakeKeyring{secrets: map[string]string{}}
+	useKeyring(t, store)
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"api_key": "old-api-key", "github_user": "testuser"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAuthConfig()
+	if err != nil {
+		t.Fatalf("Failed to load auth config: %v", err)
+	}
+
+	if loaded.APIKey != "old-api-key" || loaded.GithubUser != "testuser" {
+		t.Errorf("Expected the old file's contents, got %+v", loaded)
+	}
+	if store.secrets[keyringAccount] != "old-api-key" {
+		t.Errorf("Expected the key to be moved to the keyring, got %v", store.secrets)
+	}
+	if stored := readStoredAuth(t, path); stored.APIKey != "" || !stored.InKeyring {
+		t.Errorf("Expected the key to be gone from the file, got %+v", stored)
+	}
+	assertOwnerOnly(t, path)
+}
+
+func TestLoadAuthConfigTriesUnavailableKeyringOnce(t *testing.T) {
+	store := &fakeKeyring{secrets: map[string]string{}, err: errors.New("no session bus")}
+	useKeyring(t, store)
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"api_key": "old-api-key"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		loaded, err := LoadAuthConfig()
+		if err != nil {
+			t.Fatalf("Failed to load auth config: %v", err)
+		}
+		if loaded.APIKey != "old-api-key" {
+			t.Errorf("Expected the key from the file, got %+v", loaded)
+		}
+	}
+
+	if store.sets != 1 {
+		t.Errorf("Expected the keyring to be tried once, got %d tries", store.sets)
+	}
+	if stored := readStoredAuth(t, path); stored.APIKey != "old-api-key" || !stored.KeyringUnavailable {
+		t.Errorf("Expected the key to stay in the file and the failure to be recorded, got %+v", stored)
+	}
+
+	// logging in again gives the keyring another chance
+	store.err = nil
+	if err := SaveAuthConfig(&AuthConfig{APIKey: "new-api-key"}); err != nil {
+		t.Fatalf("Failed to save auth config: %v", err)
+	}
+	if stored := readStoredAuth(t, path); stored.APIKey != "" || !stored.InKeyring || stored.KeyringUnavailable {
+		t.Errorf("Expected the key in the keyring, got %+v", stored)
+	}
+}
+
+func TestLoadAuthConfigRestrictsExposedFile(t *testing.T) {
+	useKeyring(t, nil)
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"api_key": "old-api-key"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAuthConfig()
+	if err != nil {
+		t.Fatalf("Failed to load auth config: %v", err)
+	}
+
+	if loaded.APIKey != "old-api-key" {
+		t.Errorf("Expected the key from the file, got %+v", loaded)
+	}
+	assertOwnerOnly(t, path)
+}
+
+func TestLoadAuthConfigMissingKeyring(t *testing.T) {
+	useKeyring(t, nil)
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"in_keyring": true}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadAuthConfig(); !errors.Is(err, ErrKeyringUnreadable) {
+		t.Errorf("Expected ErrKeyringUnreadable when the keyring holding the key is unavailable, got %v", err)
+	}
+}
+
+func TestLoadAuthConfigLockedKeyring(t *testing.T) {
+	useKeyring(t, &lockedKeyring{})
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"in_keyring": true}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := LoadAuthConfig()
+	if !errors.Is(err, ErrKeyringUnreadable) || auth != nil {
+		t.Errorf("Expected ErrKeyringUnreadable, got %+v, %v", auth, err)
+	}
+}
+
+func TestDeleteAuthConfigWithoutKeyring(t *testing.T) {
+	store := &fakeKeyring{secrets: map[string]string{}, err: errors.New("no session bus")}
+	useKeyring(t, store)
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"api_key": "file-api-key"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteAuthConfig(); err != nil {
+		t.Errorf("Expected a key in the file to need no keyring, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the auth file to be removed, got %v", err)
+	}
+}
+
+func TestDeleteAuthConfigKeyringFails(t *testing.T) {
+	store := &fakeKeyring{secrets: map[string]string{}, err: errors.New("no session bus")}
+	useKeyring(t, store)
+	path := useTempAuthPath(t)
+
+	if err := os.WriteFile(path, []byte(`{"in_keyring": true}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteAuthConfig(); !errors.Is(err, ErrKeyringEntryLeft) {
+		t.Errorf("Expected ErrKeyringEntryLeft, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the auth file to be removed even though the keyring failed, got %v", err)
+	}
+}
@@ -5,14 +5,75 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/josephburgess/gust/internal/models"
 )
 
+const DefaultCacheTTL = 10 * time.Minute
+
+// tips shown under the detailed view, the compact view shows one
+const DefaultMaxTips = 3
+
 type Config struct {
 	DefaultCity string `json:"default_city"`
-	ApiUrl      string `json:"api_url"`
-	Units       string `json:"units"`
-	DefaultView string `json:"default_view"`
-	ShowTips    bool   `json:"show_tips"`
+	// set when the default city was picked from search results, takes precedence over the name
+	DefaultCoords *models.Coordinates `json:"default_coords,omitempty"`
+	// the picked city's country, so looking the default up by name can't land somewhere else
+	DefaultCountry string `json:"default_country,omitempty"`
+	ApiUrl         string `json:"api_url"`
+	Units          string `json:"units"`
+	DefaultView    string `json:"default_view"`
+	ShowTips       bool   `json:"show_tips"`
+	// show times in this machine's zone rather than the forecast location's
+	LocalTime bool   `json:"local_time,omitempty"`
+	Provider  string `json:"provider,omitempty"`
+	// e.g. "de", empty follows the locale
+	Language string `json:"language,omitempty"`
+	// a built in theme like "rose-pine-dawn" or a file in ThemesDir, empty uses the default
+	Theme string `json:"theme,omitempty"`
+	// minutes, 0 uses DefaultCacheTTL and a negative value disables the cache
+	CacheTTL int `json:"cache_ttl_minutes,omitempty"`
+	// seconds a weather request may take, 0 uses the provider's default
+	Timeout   int        `json:"timeout_seconds,omitempty"`
+	Locations []Location `json:"locations,omitempty"`
+	// threshold rules checked by `gust check` and `gust watch`, e.g. "temp below 0 in the next 12h"
+	Rules []string `json:"rules,omitempty"`
+	// added to the built in tips, one with the same name replaces it
+	Tips []Tip `json:"tips,omitempty"`
+	// 0 uses DefaultMaxTips
+	MaxTips int `json:"max_tips,omitempty"`
+
+	// per-quantity overrides, empty follows Units
+	TemperatureUnit   string `json:"temperature_unit,omitempty"`
+	WindUnit          string `json:"wind_unit,omitempty"`
+	PressureUnit      string `json:"pressure_unit,omitempty"`
+	PrecipitationUnit string `json:"precipitation_unit,omitempty"`
+	VisibilityUnit    string `json:"visibility_unit,omitempty"`
+
+	// shell command run by `gust watch` for each new alert, empty sends a desktop notification
+	NotifyCommand string `json:"notify_command,omitempty"`
+}
+
+// a saved place, referred to by alias as `gust @home` or `gust home`
+type Location struct {
+	Alias string `json:"alias"`
+	City  string `json:"city"`
+}
+
+// shown when every one of its rules matches, higher priorities first
+type Tip struct {
+	Name string `json:"name"`
+	// rules like those in Rules, e.g. "temp below 5C now", none means it's a fallback for when nothing else matches
+	When     []string `json:"when,omitempty"`
+	Priority int      `json:"priority"`
+	// a text/template, {{.Time}} and {{.Value}} are the first rule's match
+	Message string `json:"message"`
+	// messages by language code, e.g. "de"
+	Translations map[string]string `json:"translations,omitempty"`
+	// hides a built in tip of the same name
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 type GetConfigPathFunc func() (string, error)
@@ -34,6 +95,15 @@ func defaultGetConfigPath() (string, error) {
 	return filepath.Join(configDir, "config.json"), nil
 }
 
+// user themes live here as <name>.json
+func ThemesDir() (string, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "themes"), nil
+}
+
 func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
 	if err != nil {
@@ -66,6 +136,82 @@ func Load() (*Config, error) {
 	return &config, nil
 }
 
+func (c *Config) CacheDuration() time.Duration {
+	switch {
+	case c.CacheTTL < 0:
+		return 0
+	case c.CacheTTL == 0:
+		return DefaultCacheTTL
+	default:
+		return time.Duration(c.CacheTTL) * time.Minute
+	}
+}
+
+// 0 leaves the timeout to the provider
+func (c *Config) RequestTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 0
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+func (c *Config) TipLimit() int {
+	if c.MaxTips <= 0 {
+		return DefaultMaxTips
+	}
+	return c.MaxTips
+}
+
+// what to fetch when no city is given, coordinates win so an ambiguous name can't drift
+func (c *Config) DefaultLocation() string {
+	if c.DefaultCoords != nil {
+		return c.DefaultCoords.GeoURI()
+	}
+	return c.DefaultCity
+}
+
+// aliases are matched case-insensitively, with or without a leading @
+func (c *Config) FindLocation(alias string) (*Location, bool) {
+	alias = strings.TrimPrefix(alias, "@")
+	for i := range c.Locations {
+		if strings.EqualFold(c.Locations[i].Alias, alias) {
+			return &c.Locations[i], true
+		}
+	}
+	return nil, false
+}
+
+// replaces the city of an existing alias, otherwise appends
+func (c *Config) AddLocation(alias, city string) {
+	alias = strings.TrimPrefix(alias, "@")
+	if existing, ok := c.FindLocation(alias); ok {
+		existing.City = city
+		return
+	}
+	c.Locations = append(c.Locations, Location{Alias: alias, City: city})
+}
+
+func (c *Config) RemoveLocation(alias string) bool {
+	alias = strings.TrimPrefix(alias, "@")
+	for i, location := range c.Locations {
+		if strings.EqualFold(location.Alias, alias) {
+			c.Locations = append(c.Locations[:i], c.Locations[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// n counts from 1, as `gust rules list` shows them
+func (c *Config) RemoveRule(n int) (string, bool) {
+	if n < 1 || n > len(c.Rules) {
+		return "", false
+	}
+	removed := c.Rules[n-1]
+	c.Rules = append(c.Rules[:n-1], c.Rules[n:]...)
+	return removed, true
+}
+
 func (c *Config) Save() error {
 	configPath, err := GetConfigPath()
 	if err != nil {
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/models"
 )
 
 func TestLoadAndSave(t *testing.T) {
@@ -90,3 +93,95 @@ func TestDefaultGetConfigPath(t *testing.T) {
 		t.Errorf("Expected absolute path, got %s", path)
 	}
 }
+
+func TestCacheDuration(t *testing.T) {
+	testCases := []struct {
+		ttl      int
+		expected time.Duration
+	}{
+		{0, DefaultCacheTTL},
+		{5, 5 * time.Minute},
+		{-1, 0},
+	}
+
+	for _, tc := range testCases {
+		cfg := &Config{CacheTTL: tc.ttl}
+		if got := cfg.CacheDuration(); got != tc.expected {
+			t.Errorf("CacheDuration() with ttl %d = %v, want %v", tc.ttl, got, tc.expected)
+		}
+	}
+}
+
+func TestLocations(t *testing.T) {
+	cfg := &Config{}
+
+	cfg.AddLocation("home", "London")
+	cfg.AddLocation("@Work", "Berlin")
+
+	if len(cfg.Locations) != 2 {
+		t.Fatalf("Expected 2 locations, got %d", len(cfg.Locations))
+	}
+
+	location, ok := cfg.FindLocation("@HOME")
+	if !ok || location.City != "London" {
+		t.Errorf("Expected @HOME to resolve to London, got %+v", location)
+	}
+
+	cfg.AddLocation("work", "Munich")
+	if len(cfg.Locations) != 2 {
+		t.Errorf("Adding an existing alias should replace it, got %d locations", len(cfg.Locations))
+	}
+	if location, _ := cfg.FindLocation("work"); location.City != "Munich" {
+		t.Errorf("Expected work to be updated to Munich, got %s", location.City)
+	}
+
+	if !cfg.RemoveLocation("home") {
+		t.Error("Expected home to be removed")
+	}
+	if cfg.RemoveLocation("home") {
+		t.Error("Removing a missing alias should report false")
+	}
+	if _, ok := cfg.FindLocation("home"); ok {
+		t.Error("home should no longer resolve")
+	}
+}
+
+func TestDefaultLocation(t *testing.T) {
+	cfg := &Config{DefaultCity: "Springfield"}
+	if got := cfg.DefaultLocation(); got != "Springfield" {
+		t.Errorf("Expected the city name without coordinates, got %s", got)
+	}
+
+	cfg.DefaultCoords = &models.Coordinates{Lat: 39.8017, Lon: -89.6436}
+	if got := cfg.DefaultLocation(); got != "geo:39.8017,-89.6436" {
+		t.Errorf("Expected a geo URI once coordinates are set, got %s", got)
+	}
+}
+
+func TestResolveDisplayUnits(t *testing.T) {
+	testCases := []struct {
+		name      string
+		units     string
+		overrides DisplayUnits
+		expected  DisplayUnits
+	}{
+		{"metric", "metric", DisplayUnits{}, DisplayUnits{"C", "km/h", "hPa", "mm", "km"}},
+		{"imperial", "imperial", DisplayUnits{}, DisplayUnits{"F", "mph", "hPa", "mm", "km"}},
+		{"standard", "standard", DisplayUnits{}, DisplayUnits{"K", "km/h", "hPa", "mm", "km"}},
+		{"knots with celsius", "metric", DisplayUnits{Wind: "kn"}, DisplayUnits{"C", "kn", "hPa", "mm", "km"}},
+		{"inches of rain", "imperial", DisplayUnits{Precipitation: "in", Pressure: "inHg", Visibility: "mi"}, DisplayUnits{"F", "mph", "inHg", "in", "mi"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveDisplayUnits(tc.units, tc.overrides); got != tc.expected {
+				t.Errorf("ResolveDisplayUnits() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+
+	cfg := &Config{Units: "metric", WindUnit: "bft"}
+	if got := cfg.DisplayUnits().Wind; got != "bft" {
+		t.Errorf("DisplayUnits().Wind = %q, want bft", got)
+	}
+}
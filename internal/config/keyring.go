@@ -0,0 +1,145 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const keyringService = "gust"
+
+// the entry the api key is stored under
+const keyringAccount = "api_key"
+
+var errSecretNotFound = errors.New("secret not found in keyring")
+
+// somewhere the OS keeps secrets away from other users
+type secretStore interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// for tests, nil when there's no keyring and the key stays in the auth file
+var keyring secretStore = systemKeyring()
+
+// macOS keychain or the Secret Service, through their command line tools so gust needs no cgo
+func systemKeyring() secretStore {
+	switch {
+	case runtime.GOOS == "darwin":
+		if path, err := exec.LookPath("security"); err == nil {
+			return macKeychain{path: path}
+		}
+	case runtime.GOOS != "windows":
+		if path, err := exec.LookPath("secret-tool"); err == nil {
+			return secretService{path: path}
+		}
+	}
+	return nil
+}
+
+// a locked keyring can prompt for a password, a missing session bus shouldn't stall every command
+const keyringTimeout = 30 * time.Second
+
+// runs a keyring tool, with stdin when it's not empty
+func runKeyringTool(path string, stdin string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s: %w: %s", filepath.Base(path), err, message)
+		}
+		return "", fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// the freedesktop Secret Service, e.g. GNOME Keyring or KWallet. On a server without
+// a session bus secret-tool fails and the key falls back to the file
+type secretService struct {
+	path string
+}
+
+func (s secretService) Get(account string) (string, error) {
+	secret, err := runKeyringTool(s.path, "", "lookup", "service", keyringService, "account", account)
+	if err != nil {
+		var exitErr *exec.ExitError
+		// lookup exits 1 with no output when there's nothing stored
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", errSecretNotFound
+		}
+		return "", err
+	}
+	if secret == "" {
+		return "", errSecretNotFound
+	}
+	return secret, nil
+}
+
+// the secret goes over stdin so it never shows up in ps
+func (s secretService) Set(account, secret string) error {
+	_, err := runKeyringTool(s.path, secret, "store", "--label=gust api key", "service", keyringService, "account", account)
+	return err
+}
+
+func (s secretService) Delete(account string) error {
+	_, err := runKeyringTool(s.path, "", "clear", "service", keyringService, "account", account)
+	return err
+}
+
+type macKeychain struct {
+	path string
+}
+
+func (k macKeychain) Get(account string) (string, error) {
+	secret, err := runKeyringTool(k.path, "", "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	if err != nil {
+		var exitErr *exec.ExitError
+		// errSecItemNotFound
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 44 {
+			return "", errSecretNotFound
+		}
+		return "", err
+	}
+	return secret, nil
+}
+
+// security's interactive mode reads the command from stdin, keeping the secret out of ps
+func (k macKeychain) Set(account, secret string) error {
+	_, err := runKeyringTool(k.path, addPasswordCommand(account, secret), "-i")
+	return err
+}
+
+// security -i splits its input into words the way a shell does, so quoting keeps a
+// key with spaces or quotes in one argument
+func addPasswordCommand(account, secret string) string {
+	return fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", quote(keyringService), quote(account), quote(secret))
+}
+
+func (k macKeychain) Delete(account string) error {
+	_, err := runKeyringTool(k.path, "", "delete-generic-password", "-s", keyringService, "-a", account)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 44 {
+		return nil
+	}
+	return err
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// the words a shell splits the command into, which is how security -i reads it
+func shellWords(t *testing.T, command string) []string {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh to split the command with")
+	}
+	out, err := exec.Command(sh, "-c", `eval "set -- $1"; for word; do printf '%s\0' "$word"; done`, "sh", command).Output()
+	if err != nil {
+		t.Fatalf("Failed to split %q: %v", command, err)
+	}
+	return strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+}
+
+func TestAddPasswordCommandQuoting(t *testing.T) {
+	secrets := []string{
+		"plain-api-key",
+		"with spaces",
+		"it's quoted",
+		`double "quoted"`,
+		`back\slash`,
+		"$HOME and `ls`",
+		"''",
+	}
+
+	for _, secret := range secrets {
+		words := shellWords(t, addPasswordCommand(keyringAccount, secret))
+		expected := []string{"add-generic-password", "-U", "-s", keyringService, "-a", keyringAccount, "-w", secret}
+		if !reflect.DeepEqual(words, expected) {
+			t.Errorf("Expected %q, got %q", expected, words)
+		}
+	}
+}
+
+func TestMacKeychainSetUsesStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a shell script standing in for security")
+	}
+	dir := t.TempDir()
+	record := filepath.Join(dir, "record")
+	script := filepath.Join(dir, "security")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho \"$@\" > "+record+"\ncat >> "+record+"\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (macKeychain{path: script}).Set(keyringAccount, "it's secret"); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	data, err := os.ReadFile(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "-i\n" + addPasswordCommand(keyringAccount, "it's secret")
+	if string(data) != expected {
+		t.Errorf("Expected the secret on stdin only, got %q", data)
+	}
+}
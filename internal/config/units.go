@@ -0,0 +1,48 @@
+package config
+
+// the unit each quantity is shown in
+type DisplayUnits struct {
+	Temperature   string
+	Wind          string
+	Pressure      string
+	Precipitation string
+	Visibility    string
+}
+
+// units follow the metric/imperial/standard setting unless overridden one by one
+func ResolveDisplayUnits(units string, overrides DisplayUnits) DisplayUnits {
+	resolved := DisplayUnits{Temperature: "C", Wind: "km/h", Pressure: "hPa", Precipitation: "mm", Visibility: "km"}
+	switch units {
+	case "imperial":
+		resolved.Temperature, resolved.Wind = "F", "mph"
+	case "standard":
+		resolved.Temperature = "K"
+	}
+
+	if overrides.Temperature != "" {
+		resolved.Temperature = overrides.Temperature
+	}
+	if overrides.Wind != "" {
+		resolved.Wind = overrides.Wind
+	}
+	if overrides.Pressure != "" {
+		resolved.Pressure = overrides.Pressure
+	}
+	if overrides.Precipitation != "" {
+		resolved.Precipitation = overrides.Precipitation
+	}
+	if overrides.Visibility != "" {
+		resolved.Visibility = overrides.Visibility
+	}
+	return resolved
+}
+
+func (c *Config) DisplayUnits() DisplayUnits {
+	return ResolveDisplayUnits(c.Units, DisplayUnits{
+		Temperature:   c.TemperatureUnit,
+		Wind:          c.WindUnit,
+		Pressure:      c.PressureUnit,
+		Precipitation: c.PrecipitationUnit,
+		Visibility:    c.VisibilityUnit,
+	})
+}
@@ -0,0 +1,315 @@
+package i18n
+
+var german = map[string]string{
+	// date layouts
+	"Mon Jan 2":         "Mon 2. Jan",
+	"Mon Jan 2 15:04":   "Mon 2. Jan 15:04",
+	"Mon 02 Jan":        "Mon 02. Jan",
+	"Monday 02 January": "Monday, 02. January",
+
+	// forecasts
+	"WEATHER FOR %s":                                 "WETTER FÜR %s",
+	"%s WEATHER":                                     "WETTER IN %s",
+	"5-DAY FORECAST FOR %s":                          "5-TAGE-VORHERSAGE FÜR %s",
+	"24H FORECAST FOR %s":                            "24-STUNDEN-VORHERSAGE FÜR %s",
+	"48H FORECAST FOR %s":                            "48-STUNDEN-VORHERSAGE FÜR %s",
+	"NEXT HOUR IN %s":                                "NÄCHSTE STUNDE IN %s",
+	"WEATHER ALERTS FOR %s":                          "WETTERWARNUNGEN FÜR %s",
+	"SAVED LOCATIONS":                                "GESPEICHERTE ORTE",
+	"Current Conditions: %s %s":                      "Aktuelles Wetter: %s %s",
+	"Temperature: %s %s (F/L: %s)":                   "Temperatur: %s %s (gefühlt: %s)",
+	"Humidity: %d%% %s":                              "Luftfeuchtigkeit: %d%% %s",
+	"UV Index: %.1f ☀️":                              "UV-Index: %.1f ☀️",
+	"UV Index: %.1f":                                 "UV-Index: %.1f",
+	"Cloud coverage: %d%% ☁️":                        "Bewölkung: %d%% ☁️",
+	"Visibility: %s":                                 "Sichtweite: %s",
+	"Sunrise: %s %s  Sunset: %s %s  (%s)":            "Sonnenaufgang: %s %s  Sonnenuntergang: %s %s  (%s)",
+	"Wind: %s %s %s (Gusts: %s)":                     "Wind: %s %s %s (Böen: %s)",
+	"Rain: %s (last hour) 🌧️":                        "Regen: %s (letzte Stunde) 🌧️",
+	"Snow: %s (last hour) ❄️":                        "Schnee: %s (letzte Stunde) ❄️",
+	"⚠️  There are %d weather alerts for this area.": "⚠️  Für dieses Gebiet gibt es %d Wetterwarnungen.",
+	"Use 'gust alerts %s' to view them.":             "Mit 'gust alerts %s' anzeigen.",
+	"⚠️ %d alerts":                                   "⚠️ %d Warnungen",
+	"High/Low: %s/%s %s":                             "Max/Min: %s/%s %s",
+	"Morning: %s  Day: %s  Evening: %s  Night: %s":   "Morgens: %s  Tagsüber: %s  Abends: %s  Nachts: %s",
+	"Conditions: %s":                                 "Wetter: %s",
+	"Precipitation: %d%% chance":                     "Niederschlag: %d%% Wahrscheinlichkeit",
+	"Rain: %s 🌧️":                                    "Regen: %s 🌧️",
+	"Snow: %s ❄️":                                    "Schnee: %s ❄️",
+	"%.0f%% chance of precipitation":                 "%.0f%% Niederschlagswahrscheinlichkeit",
+	"Rain: %s/h":                                     "Regen: %s/h",
+	"Snow: %s/h":                                     "Schnee: %s/h",
+	"No weather alerts for this area.":               "Keine Wetterwarnungen für dieses Gebiet.",
+	"Issued by: %s":                                  "Herausgegeben von: %s",
+	"Valid: %s to %s":                                "Gültig: %s bis %s",
+	"No minute by minute forecast available for this location": "Für diesen Ort gibt es keine minutengenaue Vorhersage",
+	"No rain expected in the next hour":                        "In der nächsten Stunde wird kein Regen erwartet",
+	"Rain now":                                                 "Jetzt Regen",
+	"Rain starting in %d min":                                  "Regen in %d Min.",
+	", stopping around %s":                                     ", endet gegen %s",
+	", lasting at least the next hour":                         ", mindestens die ganze nächste Stunde",
+	"now":                                                      "jetzt",
+	"Not enough hourly data to draw a graph.":                  "Nicht genug stündliche Daten für ein Diagramm.",
+	"Temperature":                                              "Temperatur",
+	"Chance of precipitation":                                  "Niederschlagswahrscheinlichkeit",
+	"No precipitation expected in the next 48 hours ☀️":        "In den nächsten 48 Stunden wird kein Niederschlag erwartet ☀️",
+	"Excellent":                                                "Ausgezeichnet",
+	"Good":                                                     "Gut",
+	"Moderate":                                                 "Mäßig",
+	"Poor":                                                     "Schlecht",
+
+	// conditions open-meteo reports
+	"clear sky":              "klarer Himmel",
+	"mainly clear":           "überwiegend klar",
+	"partly cloudy":          "teilweise bewölkt",
+	"overcast":               "bedeckt",
+	"fog":                    "Nebel",
+	"light drizzle":          "leichter Nieselregen",
+	"drizzle":                "Nieselregen",
+	"heavy drizzle":          "starker Nieselregen",
+	"freezing drizzle":       "gefrierender Nieselregen",
+	"light rain":             "leichter Regen",
+	"moderate rain":          "mäßiger Regen",
+	"heavy rain":             "starker Regen",
+	"freezing rain":          "gefrierender Regen",
+	"light snow":             "leichter Schneefall",
+	"snow":                   "Schneefall",
+	"heavy snow":             "starker Schneefall",
+	"snow grains":            "Schneegriesel",
+	"light shower rain":      "leichte Regenschauer",
+	"shower rain":            "Regenschauer",
+	"heavy shower rain":      "starke Regenschauer",
+	"light shower snow":      "leichte Schneeschauer",
+	"shower snow":            "Schneeschauer",
+	"thunderstorm":           "Gewitter",
+	"thunderstorm with hail": "Gewitter mit Hagel",
+	"unknown":                "unbekannt",
+
+	// tips
+	"It might be snowing right now! Stay warm and take care on slippery surfaces! ⛄":        "Es schneit vielleicht gerade! Zieh dich warm an und pass auf rutschigen Wegen auf! ⛄",
+	"It might be raining right now - don't go out without an umbrella! ☔":                   "Es regnet vielleicht gerade - geh nicht ohne Regenschirm raus! ☔",
+	"Snow expected around {{.Time}} - dress warmly and wear appropriate footwear! ❄️":       "Schnee gegen {{.Time}} erwartet - zieh dich warm an und trag passende Schuhe! ❄️",
+	"Rain expected around {{.Time}} - don't forget your umbrella! ☔":                        "Regen gegen {{.Time}} erwartet - vergiss deinen Regenschirm nicht! ☔",
+	"Rain likely around {{.Time}} ({{.Value}} chance) - an umbrella might come in handy! ☔": "Regen wahrscheinlich gegen {{.Time}} ({{.Value}} Wahrscheinlichkeit) - ein Regenschirm könnte nützlich sein! ☔",
+	"It's quite cold - wear a heavy coat and maybe a scarf! 🧣":                              "Es ist ziemlich kalt - trag einen dicken Mantel und vielleicht einen Schal! 🧣",
+	"It's cool today - a jacket would be a good idea. 🧥":                                    "Heute ist es kühl - eine Jacke wäre eine gute Idee. 🧥",
+	"It's hot today - stay hydrated and wear sunscreen! 🧴":                                  "Heute ist es heiß - trink genug und benutz Sonnencreme! 🧴",
+	"UV index is high ({{.Value}}) - wear sunscreen and maybe a hat! 🧢":                     "Der UV-Index ist hoch ({{.Value}}) - benutz Sonnencreme und trag vielleicht einen Hut! 🧢",
+	"It's quite windy today ({{.Value}}) - secure any loose items outdoors! 💨":              "Heute ist es ziemlich windig ({{.Value}}) - sichere lose Gegenstände im Freien! 💨",
+	"Conditions look fine, enjoy your day! 🌤️":                                              "Die Bedingungen sehen gut aus, genieß deinen Tag! 🌤️",
+
+	// dashboard
+	"Current":                          "Aktuell",
+	"Hourly":                           "Stündlich",
+	"Daily":                            "Täglich",
+	"Alerts":                           "Warnungen",
+	"Couldn't load weather for %s: %v": "Wetter für %s konnte nicht geladen werden: %v",
+	"Fetching weather for %s...":       "Wetter für %s wird abgerufen...",
+	"Fetching weather for %s... (attempt %d of %d)": "Wetter für %s wird abgerufen... (Versuch %d von %d)",
+	"refreshing":           "aktualisiere",
+	"updated %s · next %s": "aktualisiert %s · nächste %s",
+	"←/→ tabs • ↑/↓ select • r refresh • q quit": "←/→ Tabs • ↑/↓ Auswahl • r aktualisieren • q beenden",
+	"⚠️ refresh failed: %v":                      "⚠️ Aktualisierung fehlgeschlagen: %v",
+	"Feels like":                                 "Gefühlt",
+	"feels":                                      "gefühlt",
+	"Humidity":                                   "Luftfeuchtigkeit",
+	"Wind":                                       "Wind",
+	"Gusts":                                      "Böen",
+	"UV index":                                   "UV-Index",
+	"Pressure":                                   "Luftdruck",
+	"Visibility":                                 "Sichtweite",
+	"Clouds":                                     "Bewölkung",
+	"Sunrise":                                    "Sonnenaufgang",
+	"Sunset":                                     "Sonnenuntergang",
+	"Morning":                                    "Morgens",
+	"Day":                                        "Tagsüber",
+	"Evening":                                    "Abends",
+	"Night":                                      "Nachts",
+	"Rain chance":                                "Regenrisiko",
+	"Rain":                                       "Regen",
+	"Snow":                                       "Schnee",
+	"No hourly forecast available":               "Keine stündliche Vorhersage verfügbar",
+	"No daily forecast available":                "Keine Tagesvorhersage verfügbar",
+	"No weather alerts for this area 🎉":          "Keine Wetterwarnungen für dieses Gebiet 🎉",
+	"%s · %s until %s %s":                        "%s · %s bis %s %s",
+	"%s until %s (%s)":                           "%s bis %s (%s)",
+	"From %s (%s)":                               "Ab %s (%s)",
+	"Issued by %s":                               "Herausgegeben von %s",
+	"%s at %s":                                   "%s am %s",
+	"%s on %s":                                   "%s am %s",
+	"Offline: showing cached weather from %s (%s)": "Offline: zeige zwischengespeichertes Wetter von %s (%s)",
+	"%d minute(s) ago": "vor %d Minute(n)",
+	"%d hour(s) ago":   "vor %d Stunde(n)",
+	"%d day(s) ago":    "vor %d Tag(en)",
+
+	// setup wizard
+	"Simple terminal weather 🌤️":                                          "Einfaches Wetter im Terminal 🌤️",
+	"Enter a default city 🏙️":                                             "Gib eine Standardstadt ein 🏙️",
+	"You can enter a country code too, but use a comma! (e.g. London,GB)": "Du kannst auch einen Ländercode angeben, aber mit Komma! (z. B. Berlin,DE)",
+	"Searching for cities...":                                             "Suche nach Städten...",
+	"Looking for \"%s\"":                                                  "Suche nach \"%s\"",
+	"Select your town or city: 🏙️":                                        "Wähle deinen Ort: 🏙️",
+	"No cities found. Please try a different search term.":                "Keine Städte gefunden. Bitte versuch einen anderen Suchbegriff.",
+	"No cities found. Please try a different search.":                     "Keine Städte gefunden. Bitte versuch eine andere Suche.",
+	"Press Enter to select or Esc to search again":                        "Enter zum Auswählen, Esc für eine neue Suche",
+	"Choose your preferred units: 🌡️":                                     "Wähle deine Einheiten: 🌡️",
+	"Press Enter to confirm":                                              "Enter zum Bestätigen",
+	"Choose your preferred view: 📊":                                       "Wähle deine Ansicht: 📊",
+	"GitHub Auth 🔒":                                                       "GitHub-Anmeldung 🔒",
+	"To get weather data you need to authenticate with GitHub (don't worry, no permissions requested!).": "Für Wetterdaten musst du dich bei GitHub anmelden (keine Sorge, es werden keine Berechtigungen angefragt!).",
+	"Press Enter to confirm your selection":           "Enter zum Bestätigen deiner Auswahl",
+	"✓ Setup complete! 🎉":                             "✓ Einrichtung abgeschlossen! 🎉",
+	"Default city: %s 🏙️":                             "Standardstadt: %s 🏙️",
+	"Units: %s 🌡️":                                    "Einheiten: %s 🌡️",
+	"Default view: %s 📊":                              "Standardansicht: %s 📊",
+	"Provider: %s 🛰️":                                 "Anbieter: %s 🛰️",
+	"Tips enabled 💡":                                  "Tipps aktiviert 💡",
+	"Tips disabled 💡":                                 "Tipps deaktiviert 💡",
+	"Would you like tips shown on daily forecasts? 💡": "Sollen Tipps zur Vorhersage angezeigt werden? 💡",
+	"Authenticated ✅":                                 "Angemeldet ✅",
+	"Not authenticated ❌":                             "Nicht angemeldet ❌",
+	"GitHub: %s":                                      "GitHub: %s",
+	"Choose auth method: 🔑":                           "Wähle die Anmeldemethode: 🔑",
+	"Enter your OpenWeatherMap API key: 🔑":            "Gib deinen OpenWeatherMap-API-Schlüssel ein: 🔑",
+	"Get your API key from https://home.openweathermap.org/subscriptions/unauth_subscribe/onecall_30/base": "Einen API-Schlüssel bekommst du unter https://home.openweathermap.org/subscriptions/unauth_subscribe/onecall_30/base",
+	"↓j/↑k Navigate • Enter: Select • Ctrl + C: Quit":                                                      "↓j/↑k Navigieren • Enter: Auswählen • Strg + C: Beenden",
+	"Wherever the wind blows...":              "Wohin der Wind dich trägt...",
+	"Paste your OpenWeather API key here...":  "Füge hier deinen OpenWeather-API-Schlüssel ein...",
+	"metric (°C, km/h) 🌡️":                    "metrisch (°C, km/h) 🌡️",
+	"imperial (°F, mph) 🌡️":                   "imperial (°F, mph) 🌡️",
+	"standard (K, m/s) 🌡️":                    "Standard (K, m/s) 🌡️",
+	"detailed 🌤️":                             "ausführlich 🌤️",
+	"compact 📊":                               "kompakt 📊",
+	"5-day 📆":                                 "5 Tage 📆",
+	"24-hour 🕒":                               "24 Stunden 🕒",
+	"full (current + 5-day + alerts) 📋":       "komplett (aktuell + 5 Tage + Warnungen) 📋",
+	"Yes, show weather tips":                  "Ja, Wettertipps anzeigen",
+	"No, don't show tips":                     "Nein, keine Tipps anzeigen",
+	"Yes, authenticate with GitHub 🔑":         "Ja, mit GitHub anmelden 🔑",
+	"No, I'll do it later ⏱️":                 "Nein, das mache ich später ⏱️",
+	"Use gust's authentication (recommended)": "Die Anmeldung von gust nutzen (empfohlen)",
+	"Use my own OpenWeatherMap API key":       "Meinen eigenen OpenWeatherMap-API-Schlüssel nutzen",
+	"Use Open-Meteo (free, no key needed)":    "Open-Meteo nutzen (kostenlos, kein Schlüssel nötig)",
+	"Error: %v":                               "Fehler: %v",
+	"Error searching cities: %v":              "Fehler bei der Städtesuche: %v",
+
+	// commands
+	"Starting GitHub authentication...":                                      "GitHub-Anmeldung wird gestartet...",
+	"Successfully authenticated as %s\n":                                     "Erfolgreich angemeldet als %s\n",
+	"Logged out, stored credentials removed.":                                "Abgemeldet, gespeicherte Zugangsdaten wurden entfernt.",
+	"%v, remove the \"gust\" entry from your keyring yourself":               "%v, entferne den Eintrag \"gust\" selbst aus deinem Schlüsselbund",
+	"Not authenticated. Run 'gust auth login' or 'gust auth key <api-key>'.": "Nicht angemeldet. Führe 'gust auth login' oder 'gust auth key <api-key>' aus.",
+	"No api key needed for this provider.":                                   "Dieser Anbieter braucht keinen API-Schlüssel.",
+	"Provider: %s":                                                           "Anbieter: %s",
+	"User: %s":                                                               "Benutzer: %s",
+	"Api key: %s":                                                            "API-Schlüssel: %s",
+	"%s was readable by other users, so your api key may have been copied. Consider replacing it with 'gust auth login' or 'gust auth key <api-key>'.": "%s war für andere Benutzer lesbar, dein API-Schlüssel könnte also kopiert worden sein. Ersetze ihn am besten mit 'gust auth login' oder 'gust auth key <api-key>'.",
+	"Couldn't secure %s: %v":                                  "%s konnte nicht geschützt werden: %v",
+	"Stored in: system keyring":                               "Gespeichert in: Schlüsselbund des Systems",
+	"Stored in: %s":                                           "Gespeichert in: %s",
+	"Last authenticated: %s":                                  "Zuletzt angemeldet: %s",
+	"API key updated.":                                        "API-Schlüssel aktualisiert.",
+	"%s is deprecated, use '%s' instead":                      "%s ist veraltet, nutze stattdessen '%s'",
+	"You need to authenticate with GitHub before using Gust.": "Du musst dich bei GitHub anmelden, bevor du gust nutzen kannst.",
+	"Run 'gust auth login' to authenticate or 'gust setup' to run the setup wizard.": "Führe 'gust auth login' zum Anmelden oder 'gust setup' für den Einrichtungsassistenten aus.",
+	"authentication required":                                                                "Anmeldung erforderlich",
+	"--lat and --lon must be used together":                                                  "--lat und --lon müssen zusammen angegeben werden",
+	"no saved location called %q - add it with 'gust locations add %s <city>'":               "kein gespeicherter Ort namens %q - füge ihn mit 'gust locations add %s <stadt>' hinzu",
+	"No city specified and no default city set.":                                             "Keine Stadt angegeben und keine Standardstadt festgelegt.",
+	"Specify a city: gust [city name]":                                                       "Gib eine Stadt an: gust [Stadtname]",
+	"Or set a default city: gust config set city London":                                     "Oder lege eine Standardstadt fest: gust config set city Berlin",
+	"Or run the setup wizard: gust setup":                                                    "Oder starte den Einrichtungsassistenten: gust setup",
+	"no city provided":                                                                       "keine Stadt angegeben",
+	"--pick %d is out of range, %q has %d matches":                                           "--pick %d liegt außerhalb des Bereichs, %q hat %d Treffer",
+	"Which %s did you mean? 🏙️":                                                              "Welches %s meinst du? 🏙️",
+	"%q matches %d places, re-run with --pick N to choose one:":                              "%q passt auf %d Orte, führe den Befehl mit --pick N erneut aus, um einen zu wählen:",
+	"no city selected":                                                                       "keine Stadt ausgewählt",
+	"invalid units %q, must be one of: metric, imperial, standard":                           "ungültige Einheiten %q, erlaubt sind: metric, imperial, standard",
+	"invalid view %q, must be one of: default, compact, daily, hourly, graph, nowcast, full": "ungültige Ansicht %q, erlaubt sind: default, compact, daily, hourly, graph, nowcast, full",
+	"invalid max tips %q, must be a whole number":                                            "ungültige Tippanzahl %q, muss eine ganze Zahl sein",
+	"invalid provider %q, must be one of: breeze, openweathermap, open-meteo":                "ungültiger Anbieter %q, erlaubt sind: breeze, openweathermap, open-meteo",
+	"invalid language %q, must be one of: %s":                                                "ungültige Sprache %q, erlaubt sind: %s",
+	"invalid cache ttl %q, must be a whole number of minutes":                                "ungültige Cache-Dauer %q, muss eine ganze Zahl von Minuten sein",
+	"invalid timeout %q, must be a whole number of seconds":                                  "ungültiges Zeitlimit %q, muss eine ganze Zahl von Sekunden sein",
+	"unknown config key %q, must be one of: %s":                                              "unbekannte Einstellung %q, erlaubt sind: %s",
+	"Default city": "Standardstadt",
+	"Default coordinates as geo:<lat>,<lon>, empty to use the city name": "Standardkoordinaten als geo:<lat>,<lon>, leer um den Stadtnamen zu nutzen",
+	"Units (metric, imperial, standard)":                                 "Einheiten (metric, imperial, standard)",
+	"%s (%s, or default to follow units)":                                "%s (%s, oder default um den Einheiten zu folgen)",
+	"Temperature unit":                                                   "Temperatureinheit",
+	"Wind speed unit":                                                    "Einheit der Windgeschwindigkeit",
+	"Pressure unit":                                                      "Druckeinheit",
+	"Rain and snow unit":                                                 "Einheit für Regen und Schnee",
+	"Visibility unit":                                                    "Sichtweiteneinheit",
+	"Default view (default, compact, daily, hourly, graph, nowcast, full)":          "Standardansicht (default, compact, daily, hourly, graph, nowcast, full)",
+	"Show weather tips (true, false)":                                               "Wettertipps anzeigen (true, false)",
+	"Most tips shown under the detailed view (0 for the default)":                   "Höchstzahl an Tipps unter der Detailansicht (0 für den Standard)",
+	"Show times in this machine's timezone instead of the location's (true, false)": "Zeiten in der Zeitzone dieses Rechners statt der des Ortes anzeigen (true, false)",
+	"Weather provider (breeze, openweathermap, open-meteo)":                         "Wetteranbieter (breeze, openweathermap, open-meteo)",
+	"Language for everything gust prints (%s), empty to follow LANG":                "Sprache für alle Ausgaben von gust (%s), leer um LANG zu folgen",
+	"Color theme (%s) or the name of a file in ~/.config/gust/themes":               "Farbschema (%s) oder der Name einer Datei in ~/.config/gust/themes",
+	"Custom API server URL (mostly for development)":                                "Eigene API-Server-URL (vor allem für die Entwicklung)",
+	"Minutes to cache weather for (0 for the default, negative to disable)":         "Minuten, die das Wetter zwischengespeichert wird (0 für den Standard, negativ zum Abschalten)",
+	"Seconds a weather request may take before giving up (0 for the default)":       "Sekunden, die eine Wetterabfrage dauern darf, bevor aufgegeben wird (0 für den Standard)",
+	"Command gust watch runs for new alerts, empty for desktop notifications":       "Befehl, den gust watch bei neuen Warnungen ausführt, leer für Desktop-Benachrichtigungen",
+	"%s set to %s":                                                               "%s auf %s gesetzt",
+	"failed to save config: %w":                                                  "Einstellungen konnten nicht gespeichert werden: %w",
+	"invalid value %q, must be true or false":                                    "ungültiger Wert %q, muss true oder false sein",
+	"--pretty can't be combined with --output json":                              "--pretty kann nicht mit --output json kombiniert werden",
+	"--pretty can't be combined with --plain":                                    "--pretty kann nicht mit --plain kombiniert werden",
+	"--pretty shows a single city and can't be combined with --all":              "--pretty zeigt eine einzelne Stadt und kann nicht mit --all kombiniert werden",
+	"--pretty needs an interactive terminal":                                     "--pretty braucht ein interaktives Terminal",
+	"--pretty shows a single city, drop the extra cities or --pretty":            "--pretty zeigt eine einzelne Stadt, lass die weiteren Städte oder --pretty weg",
+	"both an alias and a city are required, e.g. gust locations add home London": "Alias und Stadt sind beide nötig, z. B. gust locations add home Berlin",
+	"invalid alias %q, aliases can't contain spaces or '+'":                      "ungültiger Alias %q, Aliasse dürfen keine Leerzeichen oder '+' enthalten",
+	"Saved @%s as %s":             "@%s als %s gespeichert",
+	"no saved location called %q": "kein gespeicherter Ort namens %q",
+	"Removed @%s":                 "@%s entfernt",
+	"No saved locations yet, add one with: gust locations add home London": "Noch keine gespeicherten Orte, füge einen hinzu mit: gust locations add home Berlin",
+	"Added rule %d: %s":                                           "Regel %d hinzugefügt: %s",
+	"no rule number %d, see 'gust rules list'":                    "keine Regel Nummer %d, siehe 'gust rules list'",
+	"Removed rule: %s":                                            "Regel entfernt: %s",
+	"No rules yet, add one with: ":                                "Noch keine Regeln, füge eine hinzu mit: ",
+	"no rules to check - add one with '%s'":                       "keine Regeln zu prüfen - füge eine hinzu mit '%s'",
+	"No rules matched for %s":                                     "Keine Regel trifft für %s zu",
+	"invalid rule %q: the forecast covers the next 1 to 48 hours": "ungültige Regel %q: die Vorhersage reicht 1 bis 48 Stunden voraus",
+	"invalid rule %q: %w":                                         "ungültige Regel %q: %w",
+	"invalid rule %q: the times must differ":                      "ungültige Regel %q: die Uhrzeiten müssen sich unterscheiden",
+	"invalid rule %q, expected something like \"temp below 0 in the next 12h\"":                                         "ungültige Regel %q, erwartet wird etwas wie \"temp below 0 in the next 12h\"",
+	"invalid rule %q: unknown quantity %q, must be one of: temp, feels like, wind, gust, pop, rain, snow, humidity, uv": "ungültige Regel %q: unbekannte Größe %q, erlaubt sind: temp, feels like, wind, gust, pop, rain, snow, humidity, uv",
+	"invalid rule %q: pop is a forecast, use a time in the future":                                                      "ungültige Regel %q: pop ist eine Vorhersage, nutze einen Zeitpunkt in der Zukunft",
+	"invalid time %q, use 24 hour HH:MM":                                                                                "ungültige Uhrzeit %q, nutze das 24-Stunden-Format HH:MM",
+	"%s is a percentage":                                                                                                "%s ist ein Prozentwert",
+	"%s has no unit":                                                                                                    "%s hat keine Einheit",
+	"Defaults not set, running setup...":                                                                                "Keine Standardeinstellungen, Einrichtung wird gestartet...",
+	"Running setup wizard...":                                                                                           "Einrichtungsassistent wird gestartet...",
+	"Setup complete! Run 'gust' to check the weather for your default city.":                                            "Einrichtung abgeschlossen! Führe 'gust' aus, um das Wetter für deine Standardstadt zu sehen.",
+	"the %s provider doesn't report weather alerts, pick another with 'gust config set provider'":                       "der Anbieter %s meldet keine Wetterwarnungen, wähle einen anderen mit 'gust config set provider'",
+	"%v, starting with no seen alerts":                                                                                  "%v, beginne ohne bereits gesehene Warnungen",
+	"Watching %d location(s) for weather alerts and %d rule(s) every %s, press Ctrl+C to stop":                          "Überwache %d Ort(e) auf Wetterwarnungen und %d Regel(n) alle %s, Strg+C zum Beenden",
+	"Rate limited, checking again in %s":                                                                                "Anfragelimit erreicht, nächste Prüfung in %s",
+	"%s: %s from %s":                                                                                                    "%s: %s von %s",
+	"nothing to watch - add a location with 'gust locations add <alias> <city>' or set a default city":                  "nichts zu überwachen - füge einen Ort mit 'gust locations add <alias> <stadt>' hinzu oder lege eine Standardstadt fest",
+	"failed to load weather for %d of %d cities":                                                                        "Wetter für %d von %d Städten konnte nicht geladen werden",
+	"failed to get weather data: %w":                                                                                    "Wetterdaten konnten nicht abgerufen werden: %w",
+	"no saved locations - add one with 'gust locations add <alias> <city>'":                                             "keine gespeicherten Orte - füge einen mit 'gust locations add <alias> <stadt>' hinzu",
+	"no cached weather for %s - run without --offline to fetch it":                                                      "kein zwischengespeichertes Wetter für %s - ohne --offline ausführen, um es abzurufen",
+	"⚠️ API Rate Limit Warning":                                                                                         "⚠️ Warnung zum API-Anfragelimit",
+	"You have %s requests remaining out of %d.":                                                                         "Du hast noch %s von %d Anfragen übrig.",
+	"Your rate limit will reset at %s (%d minutes from now).":                                                           "Dein Anfragelimit wird um %s zurückgesetzt (in %d Minuten).",
+	"❌ API Rate Limit Reached":                                                                                          "❌ API-Anfragelimit erreicht",
+	"Sorry - you have used all %d available requests.":                                                                  "Sorry - du hast alle %d verfügbaren Anfragen verbraucht.",
+	"You must really like checking the weather!!":                                                                       "Du schaust wohl wirklich gern aufs Wetter!!",
+	"💡 If you think the limits are too low please get in touch :)":                                                      "💡 Wenn dir die Limits zu niedrig erscheinen, melde dich gern :)",
+
+	// api error hints
+	"Check your api key with 'gust auth status', or log in again with 'gust auth login'.":                                       "Prüfe deinen API-Schlüssel mit 'gust auth status' oder melde dich mit 'gust auth login' neu an.",
+	"Check the spelling or add a country code, e.g. London,GB.":                                                                 "Prüfe die Schreibweise oder gib einen Ländercode an, z. B. London,GB.",
+	"Unlock your system keyring and try again. Only log in again if the key is gone from it.":                                   "Entsperre deinen Schlüsselbund und versuche es erneut. Melde dich nur neu an, wenn der Schlüssel daraus verschwunden ist.",
+	"The weather service can't be reached right now, try again later or use --offline for cached weather.":                      "Der Wetterdienst ist gerade nicht erreichbar, versuche es später erneut oder nutze --offline für gespeichertes Wetter.",
+	"The weather service took too long to answer, try again later or allow it longer with 'gust config set timeout <seconds>'.": "Der Wetterdienst hat zu lange gebraucht, versuche es später erneut oder gib ihm mit 'gust config set timeout <sekunden>' mehr Zeit.",
+	"The weather service sent a response gust couldn't read, try again later.":                                                  "Der Wetterdienst hat eine Antwort geschickt, die gust nicht lesen konnte, versuche es später erneut.",
+	"Rate limit reached, please try again later.":                                                                               "Anfragelimit erreicht, bitte versuche es später erneut.",
+	"Please try again in about %d hour(s) and %d minute(s) when your rate limit resets.":                                        "Bitte versuche es in etwa %d Stunde(n) und %d Minute(n) erneut, wenn dein Anfragelimit zurückgesetzt ist.",
+	"Please try again in about %d minute(s) when your rate limit resets.":                                                       "Bitte versuche es in etwa %d Minute(n) erneut, wenn dein Anfragelimit zurückgesetzt ist.",
+}
@@ -0,0 +1,317 @@
+package i18n
+
+var spanish = map[string]string{
+	// date layouts
+	"Mon Jan 2":         "Mon 2 Jan",
+	"Mon Jan 2 15:04":   "Mon 2 Jan 15:04",
+	"Mon 02 Jan":        "Mon 02 Jan",
+	"Monday 02 January": "Monday, 02 de January",
+
+	// forecasts
+	"WEATHER FOR %s":                                 "TIEMPO EN %s",
+	"%s WEATHER":                                     "TIEMPO EN %s",
+	"5-DAY FORECAST FOR %s":                          "PRONÓSTICO DE 5 DÍAS PARA %s",
+	"24H FORECAST FOR %s":                            "PRONÓSTICO DE 24 H PARA %s",
+	"48H FORECAST FOR %s":                            "PRONÓSTICO DE 48 H PARA %s",
+	"NEXT HOUR IN %s":                                "PRÓXIMA HORA EN %s",
+	"WEATHER ALERTS FOR %s":                          "AVISOS METEOROLÓGICOS PARA %s",
+	"SAVED LOCATIONS":                                "UBICACIONES GUARDADAS",
+	"Current Conditions: %s %s":                      "Condiciones actuales: %s %s",
+	"Temperature: %s %s (F/L: %s)":                   "Temperatura: %s %s (sensación: %s)",
+	"Humidity: %d%% %s":                              "Humedad: %d%% %s",
+	"UV Index: %.1f ☀️":                              "Índice UV: %.1f ☀️",
+	"UV Index: %.1f":                                 "Índice UV: %.1f",
+	"Cloud coverage: %d%% ☁️":                        "Nubosidad: %d%% ☁️",
+	"Visibility: %s":                                 "Visibilidad: %s",
+	"Sunrise: %s %s  Sunset: %s %s  (%s)":            "Amanecer: %s %s  Atardecer: %s %s  (%s)",
+	"Wind: %s %s %s (Gusts: %s)":                     "Viento: %s %s %s (ráfagas: %s)",
+	"Wind: %s %s %s":                                 "Viento: %s %s %s",
+	"Wind: %s %s":                                    "Viento: %s %s",
+	"Rain: %s (last hour) 🌧️":                        "Lluvia: %s (última hora) 🌧️",
+	"Snow: %s (last hour) ❄️":                        "Nieve: %s (última hora) ❄️",
+	"⚠️  There are %d weather alerts for this area.": "⚠️  Hay %d avisos meteorológicos para esta zona.",
+	"Use 'gust alerts %s' to view them.":             "Usa 'gust alerts %s' para verlos.",
+	"⚠️ %d alerts":                                   "⚠️ %d avisos",
+	"High/Low: %s/%s %s":                             "Máx/Mín: %s/%s %s",
+	"Morning: %s  Day: %s  Evening: %s  Night: %s":   "Mañana: %s  Día: %s  Tarde: %s  Noche: %s",
+	"Conditions: %s":                                 "Condiciones: %s",
+	"Precipitation: %d%% chance":                     "Precipitación: %d%% de probabilidad",
+	"Rain: %s 🌧️":                                    "Lluvia: %s 🌧️",
+	"Snow: %s ❄️":                                    "Nieve: %s ❄️",
+	"%.0f%% chance of precipitation":                 "%.0f%% de probabilidad de precipitación",
+	"Rain: %s/h":                                     "Lluvia: %s/h",
+	"Snow: %s/h":                                     "Nieve: %s/h",
+	"No weather alerts for this area.":               "No hay avisos meteorológicos para esta zona.",
+	"Issued by: %s":                                  "Emitido por: %s",
+	"Valid: %s to %s":                                "Válido: del %s al %s",
+	"No minute by minute forecast available for this location": "No hay pronóstico minuto a minuto para esta ubicación",
+	"No rain expected in the next hour":                        "No se espera lluvia en la próxima hora",
+	"Rain now":                                                 "Lloviendo ahora",
+	"Rain starting in %d min":                                  "Lluvia en %d min",
+	", stopping around %s":                                     ", hasta aproximadamente las %s",
+	", lasting at least the next hour":                         ", durante al menos la próxima hora",
+	"now":                                                      "ahora",
+	"Not enough hourly data to draw a graph.":                  "No hay suficientes datos por hora para dibujar un gráfico.",
+	"Temperature":                                              "Temperatura",
+	"Chance of precipitation":                                  "Probabilidad de precipitación",
+	"No precipitation expected in the next 48 hours ☀️":        "No se espera precipitación en las próximas 48 horas ☀️",
+	"Excellent":                                                "Excelente",
+	"Good":                                                     "Buena",
+	"Moderate":                                                 "Moderada",
+	"Poor":                                                     "Mala",
+
+	// conditions open-meteo reports
+	"clear sky":              "cielo despejado",
+	"mainly clear":           "mayormente despejado",
+	"partly cloudy":          "parcialmente nublado",
+	"overcast":               "cubierto",
+	"fog":                    "niebla",
+	"light drizzle":          "llovizna ligera",
+	"drizzle":                "llovizna",
+	"heavy drizzle":          "llovizna intensa",
+	"freezing drizzle":       "llovizna helada",
+	"light rain":             "lluvia ligera",
+	"moderate rain":          "lluvia moderada",
+	"heavy rain":             "lluvia intensa",
+	"freezing rain":          "lluvia helada",
+	"light snow":             "nevada ligera",
+	"snow":                   "nieve",
+	"heavy snow":             "nevada intensa",
+	"snow grains":            "cinarra",
+	"light shower rain":      "chubascos ligeros",
+	"shower rain":            "chubascos",
+	"heavy shower rain":      "chubascos fuertes",
+	"light shower snow":      "chubascos de nieve ligeros",
+	"shower snow":            "chubascos de nieve",
+	"thunderstorm":           "tormenta",
+	"thunderstorm with hail": "tormenta con granizo",
+	"unknown":                "desconocido",
+
+	// tips
+	"It might be snowing right now! Stay warm and take care on slippery surfaces! ⛄":        "¡Puede que esté nevando ahora mismo! ¡Abrígate y ten cuidado con las superficies resbaladizas! ⛄",
+	"It might be raining right now - don't go out without an umbrella! ☔":                   "Puede que esté lloviendo ahora mismo: ¡no salgas sin paraguas! ☔",
+	"Snow expected around {{.Time}} - dress warmly and wear appropriate footwear! ❄️":       "Se espera nieve hacia las {{.Time}}: ¡abrígate y usa calzado adecuado! ❄️",
+	"Rain expected around {{.Time}} - don't forget your umbrella! ☔":                        "Se espera lluvia hacia las {{.Time}}: ¡no olvides el paraguas! ☔",
+	"Rain likely around {{.Time}} ({{.Value}} chance) - an umbrella might come in handy! ☔": "Probable lluvia hacia las {{.Time}} ({{.Value}} de probabilidad): ¡un paraguas podría venir bien! ☔",
+	"It's quite cold - wear a heavy coat and maybe a scarf! 🧣":                              "Hace bastante frío: ¡ponte un abrigo grueso y quizá una bufanda! 🧣",
+	"It's cool today - a jacket would be a good idea. 🧥":                                    "Hoy hace fresco: una chaqueta sería buena idea. 🧥",
+	"It's hot today - stay hydrated and wear sunscreen! 🧴":                                  "Hoy hace calor: ¡bebe agua y usa protector solar! 🧴",
+	"UV index is high ({{.Value}}) - wear sunscreen and maybe a hat! 🧢":                     "El índice UV es alto ({{.Value}}): ¡usa protector solar y quizá un sombrero! 🧢",
+	"It's quite windy today ({{.Value}}) - secure any loose items outdoors! 💨":              "Hoy hace bastante viento ({{.Value}}): ¡asegura los objetos sueltos del exterior! 💨",
+	"Conditions look fine, enjoy your day! 🌤️":                                              "Las condiciones parecen buenas, ¡disfruta del día! 🌤️",
+
+	// dashboard
+	"Current":                          "Actual",
+	"Hourly":                           "Por horas",
+	"Daily":                            "Por días",
+	"Alerts":                           "Avisos",
+	"Couldn't load weather for %s: %v": "No se pudo cargar el tiempo de %s: %v",
+	"Fetching weather for %s...":       "Obteniendo el tiempo de %s...",
+	"Fetching weather for %s... (attempt %d of %d)": "Obteniendo el tiempo de %s... (intento %d de %d)",
+	"refreshing":           "actualizando",
+	"updated %s · next %s": "actualizado %s · siguiente %s",
+	"←/→ tabs • ↑/↓ select • r refresh • q quit": "←/→ pestañas • ↑/↓ elegir • r actualizar • q salir",
+	"⚠️ refresh failed: %v":                      "⚠️ falló la actualización: %v",
+	"Feels like":                                 "Sensación",
+	"feels":                                      "sensación",
+	"Humidity":                                   "Humedad",
+	"Wind":                                       "Viento",
+	"Gusts":                                      "Ráfagas",
+	"UV index":                                   "Índice UV",
+	"Pressure":                                   "Presión",
+	"Visibility":                                 "Visibilidad",
+	"Clouds":                                     "Nubosidad",
+	"Sunrise":                                    "Amanecer",
+	"Sunset":                                     "Atardecer",
+	"Morning":                                    "Mañana",
+	"Day":                                        "Día",
+	"Evening":                                    "Tarde",
+	"Night":                                      "Noche",
+	"Rain chance":                                "Prob. de lluvia",
+	"Rain":                                       "Lluvia",
+	"Snow":                                       "Nieve",
+	"No hourly forecast available":               "No hay pronóstico por horas",
+	"No daily forecast available":                "No hay pronóstico por días",
+	"No weather alerts for this area 🎉":          "No hay avisos meteorológicos para esta zona 🎉",
+	"%s · %s until %s %s":                        "%s · %s hasta %s %s",
+	"%s until %s (%s)":                           "%s hasta %s (%s)",
+	"From %s (%s)":                               "Desde %s (%s)",
+	"Issued by %s":                               "Emitido por %s",
+	"%s at %s":                                   "%s el %s",
+	"%s on %s":                                   "%s el %s",
+	"Offline: showing cached weather from %s (%s)": "Sin conexión: mostrando el tiempo guardado del %s (%s)",
+	"%d minute(s) ago": "hace %d minuto(s)",
+	"%d hour(s) ago":   "hace %d hora(s)",
+	"%d day(s) ago":    "hace %d día(s)",
+
+	// setup wizard
+	"Simple terminal weather 🌤️":                                          "El tiempo en tu terminal 🌤️",
+	"Enter a default city 🏙️":                                             "Escribe una ciudad predeterminada 🏙️",
+	"You can enter a country code too, but use a comma! (e.g. London,GB)": "También puedes añadir un código de país, ¡pero con una coma! (p. ej. Madrid,ES)",
+	"Searching for cities...":                                             "Buscando ciudades...",
+	"Looking for \"%s\"":                                                  "Buscando \"%s\"",
+	"Select your town or city: 🏙️":                                        "Elige tu ciudad o pueblo: 🏙️",
+	"No cities found. Please try a different search term.":                "No se encontraron ciudades. Prueba con otro término de búsqueda.",
+	"No cities found. Please try a different search.":                     "No se encontraron ciudades. Prueba con otra búsqueda.",
+	"Press Enter to select or Esc to search again":                        "Pulsa Enter para elegir o Esc para buscar de nuevo",
+	"Choose your preferred units: 🌡️":                                     "Elige tus unidades: 🌡️",
+	"Press Enter to confirm":                                              "Pulsa Enter para confirmar",
+	"Choose your preferred view: 📊":                                       "Elige tu vista: 📊",
+	"GitHub Auth 🔒":                                                       "Autenticación con GitHub 🔒",
+	"To get weather data you need to authenticate with GitHub (don't worry, no permissions requested!).": "Para obtener datos del tiempo tienes que autenticarte con GitHub (tranquilo, ¡no se piden permisos!).",
+	"Press Enter to confirm your selection":           "Pulsa Enter para confirmar tu elección",
+	"✓ Setup complete! 🎉":                             "✓ ¡Configuración completada! 🎉",
+	"Default city: %s 🏙️":                             "Ciudad predeterminada: %s 🏙️",
+	"Units: %s 🌡️":                                    "Unidades: %s 🌡️",
+	"Default view: %s 📊":                              "Vista predeterminada: %s 📊",
+	"Provider: %s 🛰️":                                 "Proveedor: %s 🛰️",
+	"Tips enabled 💡":                                  "Consejos activados 💡",
+	"Tips disabled 💡":                                 "Consejos desactivados 💡",
+	"Would you like tips shown on daily forecasts? 💡": "¿Quieres ver consejos con el pronóstico? 💡",
+	"Authenticated ✅":                                 "Autenticado ✅",
+	"Not authenticated ❌":                             "No autenticado ❌",
+	"GitHub: %s":                                      "GitHub: %s",
+	"Choose auth method: 🔑":                           "Elige el método de autenticación: 🔑",
+	"Enter your OpenWeatherMap API key: 🔑":            "Escribe tu clave de API de OpenWeatherMap: 🔑",
+	"Get your API key from https://home.openweathermap.org/subscriptions/unauth_subscribe/onecall_30/base": "Consigue tu clave de API en https://home.openweathermap.org/subscriptions/unauth_subscribe/onecall_30/base",
+	"↓j/↑k Navigate • Enter: Select • Ctrl + C: Quit":                                                      "↓j/↑k Navegar • Enter: Elegir • Ctrl + C: Salir",
+	"Wherever the wind blows...":              "Donde sople el viento...",
+	"Paste your OpenWeather API key here...":  "Pega aquí tu clave de API de OpenWeather...",
+	"metric (°C, km/h) 🌡️":                    "métrico (°C, km/h) 🌡️",
+	"imperial (°F, mph) 🌡️":                   "imperial (°F, mph) 🌡️",
+	"standard (K, m/s) 🌡️":                    "estándar (K, m/s) 🌡️",
+	"detailed 🌤️":                             "detallada 🌤️",
+	"compact 📊":                               "compacta 📊",
+	"5-day 📆":                                 "5 días 📆",
+	"24-hour 🕒":                               "24 horas 🕒",
+	"full (current + 5-day + alerts) 📋":       "completa (actual + 5 días + avisos) 📋",
+	"Yes, show weather tips":                  "Sí, mostrar consejos",
+	"No, don't show tips":                     "No, sin consejos",
+	"Yes, authenticate with GitHub 🔑":         "Sí, autenticarme con GitHub 🔑",
+	"No, I'll do it later ⏱️":                 "No, lo haré más tarde ⏱️",
+	"Use gust's authentication (recommended)": "Usar la autenticación de gust (recomendado)",
+	"Use my own OpenWeatherMap API key":       "Usar mi propia clave de API de OpenWeatherMap",
+	"Use Open-Meteo (free, no key needed)":    "Usar Open-Meteo (gratis, sin clave)",
+	"Error: %v":                               "Error: %v",
+	"Error searching cities: %v":              "Error al buscar ciudades: %v",
+
+	// commands
+	"Starting GitHub authentication...":                                      "Iniciando la autenticación con GitHub...",
+	"Successfully authenticated as %s\n":                                     "Autenticado correctamente como %s\n",
+	"Logged out, stored credentials removed.":                                "Sesión cerrada, credenciales guardadas eliminadas.",
+	"%v, remove the \"gust\" entry from your keyring yourself":               "%v, elimina tú mismo la entrada \"gust\" de tu llavero",
+	"Not authenticated. Run 'gust auth login' or 'gust auth key <api-key>'.": "No autenticado. Ejecuta 'gust auth login' o 'gust auth key <api-key>'.",
+	"No api key needed for this provider.":                                   "Este proveedor no necesita clave de API.",
+	"Provider: %s":                                                           "Proveedor: %s",
+	"User: %s":                                                               "Usuario: %s",
+	"Api key: %s":                                                            "Clave de API: %s",
+	"%s was readable by other users, so your api key may have been copied. Consider replacing it with 'gust auth login' or 'gust auth key <api-key>'.": "%s podía ser leído por otros usuarios, así que tu clave de API podría haberse copiado. Considera reemplazarla con 'gust auth login' o 'gust auth key <api-key>'.",
+	"Couldn't secure %s: %v":                                  "No se pudo proteger %s: %v",
+	"Stored in: system keyring":                               "Guardada en: llavero del sistema",
+	"Stored in: %s":                                           "Guardada en: %s",
+	"Last authenticated: %s":                                  "Última autenticación: %s",
+	"API key updated.":                                        "Clave de API actualizada.",
+	"%s is deprecated, use '%s' instead":                      "%s está obsoleto, usa '%s' en su lugar",
+	"You need to authenticate with GitHub before using Gust.": "Tienes que autenticarte con GitHub antes de usar gust.",
+	"Run 'gust auth login' to authenticate or 'gust setup' to run the setup wizard.": "Ejecuta 'gust auth login' para autenticarte o 'gust setup' para abrir el asistente de configuración.",
+	"authentication required":                                                                "se requiere autenticación",
+	"--lat and --lon must be used together":                                                  "--lat y --lon deben usarse juntos",
+	"no saved location called %q - add it with 'gust locations add %s <city>'":               "no hay ninguna ubicación guardada llamada %q: añádela con 'gust locations add %s <ciudad>'",
+	"No city specified and no default city set.":                                             "No se indicó ninguna ciudad y no hay ciudad predeterminada.",
+	"Specify a city: gust [city name]":                                                       "Indica una ciudad: gust [nombre de la ciudad]",
+	"Or set a default city: gust config set city London":                                     "O fija una ciudad predeterminada: gust config set city Madrid",
+	"Or run the setup wizard: gust setup":                                                    "O abre el asistente de configuración: gust setup",
+	"no city provided":                                                                       "no se indicó ninguna ciudad",
+	"--pick %d is out of range, %q has %d matches":                                           "--pick %d está fuera de rango, %q tiene %d resultados",
+	"Which %s did you mean? 🏙️":                                                              "¿Qué %s quieres decir? 🏙️",
+	"%q matches %d places, re-run with --pick N to choose one:":                              "%q coincide con %d lugares, vuelve a ejecutarlo con --pick N para elegir uno:",
+	"no city selected":                                                                       "no se eligió ninguna ciudad",
+	"invalid units %q, must be one of: metric, imperial, standard":                           "unidades %q no válidas, deben ser una de: metric, imperial, standard",
+	"invalid view %q, must be one of: default, compact, daily, hourly, graph, nowcast, full": "vista %q no válida, debe ser una de: default, compact, daily, hourly, graph, nowcast, full",
+	"invalid max tips %q, must be a whole number":                                            "número de consejos %q no válido, debe ser un número entero",
+	"invalid provider %q, must be one of: breeze, openweathermap, open-meteo":                "proveedor %q no válido, debe ser uno de: breeze, openweathermap, open-meteo",
+	"invalid language %q, must be one of: %s":                                                "idioma %q no válido, debe ser uno de: %s",
+	"invalid cache ttl %q, must be a whole number of minutes":                                "duración de caché %q no válida, debe ser un número entero de minutos",
+	"invalid timeout %q, must be a whole number of seconds":                                  "tiempo de espera %q no válido, debe ser un número entero de segundos",
+	"unknown config key %q, must be one of: %s":                                              "ajuste %q desconocido, debe ser uno de: %s",
+	"Default city": "Ciudad predeterminada",
+	"Default coordinates as geo:<lat>,<lon>, empty to use the city name": "Coordenadas predeterminadas como geo:<lat>,<lon>, vacío para usar el nombre de la ciudad",
+	"Units (metric, imperial, standard)":                                 "Unidades (metric, imperial, standard)",
+	"%s (%s, or default to follow units)":                                "%s (%s, o default para seguir las unidades)",
+	"Temperature unit":                                                   "Unidad de temperatura",
+	"Wind speed unit":                                                    "Unidad de velocidad del viento",
+	"Pressure unit":                                                      "Unidad de presión",
+	"Rain and snow unit":                                                 "Unidad de lluvia y nieve",
+	"Visibility unit":                                                    "Unidad de visibilidad",
+	"Default view (default, compact, daily, hourly, graph, nowcast, full)":          "Vista predeterminada (default, compact, daily, hourly, graph, nowcast, full)",
+	"Show weather tips (true, false)":                                               "Mostrar consejos del tiempo (true, false)",
+	"Most tips shown under the detailed view (0 for the default)":                   "Máximo de consejos bajo la vista detallada (0 para el valor predeterminado)",
+	"Show times in this machine's timezone instead of the location's (true, false)": "Mostrar las horas en la zona horaria de este equipo en lugar de la del lugar (true, false)",
+	"Weather provider (breeze, openweathermap, open-meteo)":                         "Proveedor del tiempo (breeze, openweathermap, open-meteo)",
+	"Language for everything gust prints (%s), empty to follow LANG":                "Idioma de todo lo que muestra gust (%s), vacío para seguir LANG",
+	"Color theme (%s) or the name of a file in ~/.config/gust/themes":               "Tema de colores (%s) o el nombre de un archivo en ~/.config/gust/themes",
+	"Custom API server URL (mostly for development)":                                "URL de un servidor de API propio (sobre todo para desarrollo)",
+	"Minutes to cache weather for (0 for the default, negative to disable)":         "Minutos que se guarda el tiempo en caché (0 para el valor predeterminado, negativo para desactivar)",
+	"Seconds a weather request may take before giving up (0 for the default)":       "Segundos que puede tardar una consulta del tiempo antes de rendirse (0 para el valor predeterminado)",
+	"Command gust watch runs for new alerts, empty for desktop notifications":       "Comando que gust watch ejecuta con nuevos avisos, vacío para notificaciones de escritorio",
+	"%s set to %s":                                                               "%s fijado a %s",
+	"failed to save config: %w":                                                  "no se pudo guardar la configuración: %w",
+	"invalid value %q, must be true or false":                                    "valor %q no válido, debe ser true o false",
+	"--pretty can't be combined with --output json":                              "--pretty no se puede combinar con --output json",
+	"--pretty can't be combined with --plain":                                    "--pretty no se puede combinar con --plain",
+	"--pretty shows a single city and can't be combined with --all":              "--pretty muestra una sola ciudad y no se puede combinar con --all",
+	"--pretty needs an interactive terminal":                                     "--pretty necesita una terminal interactiva",
+	"--pretty shows a single city, drop the extra cities or --pretty":            "--pretty muestra una sola ciudad, quita las demás ciudades o --pretty",
+	"both an alias and a city are required, e.g. gust locations add home London": "hacen falta un alias y una ciudad, p. ej. gust locations add casa Madrid",
+	"invalid alias %q, aliases can't contain spaces or '+'":                      "alias %q no válido, los alias no pueden contener espacios ni '+'",
+	"Saved @%s as %s":             "@%s guardado como %s",
+	"no saved location called %q": "no hay ninguna ubicación guardada llamada %q",
+	"Removed @%s":                 "@%s eliminado",
+	"No saved locations yet, add one with: gust locations add home London": "Aún no hay ubicaciones guardadas, añade una con: gust locations add casa Madrid",
+	"Added rule %d: %s":                                           "Regla %d añadida: %s",
+	"no rule number %d, see 'gust rules list'":                    "no existe la regla número %d, consulta 'gust rules list'",
+	"Removed rule: %s":                                            "Regla eliminada: %s",
+	"No rules yet, add one with: ":                                "Aún no hay reglas, añade una con: ",
+	"no rules to check - add one with '%s'":                       "no hay reglas que comprobar: añade una con '%s'",
+	"No rules matched for %s":                                     "Ninguna regla se cumple para %s",
+	"invalid rule %q: the forecast covers the next 1 to 48 hours": "regla %q no válida: el pronóstico cubre de 1 a 48 horas",
+	"invalid rule %q: %w":                                         "regla %q no válida: %w",
+	"invalid rule %q: the times must differ":                      "regla %q no válida: las horas deben ser distintas",
+	"invalid rule %q, expected something like \"temp below 0 in the next 12h\"":                                         "regla %q no válida, se esperaba algo como \"temp below 0 in the next 12h\"",
+	"invalid rule %q: unknown quantity %q, must be one of: temp, feels like, wind, gust, pop, rain, snow, humidity, uv": "regla %q no válida: magnitud %q desconocida, debe ser una de: temp, feels like, wind, gust, pop, rain, snow, humidity, uv",
+	"invalid rule %q: pop is a forecast, use a time in the future":                                                      "regla %q no válida: pop es un pronóstico, usa un momento futuro",
+	"invalid time %q, use 24 hour HH:MM":                                                                                "hora %q no válida, usa el formato de 24 horas HH:MM",
+	"%s is a percentage":                                                                                                "%s es un porcentaje",
+	"%s has no unit":                                                                                                    "%s no tiene unidad",
+	"Defaults not set, running setup...":                                                                                "No hay valores predeterminados, iniciando la configuración...",
+	"Running setup wizard...":                                                                                           "Iniciando el asistente de configuración...",
+	"Setup complete! Run 'gust' to check the weather for your default city.":                                            "¡Configuración completada! Ejecuta 'gust' para ver el tiempo de tu ciudad predeterminada.",
+	"the %s provider doesn't report weather alerts, pick another with 'gust config set provider'":                       "el proveedor %s no informa de avisos meteorológicos, elige otro con 'gust config set provider'",
+	"%v, starting with no seen alerts":                                                                                  "%v, empezando sin avisos vistos",
+	"Watching %d location(s) for weather alerts and %d rule(s) every %s, press Ctrl+C to stop":                          "Vigilando avisos meteorológicos en %d ubicación(es) y %d regla(s) cada %s, pulsa Ctrl+C para parar",
+	"Rate limited, checking again in %s":                                                                                "Límite de peticiones alcanzado, se volverá a comprobar en %s",
+	"%s: %s from %s":                                                                                                    "%s: %s de %s",
+	"nothing to watch - add a location with 'gust locations add <alias> <city>' or set a default city":                  "nada que vigilar: añade una ubicación con 'gust locations add <alias> <ciudad>' o fija una ciudad predeterminada",
+	"failed to load weather for %d of %d cities":                                                                        "no se pudo cargar el tiempo de %d de %d ciudades",
+	"failed to get weather data: %w":                                                                                    "no se pudieron obtener los datos del tiempo: %w",
+	"no saved locations - add one with 'gust locations add <alias> <city>'":                                             "no hay ubicaciones guardadas: añade una con 'gust locations add <alias> <ciudad>'",
+	"no cached weather for %s - run without --offline to fetch it":                                                      "no hay tiempo guardado para %s: ejecuta sin --offline para obtenerlo",
+	"⚠️ API Rate Limit Warning":                                                                                         "⚠️ Aviso de límite de peticiones de la API",
+	"You have %s requests remaining out of %d.":                                                                         "Te quedan %s de %d peticiones.",
+	"Your rate limit will reset at %s (%d minutes from now).":                                                           "Tu límite se restablecerá a las %s (dentro de %d minutos).",
+	"❌ API Rate Limit Reached":                                                                                          "❌ Límite de peticiones de la API alcanzado",
+	"Sorry - you have used all %d available requests.":                                                                  "Lo siento, has usado las %d peticiones disponibles.",
+	"You must really like checking the weather!!":                                                                       "¡¡Sí que te gusta mirar el tiempo!!",
+	"💡 If you think the limits are too low please get in touch :)":                                                      "💡 Si crees que los límites son demasiado bajos, escríbenos :)",
+
+	// api error hints
+	"Check your api key with 'gust auth status', or log in again with 'gust auth login'.":                                       "Comprueba tu clave de API con 'gust auth status' o vuelve a iniciar sesión con 'gust auth login'.",
+	"Check the spelling or add a country code, e.g. London,GB.":                                                                 "Comprueba cómo lo has escrito o añade un código de país, p. ej. London,GB.",
+	"Unlock your system keyring and try again. Only log in again if the key is gone from it.":                                   "Desbloquea tu llavero del sistema y vuelve a intentarlo. Inicia sesión de nuevo solo si la clave ya no está en él.",
+	"The weather service can't be reached right now, try again later or use --offline for cached weather.":                      "No se puede contactar con el servicio del tiempo ahora mismo, inténtalo más tarde o usa --offline para ver el tiempo guardado.",
+	"The weather service took too long to answer, try again later or allow it longer with 'gust config set timeout <seconds>'.": "El servicio del tiempo ha tardado demasiado en responder, inténtalo más tarde o dale más tiempo con 'gust config set timeout <segundos>'.",
+	"The weather service sent a response gust couldn't read, try again later.":                                                  "El servicio del tiempo envió una respuesta que gust no pudo leer, inténtalo más tarde.",
+	"Rate limit reached, please try again later.":                                                                               "Límite de peticiones alcanzado, inténtalo más tarde.",
+	"Please try again in about %d hour(s) and %d minute(s) when your rate limit resets.":                                        "Inténtalo de nuevo en unas %d hora(s) y %d minuto(s), cuando se restablezca tu límite de peticiones.",
+	"Please try again in about %d minute(s) when your rate limit resets.":                                                       "Inténtalo de nuevo en unos %d minuto(s), cuando se restablezca tu límite de peticiones.",
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// day and month names, sunday first like time.Weekday
+type dateNames struct {
+	weekdays      [7]string
+	shortWeekdays [7]string
+	months        [12]string
+	shortMonths   [12]string
+}
+
+var names = map[string]dateNames{
+	"de": {
+		weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	},
+	"es": {
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	},
+}
+
+// the layout elements spelled out in English, longest first so "Monday" isn't read as "Mon"
+var nameElements = []string{"January", "Monday", "Jan", "Mon"}
+
+// t.Format in the current language. The layout is translated like any message first,
+// so a catalog can reorder it, e.g. "Mon Jan 2" becomes "Mon 2. Jan" in German
+func FormatTime(t time.Time, layout string) string {
+	layout = T(layout)
+	localized, ok := names[current]
+	if !ok {
+		return t.Format(layout)
+	}
+
+	var sb strings.Builder
+	for layout != "" {
+		i, element := nextNameElement(layout)
+		if i < 0 {
+			sb.WriteString(t.Format(layout))
+			break
+		}
+		sb.WriteString(t.Format(layout[:i]))
+		sb.WriteString(localized.name(t, element))
+		layout = layout[i+len(element):]
+	}
+	return sb.String()
+}
+
+func nextNameElement(layout string) (int, string) {
+	first, found := -1, ""
+	for _, element := range nameElements {
+		if i := strings.Index(layout, element); i >= 0 && (first < 0 || i < first) {
+			first, found = i, element
+		}
+	}
+	return first, found
+}
+
+func (n dateNames) name(t time.Time, element string) string {
+	switch element {
+	case "January":
+		return n.months[t.Month()-1]
+	case "Jan":
+		return n.shortMonths[t.Month()-1]
+	case "Monday":
+		return n.weekdays[t.Weekday()]
+	default:
+		return n.shortWeekdays[t.Weekday()]
+	}
+}
@@ -0,0 +1,98 @@
+// Package i18n translates what gust prints. Messages are looked up by their English text, gettext
+// style, so untranslated strings and the English catalog are simply the message itself.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const DefaultLanguage = "en"
+
+// message catalogs by language code, English needs none
+var catalogs = map[string]map[string]string{
+	"de": german,
+	"es": spanish,
+}
+
+// set once at startup from the config and locale
+var current = DefaultLanguage
+
+// every language there is a catalog for, English first
+func Supported() []string {
+	languages := []string{DefaultLanguage}
+	for lang := range catalogs {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages[1:])
+	return languages
+}
+
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok || lang == DefaultLanguage
+}
+
+// unsupported languages fall back to English
+func SetLanguage(lang string) {
+	if !IsSupported(lang) {
+		lang = DefaultLanguage
+	}
+	current = lang
+}
+
+func Language() string {
+	return current
+}
+
+// the configured language wins, then the usual locale variables in the order libc checks them.
+// The first one set decides, so LC_ALL=C still means English even with a German LANG
+func Detect(configured string) string {
+	for _, value := range []string{configured, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if value == "" {
+			continue
+		}
+		if lang := normalize(value); IsSupported(lang) {
+			return lang
+		}
+		return DefaultLanguage
+	}
+	return DefaultLanguage
+}
+
+// "de_DE.UTF-8" and "es-MX" to "de" and "es"
+func normalize(locale string) string {
+	if i := strings.IndexAny(locale, "_-.@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ToLower(locale)
+}
+
+// message in the current language, formatted with args when there are any
+func T(message string, args ...any) string {
+	return In(current, message, args...)
+}
+
+// message in lang, for text that doesn't follow the current language (e.g. provider descriptions)
+func In(lang, message string, args ...any) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// every translation of message by language, for callers that pick the language themselves
+func Translations(message string) map[string]string {
+	translations := map[string]string{}
+	for lang, catalog := range catalogs {
+		if translated, ok := catalog[message]; ok {
+			translations[lang] = translated
+		}
+	}
+	return translations
+}
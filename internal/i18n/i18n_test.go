@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func useLanguage(t *testing.T, lang string) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+	SetLanguage(lang)
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configured string
+		env        map[string]string
+		expected   string
+	}{
+		{"nothing set", "", nil, "en"},
+		{"lang", "", map[string]string{"LANG": "de_DE.UTF-8"}, "de"},
+		{"lc_messages beats lang", "", map[string]string{"LC_MESSAGES": "es_ES.UTF-8", "LANG": "de_DE.UTF-8"}, "es"},
+		{"lc_all beats everything", "", map[string]string{"LC_ALL": "C.UTF-8", "LC_MESSAGES": "es_ES", "LANG": "de_DE"}, "en"},
+		{"config beats the locale", "es", map[string]string{"LC_ALL": "de_DE.UTF-8"}, "es"},
+		{"region codes are dropped", "", map[string]string{"LANG": "es-MX"}, "es"},
+		{"unsupported language", "", map[string]string{"LANG": "fr_FR.UTF-8"}, "en"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(key, tc.env[key])
+			}
+			assert.Equal(t, tc.expected, Detect(tc.configured))
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "WEATHER FOR OSLO", T("WEATHER FOR %s", "OSLO"))
+
+	useLanguage(t, "de")
+	assert.Equal(t, "WETTER FÜR OSLO", T("WEATHER FOR %s", "OSLO"))
+	assert.Equal(t, "not in any catalog", T("not in any catalog"), "untranslated messages fall back to english")
+	assert.Equal(t, "Humedad: 80% 💧", In("es", "Humidity: %d%% %s", 80, "💧"))
+}
+
+func TestSetLanguageUnsupported(t *testing.T) {
+	useLanguage(t, "fr")
+	assert.Equal(t, DefaultLanguage, Language())
+}
+
+func TestSupported(t *testing.T) {
+	assert.Equal(t, []string{"en", "de", "es"}, Supported())
+}
+
+func TestTranslations(t *testing.T) {
+	assert.Equal(t, map[string]string{"de": "Temperatur", "es": "Temperatura"}, Translations("Temperature"))
+	assert.Empty(t, Translations("not in any catalog"))
+}
+
+func TestFormatTime(t *testing.T) {
+	at := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		lang, layout, expected string
+	}{
+		{"en", "Mon Jan 2", "Tue Mar 5"},
+		{"de", "Mon Jan 2", "Di 5. Mär"},
+		{"es", "Mon Jan 2", "mar 5 mar"},
+		{"de", "Mon Jan 2 15:04", "Di 5. Mär 14:30"},
+		{"de", "Monday 02 January", "Dienstag, 05. März"},
+		{"es", "Monday 02 January", "martes, 05 de marzo"},
+		{"es", "Mon 15:04", "mar 14:30"},
+		{"de", "15:04", "14:30"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lang+" "+tc.layout, func(t *testing.T) {
+			useLanguage(t, tc.lang)
+			assert.Equal(t, tc.expected, FormatTime(at, tc.layout))
+		})
+	}
+}
+
+// a translation with different verbs would print %!s(MISSING) or worse
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+	fields := regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+	for lang, catalog := range catalogs {
+		for message, translated := range catalog {
+			assert.Equal(t, verbs.FindAllString(message, -1), verbs.FindAllString(translated, -1), "%s: %q", lang, message)
+			assert.ElementsMatch(t, fields.FindAllString(message, -1), fields.FindAllString(translated, -1), "%s: %q", lang, message)
+			assert.Equal(t, strings.HasSuffix(message, "\n"), strings.HasSuffix(translated, "\n"), "%s: %q", lang, message)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func NewCoordinates(lat, lon float64) (Coordinates, error) {
+	if lat < -90 || lat > 90 {
+		return Coordinates{}, fmt.Errorf("latitude %g out of range, must be between -90 and 90", lat)
+	}
+	if lon < -180 || lon > 180 {
+		return Coordinates{}, fmt.Errorf("longitude %g out of range, must be between -180 and 180", lon)
+	}
+	return Coordinates{Lat: lat, Lon: lon}, nil
+}
+
+func IsGeoURI(s string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), "geo:")
+}
+
+// RFC 5870 style "geo:51.5,-0.12", an altitude or ";u=35" style params are ignored
+func ParseGeoURI(s string) (Coordinates, error) {
+	s = strings.TrimSpace(s)
+	if !IsGeoURI(s) {
+		return Coordinates{}, fmt.Errorf("invalid geo URI %q, expected geo:<lat>,<lon>", s)
+	}
+
+	body, _, _ := strings.Cut(s[len("geo:"):], ";")
+	parts := strings.Split(body, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Coordinates{}, fmt.Errorf("invalid geo URI %q, expected geo:<lat>,<lon>", s)
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("invalid latitude in %q", s)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("invalid longitude in %q", s)
+	}
+
+	return NewCoordinates(lat, lon)
+}
+
+// 4 decimal places is ~10m, plenty for a forecast and keeps cache keys stable
+func (c Coordinates) GeoURI() string {
+	return fmt.Sprintf("geo:%.4f,%.4f", c.Lat, c.Lon)
+}
+
+func (c Coordinates) String() string {
+	return fmt.Sprintf("%.4f, %.4f", c.Lat, c.Lon)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGeoURI(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    Coordinates
+		expectError bool
+	}{
+		{input: "geo:51.5,-0.12", expected: Coordinates{Lat: 51.5, Lon: -0.12}},
+		{input: "GEO:40.7128, -74.0060", expected: Coordinates{Lat: 40.7128, Lon: -74.006}},
+		{input: "geo:51.5,-0.12,30", expected: Coordinates{Lat: 51.5, Lon: -0.12}},
+		{input: "geo:51.5,-0.12;u=35", expected: Coordinates{Lat: 51.5, Lon: -0.12}},
+		{input: "geo:51.5", expectError: true},
+		{input: "geo:north,south", expectError: true},
+		{input: "geo:91,0", expectError: true},
+		{input: "geo:0,181", expectError: true},
+		{input: "London", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			coords, err := ParseGeoURI(tc.input)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, coords)
+		})
+	}
+}
+
+func TestCoordinatesGeoURIRoundTrip(t *testing.T) {
+	coords := Coordinates{Lat: 51.50735, Lon: -0.12776}
+	assert.Equal(t, "geo:51.5074,-0.1278", coords.GeoURI())
+
+	parsed, err := ParseGeoURI(coords.GeoURI())
+	assert.NoError(t, err)
+	assert.InDelta(t, coords.Lat, parsed.Lat, 0.0001)
+	assert.InDelta(t, coords.Lon, parsed.Lon, 0.0001)
+}
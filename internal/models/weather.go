@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,6 +14,14 @@ type City struct {
 	State   string  `json:"state"`
 }
 
+// the name with its country, e.g. "Springfield,US", as precise as a lookup by name gets
+func (c City) Query() string {
+	if c.Country == "" || strings.Contains(c.Name, ",") {
+		return c.Name
+	}
+	return c.Name + "," + c.Country
+}
+
 type OneCallResponse struct {
 	Lat            float64        `json:"lat"`
 	Lon            float64        `json:"lon"`
@@ -25,6 +34,19 @@ type OneCallResponse struct {
 	Alerts         []Alert        `json:"alerts"`
 }
 
+// the forecast location's zone, falling back to the fixed offset when the zone database doesn't know the name
+func (w *OneCallResponse) Location() *time.Location {
+	if w.Timezone != "" {
+		if loc, err := time.LoadLocation(w.Timezone); err == nil {
+			return loc
+		}
+	}
+	if w.Timezone != "" || w.TimezoneOffset != 0 {
+		return time.FixedZone(w.Timezone, w.TimezoneOffset)
+	}
+	return time.Local
+}
+
 type WeatherCondition struct {
 	ID          int    `json:"id"`
 	Main        string `json:"main"`
@@ -168,84 +190,19 @@ func GetWindDirection(degrees int) string {
 func VisibilityToString(meters int) string {
 	if meters >= 10000 {
 		return "Excellent (10+ km)"
-	} else if meters >= 5000 {
-		return fmt.Sprintf("Good (%.1f km)", float64(meters)/1000)
-	} else if meters >= 2000 {
-		return fmt.Sprintf("Moderate (%.1f km)", float64(meters)/1000)
-	} else {
-		return fmt.Sprintf("Poor (%.1f km)", float64(meters)/1000)
 	}
+	return fmt.Sprintf("%s (%.1f km)", VisibilityRating(meters), float64(meters)/1000)
 }
 
-func GetWeatherTip(weather *OneCallResponse, units string) string {
-	current := weather.Current
-	hourly := weather.Hourly
-
-	if current.Snow != nil && current.Snow.OneHour > 0 {
-		return "It might be snowing right now! Stay warm and take care on slippery surfaces! ⛄"
-	}
-
-	if current.Rain != nil && current.Rain.OneHour > 0 {
-		return "It might be raining right now - don't go out without an umbrella! ☔"
-	}
-
-	for i, hour := range hourly {
-		if i > 0 && i < 12 { // next 12hrs
-			precipTime := time.Unix(hour.Dt, 0).Format("15:04")
-
-			if hour.Snow != nil && hour.Snow.OneHour > 0.1 {
-				return fmt.Sprintf("Snow expected around %s - dress warmly and wear appropriate footwear! ❄️", precipTime)
-			}
-
-			if (hour.Rain != nil && hour.Rain.OneHour > 0.5) || hour.Pop > 0.4 {
-				return fmt.Sprintf("Rain expected around %s - don't forget your umbrella! ☔", precipTime)
-			}
-		}
-	}
-
-	var coldThreshold, coolThreshold, warmThreshold float64
-
-	switch units {
-	case "imperial":
-		coldThreshold = 40 // 5C
-		coolThreshold = 55 // 12C
-		warmThreshold = 82 // 28C
-	case "standard":
-		coldThreshold = 278 // 5C
-		coolThreshold = 285 // 12C
-		warmThreshold = 301 // 28C
-	default:
-		coldThreshold = 5
-		coolThreshold = 12
-		warmThreshold = 28
-	}
-
-	if current.Temp < coldThreshold {
-		return "It's quite cold - wear a heavy coat and maybe a scarf! 🧣"
-	} else if current.Temp < coolThreshold {
-		return "It's cool today - a jacket would be a good idea. 🧥"
-	} else if current.Temp > warmThreshold {
-		return "It's hot today - stay hydrated and wear sunscreen! 🧴"
-	}
-
-	if current.UVI > 6 {
-		return "UV index is high - wear sunscreen and maybe a hat! 🧢"
-	}
-
-	var windThreshold float64
-
-	switch units {
-	case "imperial":
-		windThreshold = 12 // mph
-	case "standard":
-		windThreshold = 5.5 // m/s
+func VisibilityRating(meters int) string {
+	switch {
+	case meters >= 10000:
+		return "Excellent"
+	case meters >= 5000:
+		return "Good"
+	case meters >= 2000:
+		return "Moderate"
 	default:
-		windThreshold = 20 // km/h
+		return "Poor"
 	}
-
-	if current.WindSpeed > windThreshold {
-		return "It's quite windy today - secure any loose items outdoors! 💨"
-	}
-
-	return "Conditions look fine, enjoy your day! 🌤️"
 }
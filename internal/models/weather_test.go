@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestGetWeatherEmoji(t *testing.T) {
@@ -182,3 +183,30 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestOneCallResponseLocation(t *testing.T) {
+	if zone := (&OneCallResponse{Timezone: "Asia/Tokyo"}).Location(); zone.String() != "Asia/Tokyo" {
+		t.Errorf("Expected Asia/Tokyo, got %s", zone)
+	}
+
+	zone := (&OneCallResponse{Timezone: "Nowhere/Special", TimezoneOffset: 3600}).Location()
+	if _, offset := time.Unix(0, 0).In(zone).Zone(); offset != 3600 {
+		t.Errorf("Expected a fixed +1h zone for an unknown name, got %d", offset)
+	}
+
+	if zone := (&OneCallResponse{}).Location(); zone != time.Local {
+		t.Errorf("Expected local time without zone information, got %s", zone)
+	}
+}
+
+func TestCityQuery(t *testing.T) {
+	if query := (City{Name: "Springfield", Country: "US"}).Query(); query != "Springfield,US" {
+		t.Errorf("Expected Springfield,US, got %s", query)
+	}
+	if query := (City{Name: "Springfield"}).Query(); query != "Springfield" {
+		t.Errorf("Expected the name alone without a country, got %s", query)
+	}
+	if query := (City{Name: "London,GB", Country: "GB"}).Query(); query != "London,GB" {
+		t.Errorf("Expected a name with a country to be kept, got %s", query)
+	}
+}
@@ -0,0 +1,107 @@
+// Package notify delivers desktop notifications, over D-Bus or through a user supplied command.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type Urgency string
+
+const (
+	UrgencyNormal   Urgency = "normal"
+	UrgencyCritical Urgency = "critical"
+)
+
+type Notification struct {
+	Title   string
+	Body    string
+	Urgency Urgency
+}
+
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// for tests
+var runCommand = func(name string, args []string, env []string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// for tests
+var lookPath = exec.LookPath
+
+// a command hook when one is configured, the desktop otherwise
+func New(command string) Notifier {
+	if strings.TrimSpace(command) != "" {
+		return &CommandNotifier{Command: command}
+	}
+	return &DesktopNotifier{}
+}
+
+// talks to org.freedesktop.Notifications, through gdbus or notify-send,
+// whichever is installed
+type DesktopNotifier struct{}
+
+func (d *DesktopNotifier) Notify(n Notification) error {
+	if _, err := lookPath("gdbus"); err == nil {
+		return runCommand("gdbus", gdbusArgs(n), nil)
+	}
+	if _, err := lookPath("notify-send"); err == nil {
+		return runCommand("notify-send", notifySendArgs(n), nil)
+	}
+	return errors.New("no D-Bus notification tool found, install gdbus or notify-send, or set notify_command")
+}
+
+func gdbusArgs(n Notification) []string {
+	return []string{
+		"call", "--session",
+		"--dest", "org.freedesktop.Notifications",
+		"--object-path", "/org/freedesktop/Notifications",
+		"--method", "org.freedesktop.Notifications.Notify",
+		// app name, replaces id, icon, summary, body, actions, hints, timeout
+		"gust", "0", "dialog-warning", n.Title, n.Body, "[]",
+		fmt.Sprintf("{'urgency': <byte %d>}", urgencyLevel(n.Urgency)),
+		"-1",
+	}
+}
+
+func notifySendArgs(n Notification) []string {
+	return []string{"--app-name=gust", "--icon=dialog-warning", "--urgency=" + string(n.Urgency), n.Title, n.Body}
+}
+
+// the spec's byte values, 0 low, 1 normal and 2 critical
+func urgencyLevel(u Urgency) int {
+	if u == UrgencyCritical {
+		return 2
+	}
+	return 1
+}
+
+// runs Command through the shell with the notification in GUST_TITLE, GUST_BODY and GUST_URGENCY
+type CommandNotifier struct {
+	Command string
+}
+
+func (c *CommandNotifier) Notify(n Notification) error {
+	env := []string{
+		"GUST_TITLE=" + n.Title,
+		"GUST_BODY=" + n.Body,
+		"GUST_URGENCY=" + string(n.Urgency),
+	}
+	if err := runCommand("sh", []string{"-c", c.Command}, env); err != nil {
+		return fmt.Errorf("notify command failed: %w", err)
+	}
+	return nil
+}
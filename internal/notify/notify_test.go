@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type call struct {
+	name string
+	args []string
+	env  []string
+}
+
+func recordCommands(t *testing.T, installed ...string) *[]call {
+	var calls []call
+	originalRun, originalLookPath := runCommand, lookPath
+	t.Cleanup(func() { runCommand, lookPath = originalRun, originalLookPath })
+
+	runCommand = func(name string, args []string, env []string) error {
+		calls = append(calls, call{name, args, env})
+		return nil
+	}
+	lookPath = func(file string) (string, error) {
+		for _, tool := range installed {
+			if tool == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+	return &calls
+}
+
+func TestNew(t *testing.T) {
+	assert.IsType(t, &DesktopNotifier{}, New(""))
+	assert.IsType(t, &DesktopNotifier{}, New("   "))
+	assert.Equal(t, &CommandNotifier{Command: "pager-hook"}, New("pager-hook"))
+}
+
+func TestDesktopNotifierUsesGdbus(t *testing.T) {
+	calls := recordCommands(t, "gdbus", "notify-send")
+
+	err := (&DesktopNotifier{}).Notify(Notification{Title: "Flood Warning", Body: "Until 18:00", Urgency: UrgencyCritical})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+
+	got := (*calls)[0]
+	assert.Equal(t, "gdbus", got.name)
+	assert.Contains(t, got.args, "org.freedesktop.Notifications.Notify")
+	assert.Contains(t, got.args, "Flood Warning")
+	assert.Contains(t, got.args, "{'urgency': <byte 2>}")
+}
+
+func TestDesktopNotifierFallsBackToNotifySend(t *testing.T) {
+	calls := recordCommands(t, "notify-send")
+
+	err := (&DesktopNotifier{}).Notify(Notification{Title: "Wind Advisory", Body: "Gusts to 60 km/h", Urgency: UrgencyNormal})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "notify-send", (*calls)[0].name)
+	assert.Contains(t, (*calls)[0].args, "--urgency=normal")
+}
+
+func TestDesktopNotifierWithoutTools(t *testing.T) {
+	calls := recordCommands(t)
+
+	err := (&DesktopNotifier{}).Notify(Notification{Title: "Heat Advisory"})
+	assert.ErrorContains(t, err, "notify_command")
+	assert.Empty(t, *calls)
+}
+
+func TestCommandNotifier(t *testing.T) {
+	calls := recordCommands(t)
+
+	err := (&CommandNotifier{Command: `echo "$GUST_TITLE" >> alerts.log`}).Notify(Notification{Title: "Flood Warning", Body: "Until 18:00", Urgency: UrgencyCritical})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+
+	got := (*calls)[0]
+	assert.Equal(t, "sh", got.name)
+	assert.Equal(t, []string{"-c", `echo "$GUST_TITLE" >> alerts.log`}, got.args)
+	assert.Equal(t, []string{"GUST_TITLE=Flood Warning", "GUST_BODY=Until 18:00", "GUST_URGENCY=critical"}, got.env)
+}
@@ -0,0 +1,74 @@
+// Package redact keeps credentials out of errors and logs
+package redact
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const mask = "****"
+
+// anything this short is more likely to be a word than a key, and masking it would mangle messages
+const minSecretLength = 8
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+)
+
+// query parameters that carry a key, by any provider's name for it
+var keyParams = regexp.MustCompile(`(?i)([?&](?:api_key|apikey|appid|key|token|access_token)=)[^&\s"']*`)
+
+// Secret marks s as something that should never be printed
+func Secret(s string) {
+	if len(s) < minSecretLength {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, secret := range secrets {
+		if secret == s {
+			return
+		}
+	}
+	secrets = append(secrets, s)
+}
+
+// String masks every registered secret in s, and the value of any key-like query parameter
+func String(s string) string {
+	s = keyParams.ReplaceAllString(s, "${1}"+mask)
+
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, mask)
+		// a url keeps it escaped
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			s = strings.ReplaceAll(s, escaped, mask)
+		}
+	}
+	return s
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return String(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Error wraps err so its message is redacted, errors.Is and errors.As still see err
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
@@ -0,0 +1,40 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	Secret("s3cret-key-value")
+	Secret("short")
+
+	testCases := []struct {
+		name, input, expected string
+	}{
+		{"query parameter", `Get "https://api.example.com/x?q=London&appid=abc123&units=metric": dial tcp`, `Get "https://api.example.com/x?q=London&appid=****&units=metric": dial tcp`},
+		{"first query parameter", "https://breeze.example.com/api/weather/London?api_key=abc123", "https://breeze.example.com/api/weather/London?api_key=****"},
+		{"registered secret", "invalid key s3cret-key-value", "invalid key ****"},
+		{"short values aren't treated as secrets", "a short message", "a short message"},
+		{"nothing to hide", "city \"Atlantis\" not found", "city \"Atlantis\" not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, String(tc.input))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	Secret("another-secret-key")
+	cause := errors.New("rejected another-secret-key")
+	err := Error(fmt.Errorf("failed: %w", cause))
+
+	assert.EqualError(t, err, "failed: rejected ****")
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, Error(nil))
+}
@@ -0,0 +1,416 @@
+// Package rules parses threshold rules like "temp below 0 in the next 12h" and checks them against a forecast.
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/units"
+)
+
+// rules without a window look at the next day
+const defaultWindowHours = 24
+
+// what a rule can be about, and the names it can be written with
+var quantityNames = map[string]string{
+	"temp":           "temp",
+	"temperature":    "temp",
+	"feels like":     "feels_like",
+	"feels_like":     "feels_like",
+	"wind":           "wind",
+	"wind speed":     "wind",
+	"gust":           "gust",
+	"gusts":          "gust",
+	"wind gust":      "gust",
+	"wind gusts":     "gust",
+	"pop":            "pop",
+	"chance of rain": "pop",
+	"rain":           "rain",
+	"snow":           "snow",
+	"humidity":       "humidity",
+	"uv":             "uvi",
+	"uvi":            "uvi",
+}
+
+var operators = map[string]string{
+	"<": "<", "below": "<", "under": "<",
+	">": ">", "above": ">", "over": ">",
+	"<=": "<=", ">=": ">=",
+}
+
+var (
+	conditionPattern = regexp.MustCompile(`^([a-z_ ]+?)\s*(<=|>=|<|>|\bbelow\b|\bunder\b|\babove\b|\bover\b)\s*(-?\d+(?:\.\d+)?)\s*(\S*)$`)
+	nextHoursPattern = regexp.MustCompile(`\s+(?:in the next|in next|next|within|in)\s+(\d+)\s*(?:h|hrs?|hours?)$`)
+	betweenPattern   = regexp.MustCompile(`\s+between\s+(\d{1,2}:\d{2})\s+and\s+(\d{1,2}:\d{2})$`)
+	dayPattern       = regexp.MustCompile(`\s+(today|tomorrow)$`)
+	nowPattern       = regexp.MustCompile(`\s+(?:right )?now$`)
+)
+
+type windowKind int
+
+const (
+	nextHours windowKind = iota
+	onDay
+	timeOfDay
+	// the current conditions
+	current
+)
+
+// the stretch of forecast a rule looks at
+type window struct {
+	kind  windowKind
+	hours int
+	// days after today, for onDay
+	day int
+	// minutes after midnight in the location's zone, for timeOfDay
+	from, to int
+}
+
+type Rule struct {
+	Text      string
+	quantity  string
+	op        string
+	threshold float64
+	// empty follows the display units
+	unit   string
+	window window
+}
+
+// the first forecast value that broke a rule
+type Match struct {
+	Rule  Rule
+	Time  time.Time
+	Value float64
+	Unit  string
+	// from the daily forecast, so Time is the day rather than the hour
+	WholeDay bool
+	// Value before it was converted into Unit
+	metric float64
+}
+
+func Parse(text string) (Rule, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	rule := Rule{Text: strings.Join(strings.Fields(text), " "), window: window{kind: nextHours, hours: defaultWindowHours}}
+
+	condition := normalized
+	if m := nextHoursPattern.FindStringSubmatch(condition); m != nil {
+		hours, _ := strconv.Atoi(m[1])
+		if hours < 1 || hours > 48 {
+			return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: the forecast covers the next 1 to 48 hours"), text)
+		}
+		rule.window.hours = hours
+		condition = strings.TrimSuffix(condition, m[0])
+	} else if m := betweenPattern.FindStringSubmatch(condition); m != nil {
+		from, err := parseClock(m[1])
+		if err != nil {
+			return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: %w"), text, err)
+		}
+		to, err := parseClock(m[2])
+		if err != nil {
+			return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: %w"), text, err)
+		}
+		if from == to {
+			return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: the times must differ"), text)
+		}
+		rule.window = window{kind: timeOfDay, from: from, to: to}
+		condition = strings.TrimSuffix(condition, m[0])
+	} else if m := dayPattern.FindStringSubmatch(condition); m != nil {
+		rule.window = window{kind: onDay}
+		if m[1] == "tomorrow" {
+			rule.window.day = 1
+		}
+		condition = strings.TrimSuffix(condition, m[0])
+	} else if m := nowPattern.FindString(condition); m != "" {
+		rule.window = window{kind: current}
+		condition = strings.TrimSuffix(condition, m)
+	}
+
+	m := conditionPattern.FindStringSubmatch(condition)
+	if m == nil {
+		return Rule{}, fmt.Errorf(i18n.T("invalid rule %q, expected something like \"temp below 0 in the next 12h\""), text)
+	}
+
+	quantity, ok := quantityNames[strings.TrimSpace(m[1])]
+	if !ok {
+		return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: unknown quantity %q, must be one of: temp, feels like, wind, gust, pop, rain, snow, humidity, uv"), text, strings.TrimSpace(m[1]))
+	}
+	if quantity == "pop" && rule.window.kind == current {
+		return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: pop is a forecast, use a time in the future"), text)
+	}
+	rule.quantity = quantity
+	rule.op = operators[m[2]]
+	rule.threshold, _ = strconv.ParseFloat(m[3], 64)
+
+	unit, err := parseUnit(quantity, m[4])
+	if err != nil {
+		return Rule{}, fmt.Errorf(i18n.T("invalid rule %q: %w"), text, err)
+	}
+	rule.unit = unit
+
+	return rule, nil
+}
+
+// every rule has to parse, so a typo isn't silently ignored
+func ParseAll(texts []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(texts))
+	for _, text := range texts {
+		rule, err := Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf(i18n.T("invalid time %q, use 24 hour HH:MM"), value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+func parseUnit(quantity, value string) (string, error) {
+	value = strings.TrimPrefix(value, "°")
+	switch quantity {
+	case "temp", "feels_like":
+		if value == "" {
+			return "", nil
+		}
+		return units.Normalize(units.Temperatures, value)
+	case "wind", "gust":
+		if value == "" {
+			return "", nil
+		}
+		return units.Normalize(units.WindSpeeds, value)
+	case "rain", "snow":
+		if value == "" {
+			return "", nil
+		}
+		return units.Normalize(units.Precipitations, value)
+	case "pop", "humidity":
+		if value != "" && value != "%" {
+			return "", fmt.Errorf(i18n.T("%s is a percentage"), quantity)
+		}
+		return "%", nil
+	default:
+		if value != "" {
+			return "", fmt.Errorf(i18n.T("%s has no unit"), quantity)
+		}
+		return "", nil
+	}
+}
+
+// looks for the first hour (or day) in the rule's window that breaks it, compared in the rule's units
+func (r Rule) Evaluate(weather *models.OneCallResponse, display config.DisplayUnits, now time.Time, loc *time.Location) (Match, bool) {
+	unit := r.resolveUnit(display)
+
+	if r.window.kind == current {
+		c := weather.Current
+		metric := hourValue(models.HourData{
+			Temp: c.Temp, FeelsLike: c.FeelsLike, WindSpeed: c.WindSpeed, WindGust: c.WindGust,
+			Rain: c.Rain, Snow: c.Snow, Humidity: c.Humidity, UVI: c.UVI,
+		}, r.quantity)
+		if value := convert(r.quantity, metric, unit); r.breaks(value) {
+			return Match{Rule: r, Time: time.Unix(c.Dt, 0).In(loc), Value: value, Unit: unit, metric: metric}, true
+		}
+		return Match{}, false
+	}
+
+	if r.window.kind == onDay {
+		target := now.In(loc).AddDate(0, 0, r.window.day)
+		for _, day := range weather.Daily {
+			date := time.Unix(day.Dt, 0).In(loc)
+			if date.YearDay() != target.YearDay() || date.Year() != target.Year() {
+				continue
+			}
+			metric := r.dayValue(day)
+			if value := convert(r.quantity, metric, unit); r.breaks(value) {
+				return Match{Rule: r, Time: date, Value: value, Unit: unit, WholeDay: true, metric: metric}, true
+			}
+			return Match{}, false
+		}
+		return Match{}, false
+	}
+
+	for _, hour := range weather.Hourly {
+		start := time.Unix(hour.Dt, 0).In(loc)
+		if !r.window.includes(start, now) {
+			continue
+		}
+		metric := hourValue(hour, r.quantity)
+		if value := convert(r.quantity, metric, unit); r.breaks(value) {
+			return Match{Rule: r, Time: start, Value: value, Unit: unit, metric: metric}, true
+		}
+	}
+	return Match{}, false
+}
+
+func (r Rule) resolveUnit(display config.DisplayUnits) string {
+	if r.unit != "" {
+		return r.unit
+	}
+	switch r.quantity {
+	case "temp", "feels_like":
+		return display.Temperature
+	case "wind", "gust":
+		return display.Wind
+	case "rain", "snow":
+		return display.Precipitation
+	}
+	return ""
+}
+
+func (r Rule) breaks(value float64) bool {
+	switch r.op {
+	case "<":
+		return value < r.threshold
+	case "<=":
+		return value <= r.threshold
+	case ">=":
+		return value >= r.threshold
+	default:
+		return value > r.threshold
+	}
+}
+
+// the hour that starts at start counts when any of it is inside the window
+func (w window) includes(start, now time.Time) bool {
+	end := start.Add(time.Hour)
+	if !end.After(now) {
+		return false
+	}
+	if w.kind == nextHours {
+		return start.Before(now.Add(time.Duration(w.hours) * time.Hour))
+	}
+
+	// the next occurrence of the time of day
+	if !start.Before(now.Add(24 * time.Hour)) {
+		return false
+	}
+	minute := start.Hour()*60 + start.Minute()
+	if w.from < w.to {
+		return minute < w.to && minute+60 > w.from
+	}
+	// the window wraps past midnight, e.g. 22:00 to 06:00
+	return minute+60 > w.from || minute < w.to
+}
+
+// the day's extreme in the direction the rule cares about
+func (r Rule) dayValue(day models.DayData) float64 {
+	below := r.op == "<" || r.op == "<="
+	switch r.quantity {
+	case "temp":
+		if below {
+			return day.Temp.Min
+		}
+		return day.Temp.Max
+	case "feels_like":
+		feels := []float64{day.FeelsLike.Morn, day.FeelsLike.Day, day.FeelsLike.Eve, day.FeelsLike.Night}
+		if below {
+			return min(feels[0], feels[1], feels[2], feels[3])
+		}
+		return max(feels[0], feels[1], feels[2], feels[3])
+	case "wind":
+		return day.WindSpeed
+	case "gust":
+		return day.WindGust
+	case "pop":
+		return day.Pop
+	case "rain":
+		return day.Rain
+	case "snow":
+		return day.Snow
+	case "humidity":
+		return float64(day.Humidity)
+	default:
+		return day.UVI
+	}
+}
+
+func hourValue(hour models.HourData, quantity string) float64 {
+	switch quantity {
+	case "temp":
+		return hour.Temp
+	case "feels_like":
+		return hour.FeelsLike
+	case "wind":
+		return hour.WindSpeed
+	case "gust":
+		return hour.WindGust
+	case "pop":
+		return hour.Pop
+	case "rain":
+		if hour.Rain != nil {
+			return hour.Rain.OneHour
+		}
+		return 0
+	case "snow":
+		if hour.Snow != nil {
+			return hour.Snow.OneHour
+		}
+		return 0
+	case "humidity":
+		return float64(hour.Humidity)
+	default:
+		return hour.UVI
+	}
+}
+
+// from the metric forecast into the unit the rule was written in
+func convert(quantity string, value float64, unit string) float64 {
+	switch quantity {
+	case "temp", "feels_like":
+		return units.Temperature(value, unit)
+	case "wind", "gust":
+		return units.WindSpeed(value, unit)
+	case "rain", "snow":
+		return units.Precipitation(value, unit)
+	case "pop":
+		return value * 100
+	default:
+		return value
+	}
+}
+
+func (m Match) FormatValue() string {
+	switch m.Unit {
+	case "C", "F":
+		return fmt.Sprintf("%.1f°%s", m.Value, m.Unit)
+	case "%":
+		return fmt.Sprintf("%.0f%%", m.Value)
+	case "bft":
+		return fmt.Sprintf("%.0f Bft", m.Value)
+	case "":
+		return fmt.Sprintf("%.1f", m.Value)
+	default:
+		return fmt.Sprintf("%.1f %s", m.Value, m.Unit)
+	}
+}
+
+// the match in the units the forecast is shown in, so a rule written in m/s still reads
+// as mph next to an imperial forecast. Quantities without units are left alone
+func (m Match) InDisplay(display config.DisplayUnits) Match {
+	unit := Rule{quantity: m.Rule.quantity}.resolveUnit(display)
+	if unit == "" || unit == m.Unit {
+		return m
+	}
+	m.Value = convert(m.Rule.quantity, m.metric, unit)
+	m.Unit = unit
+	return m
+}
+
+// e.g. "-2.3°C at Tue 03:00" or "75% on Wed"
+func (m Match) Describe() string {
+	if m.WholeDay {
+		return i18n.T("%s on %s", m.FormatValue(), i18n.FormatTime(m.Time, "Mon"))
+	}
+	return i18n.T("%s at %s", m.FormatValue(), i18n.FormatTime(m.Time, "Mon 15:04"))
+}
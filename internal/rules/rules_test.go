@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var metricDisplay = config.ResolveDisplayUnits("metric", config.DisplayUnits{})
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected Rule
+	}{
+		{"temp below 0 in the next 12h", Rule{Text: "temp below 0 in the next 12h", quantity: "temp", op: "<", threshold: 0, window: window{kind: nextHours, hours: 12}}},
+		{"Wind Gust above 60 km/h tomorrow", Rule{Text: "Wind Gust above 60 km/h tomorrow", quantity: "gust", op: ">", threshold: 60, unit: "km/h", window: window{kind: onDay, day: 1}}},
+		{"pop > 70% between 08:00 and 09:00", Rule{Text: "pop > 70% between 08:00 and 09:00", quantity: "pop", op: ">", threshold: 70, unit: "%", window: window{kind: timeOfDay, from: 8 * 60, to: 9 * 60}}},
+		{"feels like <= -5°F today", Rule{Text: "feels like <= -5°F today", quantity: "feels_like", op: "<=", threshold: -5, unit: "F", window: window{kind: onDay}}},
+		{"rain over 0.5 in within 6 hours", Rule{Text: "rain over 0.5 in within 6 hours", quantity: "rain", op: ">", threshold: 0.5, unit: "in", window: window{kind: nextHours, hours: 6}}},
+		{"snow above 0 right now", Rule{Text: "snow above 0 right now", quantity: "snow", op: ">", threshold: 0, window: window{kind: current}}},
+		{"uv >= 6", Rule{Text: "uv >= 6", quantity: "uvi", op: ">=", threshold: 6, window: window{kind: nextHours, hours: defaultWindowHours}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			rule, err := Parse(tc.text)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rule)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"cold tomorrow",
+		"pressure below 1000",
+		"temp below 0 in the next 72h",
+		"temp below 0 psi",
+		"humidity above 80 mm",
+		"uv above 6 km",
+		"pop above 50% now",
+		"pop above 50% between 08:00 and 08:00",
+		"pop above 50% between 25:00 and 26:00",
+	} {
+		t.Run(text, func(t *testing.T) {
+			_, err := Parse(text)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	rules, err := ParseAll([]string{"temp below 0", "wind above 10 m/s"})
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+
+	_, err = ParseAll([]string{"temp below 0", "nonsense"})
+	assert.Error(t, err)
+}
+
+func testForecast(now time.Time) *models.OneCallResponse {
+	weather := &models.OneCallResponse{}
+	for i := 0; i < 48; i++ {
+		weather.Hourly = append(weather.Hourly, models.HourData{
+			Dt:        now.Add(time.Duration(i) * time.Hour).Unix(),
+			Temp:      5 - float64(i)/2,
+			WindSpeed: 4,
+			WindGust:  10,
+			Pop:       0.1,
+		})
+	}
+	weather.Hourly[9].Pop = 0.8
+	weather.Current = models.CurrentWeather{Dt: now.Unix(), Temp: 21}
+
+	for i := 0; i < 3; i++ {
+		weather.Daily = append(weather.Daily, models.DayData{
+			Dt:       now.AddDate(0, 0, i).Unix(),
+			Temp:     models.TempData{Min: -1, Max: 8},
+			WindGust: 10 + float64(i)*10,
+		})
+	}
+	return weather
+}
+
+func TestEvaluate(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, loc)
+	weather := testForecast(now)
+
+	testCases := []struct {
+		rule          string
+		matches       bool
+		expectedValue string
+		expectedTime  time.Time
+	}{
+		{"temp below 0 in the next 12h", true, "-0.5°C", now.Add(11 * time.Hour)},
+		{"temp below 0 in the next 6h", false, "", time.Time{}},
+		{"temp below 32F in the next 12h", true, "31.1°F", now.Add(11 * time.Hour)},
+		{"wind above 10 km/h", true, "14.4 km/h", now},
+		{"wind above 3 bft", false, "", time.Time{}},
+		{"pop > 70% between 09:00 and 10:00", true, "80%", now.Add(9 * time.Hour)},
+		{"pop > 70% between 08:00 and 09:00", false, "", time.Time{}},
+		{"gust above 60 km/h tomorrow", true, "72.0 km/h", now.AddDate(0, 0, 1)},
+		{"gust above 60 km/h today", false, "", time.Time{}},
+		{"temp below 0 today", true, "-1.0°C", now},
+		{"temp above 10 today", false, "", time.Time{}},
+		{"temp above 20 now", true, "21.0°C", now},
+		{"rain above 0 now", false, "", time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rule, func(t *testing.T) {
+			rule, err := Parse(tc.rule)
+			require.NoError(t, err)
+
+			match, ok := rule.Evaluate(weather, metricDisplay, now, loc)
+			assert.Equal(t, tc.matches, ok)
+			if tc.matches {
+				assert.Equal(t, tc.expectedValue, match.FormatValue())
+				assert.True(t, tc.expectedTime.Equal(match.Time), "matched at %v", match.Time)
+			}
+		})
+	}
+}
+
+func TestEvaluateSkipsPastHours(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)
+	weather := testForecast(now.Add(-12*time.Hour - 30*time.Minute))
+
+	rule, err := Parse("pop above 70% in the next 3h")
+	require.NoError(t, err)
+
+	// the wet hour was at 09:00, before now
+	_, ok := rule.Evaluate(weather, metricDisplay, now, time.UTC)
+	assert.False(t, ok)
+}
+
+func TestEvaluateFollowsDisplayUnits(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	rule, err := Parse("temp below 35 in the next 2h")
+	require.NoError(t, err)
+
+	_, ok := rule.Evaluate(testForecast(now), metricDisplay, now, time.UTC)
+	assert.True(t, ok, "5°C is below 35°C")
+
+	imperial := config.ResolveDisplayUnits("imperial", config.DisplayUnits{})
+	_, ok = rule.Evaluate(testForecast(now), imperial, now, time.UTC)
+	assert.False(t, ok, "41°F is not below 35°F")
+}
+
+func TestDescribe(t *testing.T) {
+	at := time.Date(2024, 1, 9, 3, 0, 0, 0, time.UTC)
+	assert.Equal(t, "-2.3°C at Tue 03:00", Match{Time: at, Value: -2.3, Unit: "C"}.Describe())
+	assert.Equal(t, "75% on Tue", Match{Time: at, Value: 75, Unit: "%", WholeDay: true}.Describe())
+	assert.Equal(t, "5 Bft at Tue 03:00", Match{Time: at, Value: 5, Unit: "bft"}.Describe())
+}
@@ -3,7 +3,10 @@ package templates
 import (
 	"html/template"
 	"io"
+	"regexp"
 	"time"
+
+	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
 const authSuccessTemplateContent = `<!DOCTYPE html>
@@ -12,18 +15,18 @@ const authSuccessTemplateContent = `<!DOCTYPE html>
     <title>Gust Authentication Success</title>
     <style>
         :root {
-            --base: #191724;
-            --surface: #1f1d2e;
-            --overlay: #26233a;
-            --subtle: #908caa;
-            --text: #e0def4;
-            --love: #eb6f92;
-            --gold: #f6c177;
-            --rose: #ebbcba;
-            --pine: #31748f;
-            --foam: #9ccfd8;
-            --iris: #c4a7e7;
-            --highlight-med: #403d52;
+            --base: {{.Theme.Base}};
+            --surface: {{.Theme.Surface}};
+            --overlay: {{.Theme.Overlay}};
+            --subtle: {{.Theme.Subtle}};
+            --text: {{.Theme.Text}};
+            --love: {{.Theme.Love}};
+            --gold: {{.Theme.Gold}};
+            --rose: {{.Theme.Rose}};
+            --pine: {{.Theme.Pine}};
+            --foam: {{.Theme.Foam}};
+            --iris: {{.Theme.Iris}};
+            --highlight-med: {{.Theme.Highlight}};
         }
         body {
             font-family: system-ui, sans-serif;
@@ -124,6 +127,36 @@ func init() {
 	templates = template.Must(template.New("auth_success").Parse(authSuccessTemplateContent))
 }
 
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// the page needs hex colors, so ANSI numbers and monochrome's empty colors use the default theme's
+func cssTheme(theme styles.Theme) styles.Theme {
+	fallback := styles.Themes[styles.DefaultTheme]
+	colors := []struct {
+		value    *string
+		fallback string
+	}{
+		{&theme.Base, fallback.Base},
+		{&theme.Surface, fallback.Surface},
+		{&theme.Overlay, fallback.Overlay},
+		{&theme.Highlight, fallback.Highlight},
+		{&theme.Subtle, fallback.Subtle},
+		{&theme.Text, fallback.Text},
+		{&theme.Love, fallback.Love},
+		{&theme.Gold, fallback.Gold},
+		{&theme.Rose, fallback.Rose},
+		{&theme.Pine, fallback.Pine},
+		{&theme.Foam, fallback.Foam},
+		{&theme.Iris, fallback.Iris},
+	}
+	for _, color := range colors {
+		if !hexColor.MatchString(*color.value) {
+			*color.value = color.fallback
+		}
+	}
+	return theme
+}
+
 func RenderSuccessTemplate(w io.Writer, login, apiKey, serverURL string) error {
 	lastAuth := time.Now().Format(time.RFC3339)
 
@@ -132,11 +165,13 @@ func RenderSuccessTemplate(w io.Writer, login, apiKey, serverURL string) error {
 		ApiKey    string
 		ServerURL string
 		LastAuth  string
+		Theme     styles.Theme
 	}{
 		Login:     login,
 		ApiKey:    apiKey,
 		ServerURL: serverURL,
 		LastAuth:  lastAuth,
+		Theme:     cssTheme(styles.Current()),
 	}
 
 	return templates.ExecuteTemplate(w, "auth_success", data)
@@ -0,0 +1,34 @@
+package templates
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+func TestCSSTheme(t *testing.T) {
+	fallback := styles.Themes[styles.DefaultTheme]
+
+	theme := cssTheme(styles.Theme{Base: "", Love: "9", Text: "#ABCDEF", Pine: "#fff"})
+	if theme.Base != fallback.Base || theme.Love != fallback.Love || theme.Pine != fallback.Pine {
+		t.Errorf("Expected non-hex colors to fall back to %s, got %+v", styles.DefaultTheme, theme)
+	}
+	if theme.Text != "#ABCDEF" {
+		t.Errorf("Expected hex colors to be kept, got %s", theme.Text)
+	}
+}
+
+func TestRenderSuccessTemplateMonochrome(t *testing.T) {
+	styles.Apply(styles.Themes["monochrome"])
+	defer styles.Apply(styles.Themes[styles.DefaultTheme])
+
+	var out bytes.Buffer
+	if err := RenderSuccessTemplate(&out, "testuser", "key", "https://example.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(out.String(), "--base: ;") {
+		t.Error("Expected every css color to have a value")
+	}
+}
@@ -0,0 +1,225 @@
+// Package tips picks advice to show with the weather. Tips are data: rules that must all match,
+// a priority and a message template, so users can add, replace and translate them in their config.
+package tips
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/rules"
+)
+
+// conditions carry their units so they mean the same whatever is displayed
+var Builtin = []config.Tip{
+	{
+		Name:     "snowing",
+		When:     []string{"snow above 0 now"},
+		Priority: 100,
+		Message:  "It might be snowing right now! Stay warm and take care on slippery surfaces! ⛄",
+	},
+	{
+		Name:     "raining",
+		When:     []string{"rain above 0 now"},
+		Priority: 90,
+		Message:  "It might be raining right now - don't go out without an umbrella! ☔",
+	},
+	{
+		Name:     "snow_soon",
+		When:     []string{"snow above 0.1mm in the next 12h"},
+		Priority: 80,
+		Message:  "Snow expected around {{.Time}} - dress warmly and wear appropriate footwear! ❄️",
+	},
+	{
+		Name:     "rain_soon",
+		When:     []string{"rain above 0.5mm in the next 12h"},
+		Priority: 70,
+		Message:  "Rain expected around {{.Time}} - don't forget your umbrella! ☔",
+	},
+	{
+		Name:     "rain_likely",
+		When:     []string{"pop above 40% in the next 12h"},
+		Priority: 65,
+		Message:  "Rain likely around {{.Time}} ({{.Value}} chance) - an umbrella might come in handy! ☔",
+	},
+	{
+		Name:     "cold",
+		When:     []string{"temp below 5C now"},
+		Priority: 60,
+		Message:  "It's quite cold - wear a heavy coat and maybe a scarf! 🧣",
+	},
+	{
+		Name:     "cool",
+		When:     []string{"temp >= 5C now", "temp below 12C now"},
+		Priority: 50,
+		Message:  "It's cool today - a jacket would be a good idea. 🧥",
+	},
+	{
+		Name:     "hot",
+		When:     []string{"temp above 28C now"},
+		Priority: 60,
+		Message:  "It's hot today - stay hydrated and wear sunscreen! 🧴",
+	},
+	{
+		Name:     "uv",
+		When:     []string{"uv above 6 now"},
+		Priority: 55,
+		Message:  "UV index is high ({{.Value}}) - wear sunscreen and maybe a hat! 🧢",
+	},
+	{
+		Name:     "windy",
+		When:     []string{"wind above 5.5 m/s now"}, // a fresh breeze and up on the beaufort scale
+		Priority: 40,
+		Message:  "It's quite windy today ({{.Value}}) - secure any loose items outdoors! 💨",
+	},
+	{
+		Name:    "fine",
+		Message: "Conditions look fine, enjoy your day! 🌤️",
+	},
+}
+
+type tip struct {
+	name         string
+	when         []rules.Rule
+	priority     int
+	message      *template.Template
+	translations map[string]*template.Template
+}
+
+// what a message template can refer to
+type messageData struct {
+	Time  string
+	Value string
+}
+
+type Engine struct {
+	tips []tip
+}
+
+// the built in tips with custom ones added, or replacing those with the same name
+func New(custom []config.Tip) (*Engine, error) {
+	// built in tips are translated by the message catalog
+	merged := make([]config.Tip, len(Builtin))
+	for i, builtin := range Builtin {
+		builtin.Translations = i18n.Translations(builtin.Message)
+		merged[i] = builtin
+	}
+	for _, c := range custom {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == c.Name {
+				merged[i], replaced = c, true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, c)
+		}
+	}
+
+	engine := &Engine{}
+	for _, c := range merged {
+		if c.Disabled {
+			continue
+		}
+		t, err := compile(c)
+		if err != nil {
+			return nil, err
+		}
+		engine.tips = append(engine.tips, t)
+	}
+
+	// stable, so equal priorities keep the order they were defined in
+	sort.SliceStable(engine.tips, func(i, j int) bool {
+		return engine.tips[i].priority > engine.tips[j].priority
+	})
+	return engine, nil
+}
+
+func compile(c config.Tip) (tip, error) {
+	if strings.TrimSpace(c.Message) == "" {
+		return tip{}, fmt.Errorf("tip %q has no message", c.Name)
+	}
+
+	when, err := rules.ParseAll(c.When)
+	if err != nil {
+		return tip{}, fmt.Errorf("tip %q: %w", c.Name, err)
+	}
+
+	message, err := template.New(c.Name).Parse(c.Message)
+	if err != nil {
+		return tip{}, fmt.Errorf("tip %q: invalid message: %w", c.Name, err)
+	}
+
+	translations := map[string]*template.Template{}
+	for lang, text := range c.Translations {
+		translated, err := template.New(c.Name + "." + lang).Parse(text)
+		if err != nil {
+			return tip{}, fmt.Errorf("tip %q: invalid %s message: %w", c.Name, lang, err)
+		}
+		translations[lang] = translated
+	}
+
+	return tip{name: c.Name, when: when, priority: c.Priority, message: message, translations: translations}, nil
+}
+
+// up to limit messages, highest priority first. Tips without rules only show when nothing else does
+func (e *Engine) Tips(weather *models.OneCallResponse, display config.DisplayUnits, now time.Time, loc *time.Location, lang string, limit int) []string {
+	var matched, fallbacks []string
+	for _, t := range e.tips {
+		if len(t.when) == 0 {
+			fallbacks = append(fallbacks, t.render(lang, messageData{}))
+			continue
+		}
+
+		data, ok := t.matches(weather, display, now, loc)
+		if ok {
+			matched = append(matched, t.render(lang, data))
+		}
+	}
+
+	if len(matched) == 0 {
+		matched = fallbacks
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+// every rule has to match, the first one fills in the message
+func (t tip) matches(weather *models.OneCallResponse, display config.DisplayUnits, now time.Time, loc *time.Location) (messageData, bool) {
+	var data messageData
+	for i, rule := range t.when {
+		match, ok := rule.Evaluate(weather, display, now, loc)
+		if !ok {
+			return messageData{}, false
+		}
+		if i == 0 {
+			data = messageData{Time: match.Time.Format("15:04"), Value: match.InDisplay(display).FormatValue()}
+			if match.WholeDay {
+				data.Time = i18n.FormatTime(match.Time, "Mon")
+			}
+		}
+	}
+	return data, true
+}
+
+// falls back to the default message when there's no translation
+func (t tip) render(lang string, data messageData) string {
+	message := t.message
+	if translated, ok := t.translations[lang]; ok {
+		message = translated
+	}
+
+	var sb strings.Builder
+	if err := message.Execute(&sb, data); err != nil {
+		return fmt.Sprintf("tip %q: %v", t.name, err)
+	}
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+package tips
+
+import (
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var metric = config.ResolveDisplayUnits("metric", config.DisplayUnits{})
+
+func TestBuiltinTipsParse(t *testing.T) {
+	_, err := New(nil)
+	require.NoError(t, err)
+}
+
+func TestTipsRanked(t *testing.T) {
+	now := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	weather := &models.OneCallResponse{
+		Current: models.CurrentWeather{Dt: now.Unix(), Temp: 2, WindSpeed: 9, UVI: 1},
+		Hourly:  []models.HourData{{Dt: now.Unix()}, {Dt: now.Unix() + 3600, Pop: 0.8}},
+	}
+
+	engine, err := New(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Rain likely around 03:00 (80% chance) - an umbrella might come in handy! ☔",
+		"It's quite cold - wear a heavy coat and maybe a scarf! 🧣",
+		"It's quite windy today (32.4 km/h) - secure any loose items outdoors! 💨",
+	}, engine.Tips(weather, metric, now, time.UTC, "", 3))
+
+	assert.Len(t, engine.Tips(weather, metric, now, time.UTC, "", 1), 1)
+}
+
+func TestTipsValueInDisplayUnits(t *testing.T) {
+	now := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	weather := &models.OneCallResponse{
+		Current: models.CurrentWeather{Dt: now.Unix(), Temp: 15, WindSpeed: 9, UVI: 1},
+	}
+
+	engine, err := New(nil)
+	require.NoError(t, err)
+
+	imperial := config.ResolveDisplayUnits("imperial", config.DisplayUnits{})
+	assert.Contains(t, engine.Tips(weather, imperial, now, time.UTC, "", 3), "It's quite windy today (20.1 mph) - secure any loose items outdoors! 💨")
+}
+
+func TestTipsTimesInLocation(t *testing.T) {
+	// 03:00 utc is noon in tokyo
+	now := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	weather := &models.OneCallResponse{
+		Current: models.CurrentWeather{Dt: now.Unix(), Temp: 15},
+		Hourly:  []models.HourData{{Dt: now.Unix()}, {Dt: now.Unix() + 3600, Rain: &models.RainData{OneHour: 2}}},
+	}
+
+	engine, err := New(nil)
+	require.NoError(t, err)
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	assert.Equal(t, []string{"Rain expected around 12:00 - don't forget your umbrella! ☔"}, engine.Tips(weather, metric, now, tokyo, "", 1))
+	assert.Equal(t, []string{"Rain expected around 03:00 - don't forget your umbrella! ☔"}, engine.Tips(weather, metric, now, time.UTC, "", 1))
+}
+
+func TestTipsFallback(t *testing.T) {
+	now := time.Now()
+	weather := &models.OneCallResponse{Current: models.CurrentWeather{Dt: now.Unix(), Temp: 20}}
+
+	engine, err := New(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Conditions look fine, enjoy your day! 🌤️"}, engine.Tips(weather, metric, now, time.UTC, "", 3))
+}
+
+func TestCustomTips(t *testing.T) {
+	now := time.Now()
+	weather := &models.OneCallResponse{Current: models.CurrentWeather{Dt: now.Unix(), Temp: 20, Humidity: 90}}
+
+	engine, err := New([]config.Tip{
+		{Name: "muggy", When: []string{"humidity above 80 now"}, Priority: 10, Message: "Muggy at {{.Value}} humidity", Translations: map[string]string{"de": "Schwül bei {{.Value}} Luftfeuchtigkeit"}},
+		{Name: "fine", Message: "Nothing to report"},
+		{Name: "cold", Disabled: true},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Muggy at 90% humidity"}, engine.Tips(weather, metric, now, time.UTC, "", 3))
+	assert.Equal(t, []string{"Schwül bei 90% Luftfeuchtigkeit"}, engine.Tips(weather, metric, now, time.UTC, "de", 3))
+	assert.Equal(t, []string{"Muggy at 90% humidity"}, engine.Tips(weather, metric, now, time.UTC, "fr", 3), "untranslated languages fall back")
+
+	weather.Current.Humidity = 40
+	assert.Equal(t, []string{"Nothing to report"}, engine.Tips(weather, metric, now, time.UTC, "", 3), "the fallback was replaced")
+
+	weather.Current.Temp = -5
+	assert.Equal(t, []string{"Nothing to report"}, engine.Tips(weather, metric, now, time.UTC, "", 3), "the cold tip was disabled")
+}
+
+func TestInvalidCustomTips(t *testing.T) {
+	for _, custom := range []config.Tip{
+		{Name: "no message", When: []string{"temp above 0 now"}},
+		{Name: "bad rule", When: []string{"cold"}, Message: "brr"},
+		{Name: "bad template", Message: "{{.Time"},
+	} {
+		t.Run(custom.Name, func(t *testing.T) {
+			_, err := New([]config.Tip{custom})
+			assert.ErrorContains(t, err, custom.Name)
+		})
+	}
+}
+
+func TestBuiltinTranslations(t *testing.T) {
+	now := time.Now()
+	weather := &models.OneCallResponse{Current: models.CurrentWeather{Dt: now.Unix(), Temp: 20}}
+
+	engine, err := New(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Die Bedingungen sehen gut aus, genieß deinen Tag! 🌤️"}, engine.Tips(weather, metric, now, time.UTC, "de", 3))
+	assert.Equal(t, []string{"Las condiciones parecen buenas, ¡disfruta del día! 🌤️"}, engine.Tips(weather, metric, now, time.UTC, "es", 3))
+}
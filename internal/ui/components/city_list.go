@@ -0,0 +1,150 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+// shared by the setup wizard and the command line picker, rebuilt when the theme changes
+var (
+	cityCursorStyle       lipgloss.Style
+	citySelectedItemStyle lipgloss.Style
+	cityTitleStyle        lipgloss.Style
+	cityHintStyle         lipgloss.Style
+)
+
+func init() {
+	styles.OnApply(func() {
+		cityCursorStyle = lipgloss.NewStyle().Foreground(styles.Love)
+		citySelectedItemStyle = lipgloss.NewStyle().Foreground(styles.Foam)
+		cityTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Text)
+		cityHintStyle = lipgloss.NewStyle().Foreground(styles.Subtle).Italic(true)
+	})
+}
+
+// "Springfield - Illinois, US 🇺🇸"
+func CityLabel(city models.City) string {
+	var locationInfo string
+
+	if city.State != "" && city.Country != "" {
+		flag := CountryFlag(city.Country)
+		locationInfo = fmt.Sprintf("%s, %s %s", city.State, city.Country, flag)
+	} else if city.Country != "" {
+		flag := CountryFlag(city.Country)
+		locationInfo = fmt.Sprintf("%s %s", city.Country, flag)
+	} else {
+		locationInfo = fmt.Sprintf("(%.4f, %.4f)", city.Lat, city.Lon)
+	}
+
+	return fmt.Sprintf("%s - %s", city.Name, locationInfo)
+}
+
+// one line per city with an arrow on the cursor
+func RenderCityList(cities []models.City, cursor int) string {
+	var sb strings.Builder
+	for i, city := range cities {
+		if cursor == i {
+			sb.WriteString(fmt.Sprintf("%s %s\n", cityCursorStyle.Render("→"), citySelectedItemStyle.Render(CityLabel(city))))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s\n", CityLabel(city)))
+		}
+	}
+	return sb.String()
+}
+
+// regional indicator symbols for a two letter country code
+func CountryFlag(countryCode string) string {
+	if countryCode == "" {
+		return "🌍"
+	}
+
+	if len(countryCode) != 2 {
+		return "🌍"
+	}
+
+	cc := strings.ToUpper(countryCode)
+	const offset = 127397
+	firstLetter := rune(cc[0]) + offset
+	secondLetter := rune(cc[1]) + offset
+	flag := string(firstLetter) + string(secondLetter)
+
+	return flag
+}
+
+// a standalone version of the wizard's city select step
+type CityPickerModel struct {
+	Title    string
+	Cities   []models.City
+	Cursor   int
+	Selected *models.City
+	Quitting bool
+}
+
+func NewCityPicker(title string, cities []models.City) CityPickerModel {
+	return CityPickerModel{
+		Title:  title,
+		Cities: cities,
+	}
+}
+
+func (m CityPickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m CityPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c", "esc", "q":
+		m.Quitting = true
+		return m, tea.Quit
+	case "up", "k":
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+	case "down", "j":
+		if m.Cursor < len(m.Cities)-1 {
+			m.Cursor++
+		}
+	case "enter":
+		if len(m.Cities) > 0 {
+			selected := m.Cities[m.Cursor]
+			m.Selected = &selected
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m CityPickerModel) View() string {
+	if m.Selected != nil || m.Quitting {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(cityTitleStyle.Render(m.Title) + "\n\n")
+	sb.WriteString(RenderCityList(m.Cities, m.Cursor))
+	sb.WriteString("\n" + cityHintStyle.Render("Press Enter to select or Esc to cancel") + "\n")
+	return sb.String()
+}
+
+// nil without an error means the picker was cancelled
+func RunCityPicker(title string, cities []models.City) (*models.City, error) {
+	finalModel, err := tea.NewProgram(NewCityPicker(title, cities)).Run()
+	if err != nil {
+		return nil, fmt.Errorf("error running city picker: %w", err)
+	}
+
+	if m, ok := finalModel.(CityPickerModel); ok {
+		return m.Selected, nil
+	}
+	return nil, fmt.Errorf("unexpected error in city picker")
+}
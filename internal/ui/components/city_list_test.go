@@ -0,0 +1,89 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountryEmoji(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		want        string
+	}{
+		{
+			name:        "valid country code",
+			countryCode: "GB",
+			want:        "🇬🇧",
+		},
+		{
+			name:        "empty country code",
+			countryCode: "",
+			want:        "🌍",
+		},
+		{
+			name:        "invalid length",
+			countryCode: "GBR",
+			want:        "🌍",
+		},
+		{
+			name:        "lowercase code",
+			countryCode: "fr",
+			want:        "🇫🇷",
+		},
+		{
+			name:        "single letter code",
+			countryCode: "X",
+			want:        "🌍",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CountryFlag(tt.countryCode)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCityLabel(t *testing.T) {
+	assert.Equal(t, "Springfield - Illinois, US 🇺🇸", CityLabel(models.City{Name: "Springfield", State: "Illinois", Country: "US"}))
+	assert.Equal(t, "Paris - FR 🇫🇷", CityLabel(models.City{Name: "Paris", Country: "FR"}))
+	assert.Equal(t, "Nowhere - (1.5000, 2.0000)", CityLabel(models.City{Name: "Nowhere", Lat: 1.5, Lon: 2}))
+}
+
+func TestCityPicker(t *testing.T) {
+	cities := []models.City{
+		{Name: "Springfield", State: "Illinois", Country: "US"},
+		{Name: "Springfield", State: "Missouri", Country: "US"},
+	}
+
+	t.Run("enter selects the city under the cursor", func(t *testing.T) {
+		var model tea.Model = NewCityPicker("Which Springfield?", cities)
+		assert.Contains(t, model.View(), "Which Springfield?")
+		assert.Contains(t, model.View(), "Missouri")
+
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+		model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		picker := model.(CityPickerModel)
+		assert.NotNil(t, cmd)
+		if assert.NotNil(t, picker.Selected) {
+			assert.Equal(t, "Missouri", picker.Selected.State)
+		}
+		assert.Empty(t, picker.View())
+	})
+
+	t.Run("esc cancels without a selection", func(t *testing.T) {
+		model, cmd := NewCityPicker("Which Springfield?", cities).Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+		picker := model.(CityPickerModel)
+		assert.NotNil(t, cmd)
+		assert.True(t, picker.Quitting)
+		assert.Nil(t, picker.Selected)
+	})
+}
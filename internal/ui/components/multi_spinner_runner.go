@@ -0,0 +1,184 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+// one labelled unit of work for RunAll / RunAllWithSpinner
+type Task[T any] struct {
+	Label string
+	Run   func(ctx context.Context) (T, error)
+}
+
+// results come back in the same order as the tasks, a failed task only sets Err
+type TaskResult[T any] struct {
+	Value T
+	Err   error
+}
+
+type taskDoneMsg[T any] struct {
+	index  int
+	result TaskResult[T]
+}
+
+// runs a task once a slot in limit is free, tasks still waiting when ctx is done don't run at all
+func runTask[T any](ctx context.Context, limit chan struct{}, task Task[T]) TaskResult[T] {
+	select {
+	case limit <- struct{}{}:
+	case <-ctx.Done():
+		return TaskResult[T]{Err: ctx.Err()}
+	}
+	defer func() { <-limit }()
+
+	value, err := task.Run(ctx)
+	return TaskResult[T]{Value: value, Err: err}
+}
+
+// runs tasks with at most concurrency in flight, without any ui
+func RunAll[T any](ctx context.Context, tasks []Task[T], concurrency int) []TaskResult[T] {
+	limit := make(chan struct{}, max(concurrency, 1))
+	results := make([]TaskResult[T], len(tasks))
+
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task Task[T]) {
+			defer wg.Done()
+			results[i] = runTask(ctx, limit, task)
+		}(i, task)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// a single spinner with a status line per task
+type MultiSpinnerRunnerModel[T any] struct {
+	spinner   SpinnerModel
+	tasks     []Task[T]
+	results   []TaskResult[T]
+	finished  []bool
+	remaining int
+	limit     chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      bool
+}
+
+func NewMultiSpinnerRunner[T any](
+	ctx context.Context,
+	tasks []Task[T],
+	concurrency int,
+	spinnerType spinner.Spinner,
+	color lipgloss.Color,
+) MultiSpinnerRunnerModel[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return MultiSpinnerRunnerModel[T]{
+		spinner:   NewCustomSpinner(spinnerType, color),
+		tasks:     tasks,
+		results:   make([]TaskResult[T], len(tasks)),
+		finished:  make([]bool, len(tasks)),
+		remaining: len(tasks),
+		limit:     make(chan struct{}, max(concurrency, 1)),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// starts the spinner and every task, the limit channel keeps the in flight count bounded
+func (m MultiSpinnerRunnerModel[T]) Init() tea.Cmd {
+	if len(m.tasks) == 0 {
+		return tea.Quit
+	}
+
+	cmds := []tea.Cmd{m.spinner.Tick()}
+	for i, task := range m.tasks {
+		i, task := i, task
+		cmds = append(cmds, func() tea.Msg {
+			return taskDoneMsg[T]{index: i, result: runTask(m.ctx, m.limit, task)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m MultiSpinnerRunnerModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.cancel()
+			m.done = true
+			return m, tea.Quit
+		}
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case taskDoneMsg[T]:
+		m.results[msg.index] = msg.result
+		m.finished[msg.index] = true
+		m.remaining--
+		if m.remaining == 0 {
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// pending tasks share the spinner, finished ones show a tick or their error
+func (m MultiSpinnerRunnerModel[T]) View() string {
+	if m.done {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, task := range m.tasks {
+		switch {
+		case !m.finished[i]:
+			fmt.Fprintf(&b, "%s %s\n", m.spinner.View(), styles.ProgressMessageStyle.Render(task.Label))
+		case m.results[i].Err != nil:
+			fmt.Fprintf(&b, "%s %s\n", styles.ErrorStyle("✗"), styles.HintStyle.Render(fmt.Sprintf("%s: %v", task.Label, m.results[i].Err)))
+		default:
+			fmt.Fprintf(&b, "%s %s\n", styles.SuccessStyle("✓"), styles.HintStyle.Render(task.Label))
+		}
+	}
+	return output.Glyphs(b.String())
+}
+
+// like RunWithSpinner but for several tasks, one failing doesn't stop the others
+func RunAllWithSpinner[T any](ctx context.Context, tasks []Task[T], concurrency int, spinnerType spinner.Spinner, color lipgloss.Color) ([]TaskResult[T], error) {
+	if !spinnerEnabled() {
+		return RunAll(ctx, tasks, concurrency), nil
+	}
+
+	model := NewMultiSpinnerRunner(ctx, tasks, concurrency, spinnerType, color)
+	defer model.cancel()
+
+	p := tea.NewProgram(model)
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("error running spinner: %w", err)
+	}
+
+	m, ok := finalModel.(MultiSpinnerRunnerModel[T])
+	if !ok {
+		return nil, fmt.Errorf("unexpected error in spinner")
+	}
+
+	// ctrl+c leaves some tasks unfinished
+	for i := range m.results {
+		if !m.finished[i] {
+			m.results[i].Err = ErrCancelled
+		}
+	}
+	return m.results, nil
+}
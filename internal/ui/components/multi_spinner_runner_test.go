@@ -0,0 +1,109 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunAll(t *testing.T) {
+	var inFlight, peak int32
+
+	tasks := make([]Task[int], 8)
+	for i := range tasks {
+		i := i
+		tasks[i] = Task[int]{
+			Label: "task",
+			Run: func(ctx context.Context) (int, error) {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					seen := atomic.LoadInt32(&peak)
+					if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+
+				if i == 3 {
+					return 0, errors.New("boom")
+				}
+				return i * 10, nil
+			},
+		}
+	}
+
+	results := RunAll(context.Background(), tasks, 3)
+
+	assert.Len(t, results, len(tasks))
+	assert.LessOrEqual(t, peak, int32(3), "no more than 3 tasks should run at once")
+	for i, result := range results {
+		if i == 3 {
+			assert.EqualError(t, result.Err, "boom")
+			continue
+		}
+		assert.NoError(t, result.Err)
+		assert.Equal(t, i*10, result.Value, "results should stay in task order")
+	}
+}
+
+func TestRunAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran := false
+	// the one slot is taken, so the task has to wait and sees the cancellation first
+	limit := make(chan struct{}, 1)
+	limit <- struct{}{}
+	result := runTask(ctx, limit, Task[int]{Run: func(ctx context.Context) (int, error) { ran = true; return 1, nil }})
+
+	assert.False(t, ran)
+	assert.ErrorIs(t, result.Err, context.Canceled)
+}
+
+func TestMultiSpinnerRunnerModel(t *testing.T) {
+	tasks := []Task[string]{{Label: "london"}, {Label: "paris"}}
+	model := NewMultiSpinnerRunner(context.Background(), tasks, 2, WeatherEmojis, "#9ccfd8")
+
+	updated, cmd := model.Update(taskDoneMsg[string]{index: 1, result: TaskResult[string]{Err: errors.New("not found")}})
+	m := updated.(MultiSpinnerRunnerModel[string])
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.View(), "paris: not found")
+	assert.Contains(t, m.View(), "london")
+
+	updated, cmd = m.Update(taskDoneMsg[string]{index: 0, result: TaskResult[string]{Value: "ok"}})
+	m = updated.(MultiSpinnerRunnerModel[string])
+	assert.NotNil(t, cmd, "finishing the last task should quit")
+	assert.Equal(t, "ok", m.results[0].Value)
+	assert.Empty(t, m.View())
+}
+
+func TestMultiSpinnerRunnerCtrlC(t *testing.T) {
+	model := NewMultiSpinnerRunner(context.Background(), []Task[string]{{Label: "london"}}, 1, WeatherEmojis, "#9ccfd8")
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m := updated.(MultiSpinnerRunnerModel[string])
+	assert.NotNil(t, cmd)
+	assert.True(t, m.done)
+	assert.ErrorIs(t, m.ctx.Err(), context.Canceled, "in flight tasks should be told to stop")
+}
+
+func TestSpinnersSkippedWithoutTerminal(t *testing.T) {
+	original := spinnerEnabled
+	t.Cleanup(func() { spinnerEnabled = original })
+	spinnerEnabled = func() bool { return false }
+
+	value, err := RunWithSpinner(context.Background(), "loading", WeatherEmojis, "#9ccfd8", func(ctx context.Context) (int, error) { return 42, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 42, value)
+
+	tasks := []Task[int]{{Label: "a", Run: func(ctx context.Context) (int, error) { return 1, nil }}}
+	results, err := RunAllWithSpinner(context.Background(), tasks, 2, WeatherEmojis, "#9ccfd8")
+	assert.NoError(t, err)
+	assert.Equal(t, []TaskResult[int]{{Value: 1}}, results)
+}
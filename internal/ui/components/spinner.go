@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
@@ -23,8 +24,11 @@ func NewSpinner() SpinnerModel {
 	}
 }
 
-// custom spinner type/colour
+// custom spinner type/colour, plain mode always spins a line
 func NewCustomSpinner(spinnerType spinner.Spinner, color lipgloss.Color) SpinnerModel {
+	if output.IsPlain() {
+		spinnerType = spinner.Line
+	}
 	s := spinner.New()
 	s.Spinner = spinnerType
 	s.Style = lipgloss.NewStyle().Foreground(color)
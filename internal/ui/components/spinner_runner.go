@@ -1,14 +1,20 @@
 package components
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
+// returned when ctrl+c stops a spinner before its work finished
+var ErrCancelled = errors.New("cancelled")
+
 // message types
 type successMsg[T any] struct {
 	result T
@@ -16,28 +22,54 @@ type successMsg[T any] struct {
 type errorMsg struct {
 	err error
 }
+type messageMsg string
+
+type spinnerKey struct{}
+
+// lets the running func reach the program showing its spinner
+type spinnerHandle struct {
+	program *tea.Program
+}
+
+// SetSpinnerMessage replaces the message next to the spinner running the func ctx was passed to,
+// without a spinner it does nothing
+func SetSpinnerMessage(ctx context.Context, message string) {
+	if handle, ok := ctx.Value(spinnerKey{}).(*spinnerHandle); ok && handle.program != nil {
+		handle.program.Send(messageMsg(message))
+	}
+}
 
 // represents a spinner that runs a function and returns a result
 type SpinnerRunnerModel[T any] struct {
 	spinner  SpinnerModel
 	message  string
-	function func() (T, error)
-	result   T
-	err      error
-	done     bool
+	function func(ctx context.Context) (T, error)
+	ctx      context.Context
+	// ctrl+c calls this so fn can give up on whatever it's waiting for
+	cancel context.CancelFunc
+	handle *spinnerHandle
+	result T
+	err    error
+	done   bool
 }
 
-// creates a new runner - displays a spinner while executing a func
+// creates a new runner - displays a spinner while executing a func with a context derived from ctx
 func NewSpinnerRunner[T any](
+	ctx context.Context,
 	message string,
 	spinnerType spinner.Spinner,
 	color lipgloss.Color,
-	fn func() (T, error),
+	fn func(ctx context.Context) (T, error),
 ) SpinnerRunnerModel[T] {
+	handle := &spinnerHandle{}
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, spinnerKey{}, handle))
 	return SpinnerRunnerModel[T]{
 		spinner:  NewCustomSpinner(spinnerType, color),
 		message:  message,
 		function: fn,
+		ctx:      ctx,
+		cancel:   cancel,
+		handle:   handle,
 	}
 }
 
@@ -46,7 +78,7 @@ func (m SpinnerRunnerModel[T]) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick(),
 		func() tea.Msg {
-			result, err := m.function()
+			result, err := m.function(m.ctx)
 			if err != nil {
 				return errorMsg{err: err}
 			}
@@ -60,12 +92,17 @@ func (m SpinnerRunnerModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
+			m.cancel()
+			m.err = ErrCancelled
+			m.done = true
 			return m, tea.Quit
 		}
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
+	case messageMsg:
+		m.message = string(msg)
 	case errorMsg:
 		m.err = msg.err
 		m.done = true
@@ -83,13 +120,24 @@ func (m SpinnerRunnerModel[T]) View() string {
 	if m.done {
 		return ""
 	}
-	return fmt.Sprintf("%s %s", m.spinner.View(), styles.ProgressMessageStyle.Render(m.message))
+	return fmt.Sprintf("%s %s", m.spinner.View(), styles.ProgressMessageStyle.Render(output.Glyphs(m.message)))
 }
 
-// custom func that creates and runs a SpinnerRunnerModel
-func RunWithSpinner[T any](message string, spinnerType spinner.Spinner, color lipgloss.Color, fn func() (T, error)) (T, error) {
-	model := NewSpinnerRunner(message, spinnerType, color, fn)
+// for tests
+var spinnerEnabled = output.IsTerminal
+
+// custom func that creates and runs a SpinnerRunnerModel, or just runs fn when stdout isn't a terminal
+func RunWithSpinner[T any](ctx context.Context, message string, spinnerType spinner.Spinner, color lipgloss.Color, fn func(ctx context.Context) (T, error)) (T, error) {
+	if !spinnerEnabled() {
+		return fn(ctx)
+	}
+
+	model := NewSpinnerRunner(ctx, message, spinnerType, color, fn)
+	defer model.cancel()
+
 	p := tea.NewProgram(model)
+	// set before Run, so before fn starts
+	model.handle.program = p
 	finalModel, err := p.Run()
 	if err != nil {
 		var zero T
@@ -0,0 +1,51 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpinnerRunnerModel(t *testing.T) {
+	model := NewSpinnerRunner(context.Background(), "loading", WeatherEmojis, "#9ccfd8", func(ctx context.Context) (int, error) { return 0, nil })
+
+	updated, cmd := model.Update(successMsg[int]{result: 42})
+	m := updated.(SpinnerRunnerModel[int])
+	assert.NotNil(t, cmd, "a result should quit")
+	assert.Equal(t, 42, m.result)
+	assert.Empty(t, m.View())
+
+	updated, _ = model.Update(errorMsg{err: errors.New("boom")})
+	assert.EqualError(t, updated.(SpinnerRunnerModel[int]).err, "boom")
+}
+
+func TestSpinnerRunnerCtrlC(t *testing.T) {
+	model := NewSpinnerRunner(context.Background(), "loading", WeatherEmojis, "#9ccfd8", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m := updated.(SpinnerRunnerModel[int])
+	assert.NotNil(t, cmd)
+	assert.ErrorIs(t, m.err, ErrCancelled)
+	assert.ErrorIs(t, m.ctx.Err(), context.Canceled, "the running function should be told to stop")
+
+	// the function returns straight away now rather than hanging
+	_, err := m.function(m.ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSpinnerRunnerMessage(t *testing.T) {
+	model := NewSpinnerRunner(context.Background(), "loading", WeatherEmojis, "#9ccfd8", func(ctx context.Context) (int, error) { return 0, nil })
+
+	updated, _ := model.Update(messageMsg("loading (attempt 2 of 3)"))
+	assert.Contains(t, updated.View(), "attempt 2 of 3")
+
+	// nothing to update without a spinner, and nothing should block
+	SetSpinnerMessage(context.Background(), "ignored")
+	SetSpinnerMessage(model.ctx, "ignored before the program runs")
+}
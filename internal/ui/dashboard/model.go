@@ -0,0 +1,171 @@
+package dashboard
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/ui/components"
+	"github.com/josephburgess/gust/internal/ui/renderer"
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+type Tab int
+
+const (
+	TabCurrent Tab = iota
+	TabHourly
+	TabDaily
+	TabAlerts
+)
+
+var tabNames = []string{"Current", "Hourly", "Daily", "Alerts"}
+
+const (
+	// never poll faster than this, whatever the cache ttl says
+	minRefreshInterval = time.Minute
+	// backoff ceiling after rate limit errors
+	maxRefreshInterval = time.Hour
+	// pressing r again inside this window is ignored
+	manualRefreshCooldown = 30 * time.Second
+)
+
+// dashboard ui styles, rebuilt when the theme changes
+var (
+	titleStyle       lipgloss.Style
+	activeTabStyle   lipgloss.Style
+	inactiveTabStyle lipgloss.Style
+	panelStyle       lipgloss.Style
+	labelStyle       lipgloss.Style
+	valueStyle       lipgloss.Style
+	tempStyle        lipgloss.Style
+	bigTempStyle     lipgloss.Style
+	selectedRowStyle lipgloss.Style
+	cursorStyle      lipgloss.Style
+	alertStyle       lipgloss.Style
+	hintStyle        lipgloss.Style
+	errorStyle       lipgloss.Style
+)
+
+func init() {
+	styles.OnApply(func() {
+		titleStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Rose)
+		activeTabStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Base).Background(styles.Foam).Padding(0, 1)
+		inactiveTabStyle = lipgloss.NewStyle().Foreground(styles.Subtle).Padding(0, 1)
+		panelStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(styles.Iris).Padding(0, 1)
+		labelStyle = lipgloss.NewStyle().Foreground(styles.Subtle)
+		valueStyle = lipgloss.NewStyle().Foreground(styles.Text)
+		tempStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Gold)
+		bigTempStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Gold).Padding(0, 2, 0, 0)
+		selectedRowStyle = lipgloss.NewStyle().Foreground(styles.Foam).Bold(true)
+		cursorStyle = lipgloss.NewStyle().Foreground(styles.Love)
+		alertStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Love)
+		hintStyle = lipgloss.NewStyle().Foreground(styles.Subtle).Italic(true)
+		errorStyle = lipgloss.NewStyle().Foreground(styles.Love)
+	})
+}
+
+// loads weather for the dashboard's city, expected to go through the cache unless
+// refresh is set because the user asked for fresh data
+type FetchFunc func(refresh bool) (*api.WeatherResponse, error)
+
+// result of a fetch
+type weatherMsg struct {
+	weather *api.WeatherResponse
+	err     error
+}
+
+// generation drops ticks scheduled before a manual refresh
+type refreshTickMsg struct {
+	generation int
+}
+
+type Model struct {
+	Config          *config.Config
+	City            string
+	Weather         *api.WeatherResponse
+	Err             error
+	Tab             Tab
+	DayCursor       int
+	HourOffset      int
+	Width, Height   int
+	Loading         bool
+	Quitting        bool
+	LastUpdated     time.Time
+	RefreshInterval time.Duration
+	Spinner         components.SpinnerModel
+
+	baseInterval time.Duration
+	generation   int
+	units        renderer.BaseRenderer
+	fetch        FetchFunc
+	now          func() time.Time // for tests
+}
+
+func NewModel(cfg *config.Config, city string, fetch FetchFunc) Model {
+	interval := refreshInterval(cfg)
+	return Model{
+		Config:          cfg,
+		City:            city,
+		Loading:         true,
+		RefreshInterval: interval,
+		Spinner:         components.NewSpinner(),
+		baseInterval:    interval,
+		units:           renderer.BaseRenderer{Units: cfg.Units, Display: cfg.DisplayUnits()},
+		fetch:           fetch,
+		now:             time.Now,
+	}
+}
+
+// polls as often as the cache goes stale, so each refresh costs at most one request
+func refreshInterval(cfg *config.Config) time.Duration {
+	interval := cfg.CacheDuration()
+	if interval <= 0 {
+		interval = config.DefaultCacheTTL
+	}
+	return max(interval, minRefreshInterval)
+}
+
+// the dashboard opens on the tab matching the requested view
+func TabForView(view string) Tab {
+	switch view {
+	case "hourly", "graph":
+		return TabHourly
+	case "daily":
+		return TabDaily
+	case "alerts":
+		return TabAlerts
+	default:
+		return TabCurrent
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.Spinner.Tick(), m.fetchWeather(false))
+}
+
+func (m Model) fetchWeather(refresh bool) tea.Cmd {
+	fetch := m.fetch
+	return func() tea.Msg {
+		weather, err := fetch(refresh)
+		return weatherMsg{weather: weather, err: err}
+	}
+}
+
+func (m Model) scheduleRefresh() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(m.RefreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{generation: generation}
+	})
+}
+
+// full screen, restores the terminal on exit
+func Run(m Model) error {
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error running dashboard: %w", err)
+	}
+	return nil
+}
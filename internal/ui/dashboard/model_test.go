@@ -0,0 +1,89 @@
+package dashboard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testWeather() *api.WeatherResponse {
+	return &api.WeatherResponse{
+		City: &models.City{Name: "London", Country: "GB"},
+		Weather: &models.OneCallResponse{
+			Current: models.CurrentWeather{
+				Temp:      12.3,
+				FeelsLike: 10.1,
+				Humidity:  70,
+				Weather:   []models.WeatherCondition{{ID: 500, Description: "light rain"}},
+			},
+			Hourly: []models.HourData{
+				{Dt: 1700000000, Temp: 12},
+				{Dt: 1700003600, Temp: 13},
+				{Dt: 1700007200, Temp: 14},
+			},
+			Daily: []models.DayData{
+				{Dt: 1700000000, Summary: "Rain all day", Temp: models.TempData{Min: 8, Max: 13}},
+				{Dt: 1700086400, Summary: "Sunny spells", Temp: models.TempData{Min: 6, Max: 15}},
+			},
+			Alerts: []models.Alert{{SenderName: "Met Office", Event: "Yellow wind warning", Description: "Strong winds"}},
+		},
+	}
+}
+
+func newTestModel(fetch FetchFunc) Model {
+	if fetch == nil {
+		fetch = func(bool) (*api.WeatherResponse, error) { return testWeather(), nil }
+	}
+	m := NewModel(&config.Config{Units: "metric"}, "London", fetch)
+	m.now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return m
+}
+
+func TestNewModel(t *testing.T) {
+	m := newTestModel(nil)
+
+	assert.True(t, m.Loading, "the first fetch starts straight away")
+	assert.Equal(t, TabCurrent, m.Tab)
+	assert.Equal(t, config.DefaultCacheTTL, m.RefreshInterval)
+	assert.NotNil(t, m.Init())
+}
+
+func TestRefreshInterval(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cacheTTL int
+		expected time.Duration
+	}{
+		{"default ttl", 0, config.DefaultCacheTTL},
+		{"custom ttl", 30, 30 * time.Minute},
+		{"caching disabled", -1, config.DefaultCacheTTL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, refreshInterval(&config.Config{CacheTTL: tc.cacheTTL}))
+		})
+	}
+}
+
+func TestTabForView(t *testing.T) {
+	assert.Equal(t, TabHourly, TabForView("hourly"))
+	assert.Equal(t, TabDaily, TabForView("daily"))
+	assert.Equal(t, TabAlerts, TabForView("alerts"))
+	assert.Equal(t, TabCurrent, TabForView("compact"))
+	assert.Equal(t, TabCurrent, TabForView(""))
+}
+
+func TestFetchWeatherCmd(t *testing.T) {
+	m := newTestModel(func(bool) (*api.WeatherResponse, error) { return nil, errors.New("offline") })
+
+	msg := m.fetchWeather(false)()
+	result, ok := msg.(weatherMsg)
+	assert.True(t, ok)
+	assert.EqualError(t, result.err, "offline")
+}
@@ -0,0 +1,121 @@
+package dashboard
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/josephburgess/gust/internal/api"
+)
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+		return m, nil
+
+	case spinner.TickMsg:
+		// the spinner only runs while a fetch is in flight
+		if !m.Loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.Spinner, cmd = m.Spinner.Update(msg)
+		return m, cmd
+
+	case weatherMsg:
+		return m.handleWeather(msg)
+
+	case refreshTickMsg:
+		if msg.generation != m.generation || m.Loading {
+			return m, nil
+		}
+		return m.startRefresh(false)
+	}
+
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		m.Quitting = true
+		return m, tea.Quit
+
+	case "tab", "right", "l":
+		m.Tab = (m.Tab + 1) % Tab(len(tabNames))
+	case "shift+tab", "left", "h":
+		m.Tab = (m.Tab + Tab(len(tabNames)) - 1) % Tab(len(tabNames))
+	case "1", "2", "3", "4":
+		m.Tab = Tab(msg.String()[0] - '1')
+
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+
+	case "r":
+		if m.Loading || m.now().Sub(m.LastUpdated) < manualRefreshCooldown {
+			return m, nil
+		}
+		// like --refresh, the cache would only hand back what's on screen
+		return m.startRefresh(true)
+	}
+
+	return m, nil
+}
+
+// up/down picks a day on the daily tab and scrolls the hourly list
+func (m *Model) moveCursor(delta int) {
+	if m.Weather == nil || m.Weather.Weather == nil {
+		return
+	}
+
+	switch m.Tab {
+	case TabDaily:
+		m.DayCursor = clamp(m.DayCursor+delta, 0, len(m.Weather.Weather.Daily)-1)
+	case TabHourly:
+		m.HourOffset = clamp(m.HourOffset+delta, 0, len(m.Weather.Weather.Hourly)-1)
+	}
+}
+
+// manual refreshes skip the cache, timed ones only run once it has gone stale anyway
+func (m Model) startRefresh(manual bool) (tea.Model, tea.Cmd) {
+	m.Loading = true
+	m.generation++
+	return m, tea.Batch(m.Spinner.Tick(), m.fetchWeather(manual))
+}
+
+func (m Model) handleWeather(msg weatherMsg) (tea.Model, tea.Cmd) {
+	m.Loading = false
+
+	if msg.err != nil {
+		// keep showing the last good data
+		m.Err = msg.err
+		if errors.As(msg.err, new(*api.RateLimitError)) {
+			m.RefreshInterval = min(m.RefreshInterval*2, maxRefreshInterval)
+		}
+		return m, m.scheduleRefresh()
+	}
+
+	m.Weather = msg.weather
+	m.Err = nil
+	m.LastUpdated = m.now()
+	m.RefreshInterval = m.baseInterval
+	if m.Weather != nil && m.Weather.Weather != nil {
+		m.DayCursor = clamp(m.DayCursor, 0, len(m.Weather.Weather.Daily)-1)
+		m.HourOffset = clamp(m.HourOffset, 0, len(m.Weather.Weather.Hourly)-1)
+	}
+	return m, m.scheduleRefresh()
+}
+
+func clamp(value, lower, upper int) int {
+	if upper < lower {
+		return lower
+	}
+	return max(lower, min(value, upper))
+}
@@ -0,0 +1,169 @@
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/josephburgess/gust/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadedModel() Model {
+	m := newTestModel(nil)
+	updated, _ := m.Update(weatherMsg{weather: testWeather()})
+	return updated.(Model)
+}
+
+func press(m Model, key string) (Model, tea.Cmd) {
+	var msg tea.KeyMsg
+	switch key {
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		msg = tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func TestWeatherLoaded(t *testing.T) {
+	m := loadedModel()
+
+	assert.False(t, m.Loading)
+	assert.NoError(t, m.Err)
+	assert.Equal(t, "London", m.Weather.City.Name)
+	assert.Equal(t, m.now(), m.LastUpdated)
+}
+
+func TestTabNavigation(t *testing.T) {
+	m := loadedModel()
+
+	m, _ = press(m, "tab")
+	assert.Equal(t, TabHourly, m.Tab)
+
+	m, _ = press(m, "shift+tab")
+	m, _ = press(m, "shift+tab")
+	assert.Equal(t, TabAlerts, m.Tab, "tabs should wrap around")
+
+	m, _ = press(m, "3")
+	assert.Equal(t, TabDaily, m.Tab)
+}
+
+func TestDayCursor(t *testing.T) {
+	m := loadedModel()
+	m.Tab = TabDaily
+
+	m, _ = press(m, "down")
+	assert.Equal(t, 1, m.DayCursor)
+
+	m, _ = press(m, "down")
+	assert.Equal(t, 1, m.DayCursor, "cursor should stop at the last day")
+
+	m, _ = press(m, "k")
+	m, _ = press(m, "k")
+	assert.Equal(t, 0, m.DayCursor)
+}
+
+func TestHourlyScroll(t *testing.T) {
+	m := loadedModel()
+	m.Tab = TabHourly
+
+	m, _ = press(m, "j")
+	assert.Equal(t, 1, m.HourOffset)
+	assert.Equal(t, 0, m.DayCursor, "scrolling hours shouldn't move the day cursor")
+}
+
+func TestManualRefresh(t *testing.T) {
+	m := loadedModel()
+
+	m, cmd := press(m, "r")
+	assert.Nil(t, cmd, "refreshing straight after a fetch should be ignored")
+	assert.False(t, m.Loading)
+
+	m.LastUpdated = m.now().Add(-time.Minute)
+	generation := m.generation
+	m, cmd = press(m, "r")
+	assert.NotNil(t, cmd)
+	assert.True(t, m.Loading)
+	assert.Equal(t, generation+1, m.generation)
+}
+
+func TestManualRefreshSkipsCache(t *testing.T) {
+	var refreshes []bool
+	m := newTestModel(func(refresh bool) (*api.WeatherResponse, error) {
+		refreshes = append(refreshes, refresh)
+		return testWeather(), nil
+	})
+	updated, _ := m.Update(weatherMsg{weather: testWeather()})
+	m = updated.(Model)
+	m.LastUpdated = m.now().Add(-time.Minute)
+
+	_, manual := press(m, "r")
+	_, timed := m.Update(refreshTickMsg{generation: m.generation})
+	for _, cmd := range []tea.Cmd{manual, timed} {
+		batch, ok := cmd().(tea.BatchMsg)
+		require.True(t, ok)
+		for _, c := range batch {
+			c()
+		}
+	}
+
+	assert.Equal(t, []bool{true, false}, refreshes, "only pressing r skips the cache")
+}
+
+func TestRefreshTick(t *testing.T) {
+	m := loadedModel()
+
+	updated, cmd := m.Update(refreshTickMsg{generation: m.generation - 1})
+	assert.Nil(t, cmd, "ticks from before a manual refresh should be dropped")
+	assert.False(t, updated.(Model).Loading)
+
+	updated, cmd = m.Update(refreshTickMsg{generation: m.generation})
+	assert.NotNil(t, cmd)
+	assert.True(t, updated.(Model).Loading)
+}
+
+func TestFailedRefreshKeepsData(t *testing.T) {
+	m := loadedModel()
+	m.Loading = true
+
+	updated, cmd := m.Update(weatherMsg{err: fmt.Errorf("failed to get weather data: %w", &api.RateLimitError{})})
+	m = updated.(Model)
+
+	assert.NotNil(t, cmd, "another refresh should still be scheduled")
+	assert.Error(t, m.Err)
+	assert.NotNil(t, m.Weather, "the last good data should stay on screen")
+	assert.Equal(t, 2*m.baseInterval, m.RefreshInterval, "rate limits should back off")
+
+	updated, _ = m.Update(weatherMsg{weather: testWeather()})
+	assert.Equal(t, m.baseInterval, updated.(Model).RefreshInterval)
+
+	updated, _ = updated.(Model).Update(weatherMsg{err: errors.New("rate limit exceeded")})
+	assert.Equal(t, m.baseInterval, updated.(Model).RefreshInterval, "only typed rate limit errors back off")
+}
+
+func TestQuit(t *testing.T) {
+	m, cmd := press(loadedModel(), "q")
+	assert.True(t, m.Quitting)
+	assert.NotNil(t, cmd)
+	assert.Empty(t, m.View())
+}
+
+func TestWindowResize(t *testing.T) {
+	updated, _ := loadedModel().Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m := updated.(Model)
+	assert.Equal(t, 120, m.Width)
+	assert.Equal(t, 40, m.Height)
+}
@@ -0,0 +1,336 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/renderer"
+)
+
+// used until the first WindowSizeMsg arrives
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+	// below this the current and daily tabs stack their columns
+	wideLayoutWidth = 70
+)
+
+func (m Model) View() string {
+	if m.Quitting {
+		return ""
+	}
+
+	width, height := m.size()
+	bodyWidth := width - panelStyle.GetHorizontalFrameSize()
+	// header, tabs and footer take a line each
+	bodyHeight := height - 3 - panelStyle.GetVerticalFrameSize()
+
+	var body string
+	switch {
+	case m.Weather == nil && m.Err != nil:
+		body = errorStyle.Render(i18n.T("Couldn't load weather for %s: %v", m.City, m.Err))
+	case m.Weather == nil || m.Weather.Weather == nil:
+		body = m.Spinner.View() + " " + i18n.T("Fetching weather for %s...", m.City)
+	default:
+		body = m.renderTab(bodyWidth, bodyHeight)
+	}
+
+	panel := panelStyle.
+		Width(bodyWidth + panelStyle.GetHorizontalPadding()).
+		Height(bodyHeight).
+		Render(clip(body, bodyHeight))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.renderHeader(width),
+		m.renderTabs(),
+		panel,
+		m.renderFooter(width),
+	)
+}
+
+func (m Model) size() (int, int) {
+	width, height := m.Width, m.Height
+	if width <= 0 {
+		width = defaultWidth
+	}
+	if height <= 0 {
+		height = defaultHeight
+	}
+	return width, height
+}
+
+func (m Model) locationName() string {
+	if m.Weather == nil || m.Weather.City == nil {
+		return m.City
+	}
+	city := m.Weather.City
+	if city.Country != "" {
+		return fmt.Sprintf("%s, %s", city.Name, city.Country)
+	}
+	return city.Name
+}
+
+func (m Model) renderHeader(width int) string {
+	title := titleStyle.Render("gust") + " " + valueStyle.Render(m.locationName())
+
+	var status string
+	switch {
+	case m.Loading && m.Weather != nil:
+		status = m.Spinner.View() + " " + i18n.T("refreshing")
+	case !m.LastUpdated.IsZero():
+		next := m.LastUpdated.Add(m.RefreshInterval)
+		status = i18n.T("updated %s · next %s", m.LastUpdated.Format("15:04"), next.Format("15:04"))
+	}
+	status = hintStyle.Render(status)
+
+	gap := max(width-lipgloss.Width(title)-lipgloss.Width(status), 1)
+	return title + strings.Repeat(" ", gap) + status
+}
+
+func (m Model) renderTabs() string {
+	tabs := make([]string, len(tabNames))
+	for i, name := range tabNames {
+		label := fmt.Sprintf("%d %s", i+1, i18n.T(name))
+		if Tab(i) == TabAlerts && m.Weather != nil && m.Weather.Weather != nil && len(m.Weather.Weather.Alerts) > 0 {
+			label = fmt.Sprintf("%s (%d)", label, len(m.Weather.Weather.Alerts))
+		}
+		if Tab(i) == m.Tab {
+			tabs[i] = activeTabStyle.Render(label)
+		} else {
+			tabs[i] = inactiveTabStyle.Render(label)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+func (m Model) renderFooter(width int) string {
+	hint := i18n.T("←/→ tabs • ↑/↓ select • r refresh • q quit")
+	if m.Err != nil && m.Weather != nil {
+		return lipgloss.NewStyle().MaxWidth(width).Render(errorStyle.Render(i18n.T("⚠️ refresh failed: %v", m.Err)) + "  " + hintStyle.Render(hint))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(hintStyle.Render(hint))
+}
+
+func (m Model) renderTab(width, height int) string {
+	switch m.Tab {
+	case TabHourly:
+		return m.renderHourly(width, height)
+	case TabDaily:
+		return m.renderDaily(width)
+	case TabAlerts:
+		return m.renderAlerts(width)
+	default:
+		return m.renderCurrent(width)
+	}
+}
+
+func (m Model) renderCurrent(width int) string {
+	weather := m.Weather.Weather
+	current := weather.Current
+
+	emoji, description := "", ""
+	if len(current.Weather) > 0 {
+		emoji = models.GetWeatherEmoji(current.Weather[0].ID, &current)
+		description = current.Weather[0].Description
+	}
+
+	summary := lipgloss.JoinVertical(lipgloss.Left,
+		bigTempStyle.Render(fmt.Sprintf("%s %s", emoji, m.temp(current.Temp))),
+		valueStyle.Render(description),
+		labelStyle.Render(i18n.T("Feels like")+" ")+tempStyle.Render(m.temp(current.FeelsLike)),
+	)
+
+	details := renderPairs([][2]string{
+		{i18n.T("Humidity"), fmt.Sprintf("%d%%", current.Humidity)},
+		{i18n.T("Wind"), m.wind(current.WindSpeed, current.WindDeg)},
+		{i18n.T("Gusts"), m.units.FormatWind(current.WindGust)},
+		{i18n.T("UV index"), fmt.Sprintf("%.1f", current.UVI)},
+		{i18n.T("Pressure"), m.units.FormatPressure(current.Pressure)},
+		{i18n.T("Visibility"), m.units.FormatVisibility(current.Visibility)},
+		{i18n.T("Clouds"), fmt.Sprintf("%d%%", current.Clouds)},
+		{i18n.T("Sunrise"), m.clock(current.Sunrise, "15:04") + " " + m.zoneLabel(current.Sunrise)},
+		{i18n.T("Sunset"), m.clock(current.Sunset, "15:04") + " " + m.zoneLabel(current.Sunset)},
+	})
+
+	var top string
+	if width >= wideLayoutWidth {
+		top = lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(width/2).Render(summary), details)
+	} else {
+		top = lipgloss.JoinVertical(lipgloss.Left, summary, "", details)
+	}
+
+	sections := []string{top}
+	if len(weather.Daily) > 0 && weather.Daily[0].Summary != "" {
+		sections = append(sections, "", lipgloss.NewStyle().Width(width).Render(valueStyle.Render(weather.Daily[0].Summary)))
+	}
+	if len(weather.Alerts) > 0 {
+		sections = append(sections, "", alertStyle.Render(fmt.Sprintf("⚠️ %d weather alerts, see the Alerts tab", len(weather.Alerts))))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+func (m Model) renderHourly(width, height int) string {
+	hourly := m.Weather.Weather.Hourly
+	if len(hourly) == 0 {
+		return hintStyle.Render(i18n.T("No hourly forecast available"))
+	}
+
+	var rows []string
+	for _, hour := range hourly[m.HourOffset:] {
+		if len(rows) == height {
+			break
+		}
+
+		emoji := ""
+		if len(hour.Weather) > 0 {
+			emoji = models.GetWeatherEmoji(hour.Weather[0].ID, nil)
+		}
+
+		row := fmt.Sprintf("%s  %s  %s",
+			labelStyle.Render(m.clock(hour.Dt, "Mon 15:04")),
+			emoji,
+			tempStyle.Render(fmt.Sprintf("%-8s", m.temp(hour.Temp))))
+		if width >= wideLayoutWidth {
+			row += labelStyle.Render(fmt.Sprintf("  %s %-8s", i18n.T("feels"), m.temp(hour.FeelsLike)))
+		}
+		row += fmt.Sprintf("  💧 %3.0f%%  💨 %s", hour.Pop*100, m.wind(hour.WindSpeed, hour.WindDeg))
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m Model) renderDaily(width int) string {
+	daily := m.Weather.Weather.Daily
+	if len(daily) == 0 {
+		return hintStyle.Render(i18n.T("No daily forecast available"))
+	}
+
+	rows := make([]string, len(daily))
+	for i, day := range daily {
+		emoji := ""
+		if len(day.Weather) > 0 {
+			emoji = models.GetWeatherEmoji(day.Weather[0].ID, nil)
+		}
+
+		row := fmt.Sprintf("%s  %s  %s / %s",
+			m.clock(day.Dt, "Mon 02 Jan"),
+			emoji,
+			m.temp(day.Temp.Min),
+			m.temp(day.Temp.Max))
+		if i == m.DayCursor {
+			rows[i] = cursorStyle.Render("→ ") + selectedRowStyle.Render(row)
+		} else {
+			rows[i] = "  " + row
+		}
+	}
+	list := strings.Join(rows, "\n")
+
+	if width >= wideLayoutWidth {
+		listWidth := lipgloss.Width(list) + 4
+		return lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(listWidth).Render(list), m.renderDayDetail(daily[m.DayCursor], width-listWidth))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, list, "", m.renderDayDetail(daily[m.DayCursor], width))
+}
+
+func (m Model) renderDayDetail(day models.DayData, width int) string {
+	pairs := [][2]string{
+		{i18n.T("Morning"), m.temp(day.Temp.Morn)},
+		{i18n.T("Day"), m.temp(day.Temp.Day)},
+		{i18n.T("Evening"), m.temp(day.Temp.Eve)},
+		{i18n.T("Night"), m.temp(day.Temp.Night)},
+		{i18n.T("Humidity"), fmt.Sprintf("%d%%", day.Humidity)},
+		{i18n.T("Wind"), m.wind(day.WindSpeed, day.WindDeg)},
+		{i18n.T("UV index"), fmt.Sprintf("%.1f", day.UVI)},
+		{i18n.T("Rain chance"), fmt.Sprintf("%.0f%%", day.Pop*100)},
+	}
+	if day.Rain > 0 {
+		pairs = append(pairs, [2]string{i18n.T("Rain"), m.units.FormatPrecipitation(day.Rain)})
+	}
+	if day.Snow > 0 {
+		pairs = append(pairs, [2]string{i18n.T("Snow"), m.units.FormatPrecipitation(day.Snow)})
+	}
+	pairs = append(pairs,
+		[2]string{i18n.T("Sunrise"), m.clock(day.Sunrise, "15:04")},
+		[2]string{i18n.T("Sunset"), m.clock(day.Sunset, "15:04")},
+	)
+
+	sections := []string{titleStyle.Render(m.clock(day.Dt, "Monday 02 January"))}
+	if day.Summary != "" {
+		sections = append(sections, lipgloss.NewStyle().Width(width).Render(valueStyle.Render(day.Summary)))
+	}
+	sections = append(sections, "", renderPairs(pairs))
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+func (m Model) renderAlerts(width int) string {
+	alerts := m.Weather.Weather.Alerts
+	if len(alerts) == 0 {
+		return valueStyle.Render(i18n.T("No weather alerts for this area 🎉"))
+	}
+
+	wrap := lipgloss.NewStyle().Width(width)
+	var sections []string
+	for i, alert := range alerts {
+		if i > 0 {
+			sections = append(sections, "")
+		}
+		sections = append(sections,
+			alertStyle.Render("⚠️ "+alert.Event),
+			labelStyle.Render(i18n.T("%s · %s until %s %s",
+				alert.SenderName,
+				m.clock(alert.Start, "Mon 15:04"),
+				m.clock(alert.End, "Mon 15:04"),
+				m.zoneLabel(alert.End))),
+			wrap.Render(valueStyle.Render(strings.TrimSpace(alert.Description))),
+		)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// timestamps read in the forecast location's zone unless local time was asked for
+func (m Model) clock(timestamp int64, layout string) string {
+	return i18n.FormatTime(time.Unix(timestamp, 0).In(renderer.DisplayZone(m.Weather.Weather, m.Config)), layout)
+}
+
+func (m Model) zoneLabel(timestamp int64) string {
+	return renderer.ZoneLabel(time.Unix(timestamp, 0).In(renderer.DisplayZone(m.Weather.Weather, m.Config)))
+}
+
+func (m Model) temp(value float64) string {
+	return m.units.FormatTemperature(value)
+}
+
+func (m Model) wind(speed float64, deg int) string {
+	return m.units.FormatWind(speed) + " " + models.GetWindDirection(deg)
+}
+
+// label/value rows with the values lined up
+func renderPairs(pairs [][2]string) string {
+	labelWidth := 0
+	for _, pair := range pairs {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(pair[0]))
+	}
+
+	rows := make([]string, len(pairs))
+	for i, pair := range pairs {
+		rows[i] = labelStyle.Render(fmt.Sprintf("%-*s", labelWidth, pair[0])) + "  " + valueStyle.Render(pair[1])
+	}
+	return strings.Join(rows, "\n")
+}
+
+// keeps the panel from growing past the window
+func clip(content string, height int) string {
+	if height <= 0 {
+		return ""
+	}
+	lines := strings.Split(content, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,77 @@
+package dashboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewTabs(t *testing.T) {
+	testCases := []struct {
+		tab           Tab
+		expectedParts []string
+	}{
+		{TabCurrent, []string{"12.3°C", "light rain", "Feels like", "Humidity", "Rain all day", "1 weather alerts"}},
+		{TabHourly, []string{"12.0°C", "13.0°C", "14.0°C"}},
+		{TabDaily, []string{"8.0°C / 13.0°C", "6.0°C / 15.0°C", "Rain all day", "Morning"}},
+		{TabAlerts, []string{"Yellow wind warning", "Met Office", "Strong winds"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tabNames[tc.tab], func(t *testing.T) {
+			m := loadedModel()
+			m.Tab = tc.tab
+
+			view := m.View()
+			assert.Contains(t, view, "London, GB")
+			assert.Contains(t, view, "4 Alerts (1)")
+			for _, part := range tc.expectedParts {
+				assert.Contains(t, view, part)
+			}
+		})
+	}
+}
+
+func TestViewSelectedDay(t *testing.T) {
+	m := loadedModel()
+	m.Tab = TabDaily
+	m.DayCursor = 1
+
+	view := m.View()
+	assert.Contains(t, view, "Sunny spells")
+	assert.NotContains(t, view, "Rain all day")
+}
+
+func TestViewFitsWindow(t *testing.T) {
+	for _, size := range [][2]int{{120, 40}, {60, 20}, {80, 10}} {
+		m := loadedModel()
+		m.Width, m.Height = size[0], size[1]
+
+		for tab := range tabNames {
+			m.Tab = Tab(tab)
+			view := m.View()
+
+			assert.LessOrEqual(t, lipgloss.Height(view), size[1], "%s tab too tall for %v", tabNames[tab], size)
+			for _, line := range strings.Split(view, "\n") {
+				assert.LessOrEqual(t, lipgloss.Width(line), size[0], "%s tab too wide for %v", tabNames[tab], size)
+			}
+		}
+	}
+}
+
+func TestViewLoadingAndErrors(t *testing.T) {
+	m := newTestModel(nil)
+	assert.Contains(t, m.View(), "Fetching weather for London")
+
+	updated, _ := m.Update(weatherMsg{err: errors.New("city not found")})
+	assert.Contains(t, updated.(Model).View(), "city not found")
+
+	failed := loadedModel()
+	updated, _ = failed.Update(weatherMsg{err: errors.New("timeout")})
+	view := updated.(Model).View()
+	assert.Contains(t, view, "refresh failed")
+	assert.Contains(t, view, "12.3°C", "stale data should still be shown")
+}
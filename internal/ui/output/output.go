@@ -2,33 +2,52 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/redact"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
 func PrintError(message string) {
-	fmt.Println(styles.ErrorStyle("❌ " + message))
+	Println(styles.ErrorStyle("❌ " + redact.String(message)))
 }
 
 func PrintSuccess(message string) {
-	fmt.Println(styles.SuccessStyle("✅ " + message))
+	Println(styles.SuccessStyle("✅ " + message))
 }
 
 func PrintInfo(message string) {
-	fmt.Println(styles.InfoStyle(message))
+	Println(styles.InfoStyle(message))
 }
 
 func PrintWarning(message string) {
-	fmt.Println(styles.WarningStyle("⚠️ " + message))
+	Println(styles.WarningStyle("⚠️ " + message))
+}
+
+// for warnings that mustn't end up in piped output, e.g. json
+func PrintStderrWarning(message string) {
+	fmt.Fprintln(os.Stderr, Glyphs(styles.WarningStyle("⚠️ "+message)))
+}
+
+// for tests
+var debug = os.Getenv("GUST_DEBUG") != ""
+
+// only shown with GUST_DEBUG set, for failures gust quietly works around
+func PrintDebug(message string) {
+	if debug {
+		fmt.Fprintln(os.Stderr, "debug: "+redact.String(message))
+	}
 }
 
 func PrintHeader(title string) {
-	fmt.Printf("\n%s\n%s\n", styles.HeaderStyle(title), styles.Divider(len(title)*2))
+	Printf("\n%s\n%s\n", styles.HeaderStyle(title), styles.Divider(len(title)*2))
 }
 
 func PrintBoxedMessage(message string) {
-	fmt.Println(styles.BoxStyle.Render(message))
+	// swapped before drawing, the box is sized to fit the emoji
+	Println(styles.BoxStyle.Render(Glyphs(message)))
 }
 
 func PrintRateLimitWarning(remaining, limit int, resetTime time.Time) {
@@ -36,17 +55,13 @@ func PrintRateLimitWarning(remaining, limit int, resetTime time.Time) {
 	minutesUntilReset := int(timeUntilReset.Minutes())
 	resetFormatted := resetTime.Format("15:04")
 
-	fmt.Println()
-	fmt.Println(styles.BoxStyle.Render(fmt.Sprintf(
-		"⚠️ API Rate Limit Warning\n\n"+
-			"You have %s requests remaining out of %d.\n"+
-			"Your rate limit will reset at %s (%d minutes from now).",
-		styles.HighlightStyleF(fmt.Sprintf("%d", remaining)),
-		limit,
-		styles.TimeStyle(resetFormatted),
-		minutesUntilReset,
+	Println()
+	Println(styles.BoxStyle.Render(Glyphs(
+		i18n.T("⚠️ API Rate Limit Warning") + "\n\n" +
+			i18n.T("You have %s requests remaining out of %d.", styles.HighlightStyleF(fmt.Sprintf("%d", remaining)), limit) + "\n" +
+			i18n.T("Your rate limit will reset at %s (%d minutes from now).", styles.TimeStyle(resetFormatted), minutesUntilReset),
 	)))
-	fmt.Println()
+	Println()
 }
 
 func PrintRateLimitError(limit int, resetTime time.Time) {
@@ -54,18 +69,32 @@ func PrintRateLimitError(limit int, resetTime time.Time) {
 	minutesUntilReset := int(timeUntilReset.Minutes())
 	resetFormatted := resetTime.Format("15:04")
 
-	fmt.Println()
-	fmt.Println(styles.BoxStyle.BorderForeground(styles.Love).Render(fmt.Sprintf(
-		"❌ API Rate Limit Reached\n\n"+
-			"Sorry - you have used all %d available requests.\n"+
-			"You must really like checking the weather!!\n"+
-			"Your rate limit will reset at %s (%d minutes from now).\n\n"+
-			"💡 If you think the limits are too low please get in touch :)",
-		limit,
-		styles.TimeStyle(resetFormatted),
-		minutesUntilReset,
+	Println()
+	Println(styles.BoxStyle.BorderForeground(styles.Love).Render(Glyphs(
+		i18n.T("❌ API Rate Limit Reached") + "\n\n" +
+			i18n.T("Sorry - you have used all %d available requests.", limit) + "\n" +
+			i18n.T("You must really like checking the weather!!") + "\n" +
+			i18n.T("Your rate limit will reset at %s (%d minutes from now).", styles.TimeStyle(resetFormatted), minutesUntilReset) + "\n\n" +
+			i18n.T("💡 If you think the limits are too low please get in touch :)"),
 	)))
-	fmt.Println()
+	Println()
+}
+
+func PrintStaleDataWarning(fetchedAt time.Time) {
+	age := time.Since(fetchedAt)
+
+	var ago string
+	switch {
+	case age < time.Hour:
+		ago = i18n.T("%d minute(s) ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		ago = i18n.T("%d hour(s) ago", int(age.Hours()))
+	default:
+		ago = i18n.T("%d day(s) ago", int(age.Hours()/24))
+	}
+
+	PrintWarning(i18n.T("Offline: showing cached weather from %s (%s)",
+		i18n.FormatTime(fetchedAt, "Mon Jan 2 15:04"), ago))
 }
 
 // going to implement this later - will create an api key status check endpoint
@@ -95,6 +124,6 @@ func PrintRateLimitStatus(remaining, limit int) {
 		usageText = styles.InfoStyle(fmt.Sprintf("%.0f%% used", percentage))
 	}
 
-	fmt.Printf("API Usage: [%s%s] %s (%d/%d)\n", filled, empty, usageText, used, limit)
+	Printf("API Usage: [%s%s] %s (%d/%d)\n", filled, empty, usageText, used, limit)
 }
 */
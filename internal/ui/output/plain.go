@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+var plain bool
+
+// SetPlain swaps emoji for ASCII in everything printed through this package from now on
+func SetPlain(enabled bool) {
+	plain = enabled
+}
+
+func IsPlain() bool {
+	return plain
+}
+
+// the emoji that carry meaning, anything else is dropped
+var asciiGlyphs = strings.NewReplacer(
+	"⚠️", "!",
+	"⚠", "!",
+	"❌", "x",
+	"✗", "x",
+	"✅", "+",
+	"✓", "+",
+	"💡", "*",
+	"→", "->",
+	"←", "<-",
+	"↑", "^",
+	"↓", "v",
+)
+
+// Glyphs returns s with its emoji replaced by ASCII in plain mode, and unchanged otherwise
+func Glyphs(s string) string {
+	if !plain {
+		return s
+	}
+
+	runes := []rune(asciiGlyphs.Replace(s))
+	var sb strings.Builder
+	for i := 0; i < len(runes); i++ {
+		if !isEmoji(runes[i]) {
+			sb.WriteRune(runes[i])
+			continue
+		}
+
+		// take a space with it so "Rain 🌧️" doesn't leave "Rain "
+		for i+1 < len(runes) && isEmoji(runes[i+1]) {
+			i++
+		}
+		text := sb.String()
+		if strings.HasSuffix(text, " ") {
+			sb.Reset()
+			sb.WriteString(strings.TrimSuffix(text, " "))
+		} else if i+1 < len(runes) && runes[i+1] == ' ' {
+			i++
+		}
+	}
+	return sb.String()
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons and flags
+		r >= 0x2600 && r <= 0x27BF, // symbols like ☀ and dingbats
+		r >= 0x2300 && r <= 0x23FF, // ⏱
+		r >= 0x2B00 && r <= 0x2BFF,
+		r == 0x200D, // joins emoji into one
+		unicode.Is(unicode.Variation_Selector, r):
+		return true
+	}
+	return false
+}
+
+// Printf, Println and Print write to stdout through Glyphs
+func Printf(format string, a ...any) {
+	fmt.Fprint(os.Stdout, Glyphs(fmt.Sprintf(format, a...)))
+}
+
+func Println(a ...any) {
+	fmt.Fprint(os.Stdout, Glyphs(fmt.Sprintln(a...)))
+}
+
+func Print(a ...any) {
+	fmt.Fprint(os.Stdout, Glyphs(fmt.Sprint(a...)))
+}
@@ -0,0 +1,34 @@
+package output
+
+import "testing"
+
+func TestGlyphs(t *testing.T) {
+	testCases := []struct {
+		in, expected string
+	}{
+		{"⚠️ 2 alerts", "! 2 alerts"},
+		{"❌ API Rate Limit Reached", "x API Rate Limit Reached"},
+		{"💡 Bring an umbrella", "* Bring an umbrella"},
+		{"Humidity: 80% 💧", "Humidity: 80%"},
+		{"☔ Rain starting in 5 min", "Rain starting in 5 min"},
+		{"Clouds ☁️ and wind 🌬️ today", "Clouds and wind today"},
+		{"Springfield - Illinois, US 🇺🇸", "Springfield - Illinois, US"},
+		{"→ Mon 5", "-> Mon 5"},
+		{"Temperature: 12°C", "Temperature: 12°C"},
+	}
+
+	SetPlain(true)
+	t.Cleanup(func() { SetPlain(false) })
+
+	for _, tc := range testCases {
+		if got := Glyphs(tc.in); got != tc.expected {
+			t.Errorf("Glyphs(%q) = %q, expected %q", tc.in, got, tc.expected)
+		}
+	}
+}
+
+func TestGlyphsOnlyInPlainMode(t *testing.T) {
+	if got := Glyphs("⚠️ 2 alerts"); got != "⚠️ 2 alerts" {
+		t.Errorf("Expected emoji to be kept outside plain mode, got %q", got)
+	}
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// true when a person is at the keyboard, so prompts and pickers make sense
+func IsInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// true when stdout is a terminal, so there's somewhere to draw a spinner
+func IsTerminal() bool {
+	return isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
@@ -6,30 +6,34 @@ import (
 	"time"
 
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
 func (r *TerminalRenderer) RenderAlerts(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
-	fmt.Print(styles.FormatHeader(fmt.Sprintf("WEATHER ALERTS FOR %s", strings.ToUpper(city.Name))))
+	output.Print(styles.FormatHeader(i18n.T("WEATHER ALERTS FOR %s", strings.ToUpper(city.Name))))
 
 	if len(weather.Alerts) == 0 {
-		fmt.Println("No weather alerts for this area.")
+		output.Println(i18n.T("No weather alerts for this area."))
 		return
 	}
 
+	loc := DisplayZone(weather, cfg)
 	for i, alert := range weather.Alerts {
 		if i > 0 {
-			fmt.Println(styles.Divider(30))
+			output.Println(styles.Divider(30))
 		}
 
-		fmt.Printf("%s\n", styles.AlertStyle(fmt.Sprintf("⚠️  %s", alert.Event)))
-		fmt.Printf("Issued by: %s\n", alert.SenderName)
-		fmt.Printf("Valid: %s to %s\n\n",
-			styles.TimeStyle(time.Unix(alert.Start, 0).Format("Mon Jan 2 15:04")),
-			styles.TimeStyle(time.Unix(alert.End, 0).Format("Mon Jan 2 15:04")))
+		output.Printf("%s\n", styles.AlertStyle(fmt.Sprintf("⚠️  %s", alert.Event)))
+		output.Println(i18n.T("Issued by: %s", alert.SenderName))
+		end := time.Unix(alert.End, 0).In(loc)
+		output.Printf(i18n.T("Valid: %s to %s")+"\n\n",
+			styles.TimeStyle(i18n.FormatTime(time.Unix(alert.Start, 0).In(loc), "Mon Jan 2 15:04")),
+			styles.TimeStyle(i18n.FormatTime(end, "Mon Jan 2 15:04")+" "+ZoneLabel(end)))
 
-		fmt.Println(alert.Description)
-		fmt.Println()
+		output.Println(alert.Description)
+		output.Println()
 	}
 }
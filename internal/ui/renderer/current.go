@@ -1,55 +1,57 @@
 package renderer
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
 func (r *TerminalRenderer) RenderCurrentWeather(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
 	current := weather.Current
 
-	fmt.Print(styles.FormatHeader(fmt.Sprintf("WEATHER FOR %s", strings.ToUpper(city.Name))))
+	output.Print(styles.FormatHeader(i18n.T("WEATHER FOR %s", strings.ToUpper(city.Name))))
 
 	if len(current.Weather) > 0 {
 		weatherCond := current.Weather[0]
 
-		fmt.Printf("Current Conditions: %s %s\n\n",
+		output.Printf(i18n.T("Current Conditions: %s %s")+"\n\n",
 			styles.HighlightStyleF(weatherCond.Description),
 			models.GetWeatherEmoji(weatherCond.ID, &current))
 
-		tempUnit := r.GetTemperatureUnit()
-
-		fmt.Printf("Temperature: %s %s (F/L: %.1f%s)\n",
-			styles.TempStyle(fmt.Sprintf("%.1f%s", current.Temp, tempUnit)),
+		output.Printf(i18n.T("Temperature: %s %s (F/L: %s)")+"\n",
+			styles.TempStyle(r.FormatTemperature(current.Temp)),
 			"🌡️",
-			current.FeelsLike, tempUnit)
+			r.FormatTemperature(current.FeelsLike))
 
-		fmt.Printf("Humidity: %d%% %s\n", current.Humidity, "💧")
+		output.Println(i18n.T("Humidity: %d%% %s", current.Humidity, "💧"))
 		if current.UVI > 0 {
-			fmt.Printf("UV Index: %.1f ☀️\n", current.UVI)
+			output.Println(i18n.T("UV Index: %.1f ☀️", current.UVI))
 		}
 
 		r.displayWindInfo(current.WindSpeed, current.WindDeg, current.WindGust)
 
 		if current.Clouds > 0 {
-			fmt.Printf("Cloud coverage: %d%% ☁️\n", current.Clouds)
+			output.Println(i18n.T("Cloud coverage: %d%% ☁️", current.Clouds))
 		}
 
 		r.displayPrecipitation(current.Rain, current.Snow)
-		fmt.Printf("Visibility: %s\n", models.VisibilityToString(current.Visibility))
+		output.Println(i18n.T("Visibility: %s", r.DescribeVisibility(current.Visibility)))
 
-		fmt.Printf("Sunrise: %s %s  Sunset: %s %s\n",
-			time.Unix(current.Sunrise, 0).Format("15:04"),
+		loc := DisplayZone(weather, cfg)
+		sunset := time.Unix(current.Sunset, 0).In(loc)
+		output.Printf(i18n.T("Sunrise: %s %s  Sunset: %s %s  (%s)")+"\n",
+			time.Unix(current.Sunrise, 0).In(loc).Format("15:04"),
 			"🌅",
-			time.Unix(current.Sunset, 0).Format("15:04"),
-			"🌇")
-		r.displayWeatherTip(weather, cfg)
-		fmt.Printf("\n")
+			sunset.Format("15:04"),
+			"🌇",
+			ZoneLabel(sunset))
+		r.displayWeatherTips(weather, cfg, cfg.TipLimit())
+		output.Printf("\n")
 	}
 	r.displayAlertSummary(weather.Alerts, city.Name)
 }
@@ -6,70 +6,68 @@ import (
 	"time"
 
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
 func (r *TerminalRenderer) RenderDailyForecast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
-	fmt.Print(styles.FormatHeader(fmt.Sprintf("5-DAY FORECAST FOR %s", strings.ToUpper(city.Name))))
+	output.Print(styles.FormatHeader(i18n.T("5-DAY FORECAST FOR %s", strings.ToUpper(city.Name))))
 
 	if len(weather.Daily) > 0 {
-		tempUnit := r.GetTemperatureUnit()
+		loc := DisplayZone(weather, cfg)
 
 		for i, day := range weather.Daily {
 			if i >= 5 {
 				break
 			}
 
-			date := time.Unix(day.Dt, 0).Format("Mon Jan 2")
+			date := i18n.FormatTime(time.Unix(day.Dt, 0).In(loc), "Mon Jan 2")
 
 			if i > 0 {
-				fmt.Println()
+				output.Println()
 			}
 
-			fmt.Printf("%s: %s\n",
+			output.Printf("%s: %s\n",
 				styles.HighlightStyleF(date),
 				day.Summary)
 
-			fmt.Printf("  High/Low: %s/%s %s\n",
-				styles.TempStyle(fmt.Sprintf("%.1f%s", day.Temp.Max, tempUnit)),
-				styles.TempStyle(fmt.Sprintf("%.1f%s", day.Temp.Min, tempUnit)),
+			output.Printf("  "+i18n.T("High/Low: %s/%s %s")+"\n",
+				styles.TempStyle(r.FormatTemperature(day.Temp.Max)),
+				styles.TempStyle(r.FormatTemperature(day.Temp.Min)),
 				"🌡️")
 
-			fmt.Printf("  Morning: %.1f%s  Day: %.1f%s  Evening: %.1f%s  Night: %.1f%s\n",
-				day.Temp.Morn, tempUnit,
-				day.Temp.Day, tempUnit,
-				day.Temp.Eve, tempUnit,
-				day.Temp.Night, tempUnit)
+			output.Printf("  "+i18n.T("Morning: %s  Day: %s  Evening: %s  Night: %s")+"\n",
+				r.FormatTemperature(day.Temp.Morn),
+				r.FormatTemperature(day.Temp.Day),
+				r.FormatTemperature(day.Temp.Eve),
+				r.FormatTemperature(day.Temp.Night))
 
 			if len(day.Weather) > 0 {
 				weather := day.Weather[0]
 				condition := fmt.Sprintf("%s %s", weather.Description, models.GetWeatherEmoji(weather.ID, nil))
-				fmt.Printf("  Conditions: %s\n", styles.InfoStyle(condition))
+				output.Println("  " + i18n.T("Conditions: %s", styles.InfoStyle(condition)))
 			}
 
 			if day.Pop > 0 {
-				fmt.Printf("  Precipitation: %d%% chance\n", int(day.Pop*100))
+				output.Println("  " + i18n.T("Precipitation: %d%% chance", int(day.Pop*100)))
 			}
 
 			if day.Rain > 0 {
-				fmt.Printf("  Rain: %.1f mm 🌧️\n", day.Rain)
+				output.Println("  " + i18n.T("Rain: %s 🌧️", r.FormatPrecipitation(day.Rain)))
 			}
 
 			if day.Snow > 0 {
-				fmt.Printf("  Snow: %.1f mm ❄️\n", day.Snow)
+				output.Println("  " + i18n.T("Snow: %s ❄️", r.FormatPrecipitation(day.Snow)))
 			}
 
-			windUnit := r.GetWindSpeedUnit()
-			windSpeed := r.FormatWindSpeed(day.WindSpeed)
-
-			fmt.Printf("  Wind: %.1f %s %s\n",
-				windSpeed,
-				windUnit,
+			output.Printf("  "+i18n.T("Wind: %s %s")+"\n",
+				r.FormatWind(day.WindSpeed),
 				models.GetWindDirection(day.WindDeg))
 
-			fmt.Printf("  UV Index: %.1f\n", day.UVI)
+			output.Println("  " + i18n.T("UV Index: %.1f", day.UVI))
 		}
-		fmt.Println()
+		output.Println()
 	}
 }
@@ -0,0 +1,221 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+const (
+	graphHours = 48
+	// braille rows for the temperature line, each row is 4 dots tall
+	tempGraphRows = 8
+	// block rows for the precipitation chance bars, each row is 8 eighths tall
+	popGraphRows = 4
+	// width of the y axis labels
+	graphGutter = 8
+)
+
+var barBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+func (r *TerminalRenderer) RenderHourlyGraph(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	output.Print(styles.FormatHeader(i18n.T("48H FORECAST FOR %s", strings.ToUpper(city.Name))))
+
+	hours := weather.Hourly
+	if len(hours) > graphHours {
+		hours = hours[:graphHours]
+	}
+	if len(hours) < 2 {
+		output.Println(i18n.T("Not enough hourly data to draw a graph."))
+		return
+	}
+
+	temps := make([]float64, len(hours))
+	pops := make([]float64, len(hours))
+	amounts := make([]float64, len(hours))
+	for i, hour := range hours {
+		temps[i] = r.ConvertTemperature(hour.Temp)
+		pops[i] = hour.Pop
+		if hour.Rain != nil {
+			amounts[i] += hour.Rain.OneHour
+		}
+		if hour.Snow != nil {
+			amounts[i] += hour.Snow.OneHour
+		}
+	}
+
+	tempUnit := r.GetTemperatureUnit()
+	low, high := minMax(temps)
+
+	output.Println(styles.HighlightStyleF(i18n.T("Temperature")))
+	for i, row := range temperatureRows(temps, tempGraphRows) {
+		label := ""
+		switch i {
+		case 0:
+			label = fmt.Sprintf("%.1f%s", high, tempUnit)
+		case tempGraphRows - 1:
+			label = fmt.Sprintf("%.1f%s", low, tempUnit)
+		}
+		output.Println(axisLabel(label) + styles.TempStyle(row))
+	}
+
+	output.Println()
+	output.Println(styles.HighlightStyleF(i18n.T("Chance of precipitation")))
+	for i, row := range barRows(pops, 1, popGraphRows) {
+		label := ""
+		switch i {
+		case 0:
+			label = "100%"
+		case popGraphRows - 1:
+			label = "0%"
+		}
+		output.Println(axisLabel(label) + styles.InfoStyle(row))
+	}
+
+	_, wettest := minMax(amounts)
+	if wettest > 0 {
+		output.Println(axisLabel(r.FormatPrecipitation(wettest)) + styles.InfoStyle(barRows(amounts, wettest, 1)[0]))
+	}
+
+	loc := DisplayZone(weather, cfg)
+	ticks, labels, days := timeAxis(hours, loc)
+	output.Println(strings.Repeat(" ", graphGutter+1) + "└" + ticks)
+	output.Println(strings.Repeat(" ", graphGutter+2) + styles.TimeStyle(labels) + "  " + ZoneLabel(time.Unix(hours[0].Dt, 0).In(loc)))
+	output.Println(strings.Repeat(" ", graphGutter+2) + styles.HighlightStyleF(days))
+
+	if wettest == 0 {
+		output.Println()
+		output.Println(styles.InfoStyle(i18n.T("No precipitation expected in the next 48 hours ☀️")))
+	}
+	output.Println()
+}
+
+func axisLabel(label string) string {
+	if label == "" {
+		return fmt.Sprintf("%*s │", graphGutter, "")
+	}
+	return fmt.Sprintf("%*s ┤", graphGutter, label)
+}
+
+// one braille cell per value, the right hand dot column interpolates towards the next value
+func temperatureRows(values []float64, rows int) []string {
+	dotRows := rows * 4
+	low, high := minMax(values)
+
+	level := func(value float64) int {
+		if high == low {
+			return dotRows / 2
+		}
+		return dotRows - 1 - int(math.Round((value-low)/(high-low)*float64(dotRows-1)))
+	}
+
+	dots := make([][]bool, dotRows)
+	for y := range dots {
+		dots[y] = make([]bool, len(values)*2)
+	}
+
+	prev := -1
+	for x := 0; x < len(values)*2; x++ {
+		i := x / 2
+		value := values[i]
+		if x%2 == 1 && i+1 < len(values) {
+			value = (values[i] + values[i+1]) / 2
+		}
+
+		y := level(value)
+		// fill the gap to the previous point so steep changes stay connected
+		from, to := y, y
+		if prev >= 0 {
+			from, to = min(prev, y), max(prev, y)
+		}
+		for fill := from; fill <= to; fill++ {
+			dots[fill][x] = true
+		}
+		prev = y
+	}
+
+	// braille dot bits, indexed by [row within cell][column within cell]
+	bits := [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+	lines := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		var sb strings.Builder
+		for cell := 0; cell < len(values); cell++ {
+			char := rune(0x2800)
+			for dy := 0; dy < 4; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					if dots[row*4+dy][cell*2+dx] {
+						char |= bits[dy][dx]
+					}
+				}
+			}
+			sb.WriteRune(char)
+		}
+		lines[row] = sb.String()
+	}
+	return lines
+}
+
+// vertical bars in eighth blocks, one column per value scaled against maxValue
+func barRows(values []float64, maxValue float64, rows int) []string {
+	lines := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		var sb strings.Builder
+		below := (rows - 1 - row) * 8
+
+		for _, value := range values {
+			eighths := 0
+			if maxValue > 0 {
+				eighths = int(math.Round(math.Min(value/maxValue, 1) * float64(rows*8)))
+			}
+			// a drizzle still deserves a mark
+			if value > 0 && eighths == 0 {
+				eighths = 1
+			}
+			sb.WriteRune(barBlocks[max(0, min(eighths-below, 8))])
+		}
+		lines[row] = sb.String()
+	}
+	return lines
+}
+
+// tick marks every 6 hours, hour labels under them and day names from midnight
+func timeAxis(hours []models.HourData, loc *time.Location) (string, string, string) {
+	ticks := []rune(strings.Repeat("─", len(hours)))
+	labels := []rune(strings.Repeat(" ", len(hours)+2))
+	days := []rune(strings.Repeat(" ", len(hours)+3))
+
+	for i, hour := range hours {
+		t := time.Unix(hour.Dt, 0).In(loc)
+		if t.Hour()%6 == 0 {
+			ticks[i] = '┬'
+			copy(labels[i:], []rune(t.Format("15")))
+		}
+		if t.Hour() == 0 {
+			copy(days[i:], []rune(i18n.FormatTime(t, "Mon")))
+		}
+	}
+
+	// label the first day too, unless midnight is so close the names would collide
+	if first := []rune(i18n.FormatTime(time.Unix(hours[0].Dt, 0).In(loc), "Mon")); strings.TrimSpace(string(days[:len(first)+1])) == "" {
+		copy(days, first)
+	}
+
+	return string(ticks), strings.TrimRight(string(labels), " "), strings.TrimRight(string(days), " ")
+}
+
+func minMax(values []float64) (float64, float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+	return low, high
+}
@@ -1,24 +1,25 @@
 package renderer
 
 import (
-	"fmt"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
-func (r *TerminalRenderer) RenderHourlyForecast(city *models.City, weather *models.OneCallResponse, config *config.Config) {
-	fmt.Print(styles.FormatHeader(fmt.Sprintf("24H FORECAST FOR %s", strings.ToUpper(city.Name))))
+func (r *TerminalRenderer) RenderHourlyForecast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	output.Print(styles.FormatHeader(i18n.T("24H FORECAST FOR %s", strings.ToUpper(city.Name))))
 
 	if len(weather.Hourly) > 0 {
 		hourLimit := int(math.Min(24, float64(len(weather.Hourly))))
 		currentDay := ""
 
-		tempUnit := r.GetTemperatureUnit()
+		loc := DisplayZone(weather, cfg)
 
 		for i := 0; i < hourLimit; i++ {
 			hour := weather.Hourly[i]
@@ -26,31 +27,31 @@ func (r *TerminalRenderer) RenderHourlyForecast(city *models.City, weather *mode
 				continue
 			}
 
-			t := time.Unix(hour.Dt, 0)
-			day := t.Format("Mon Jan 2")
+			t := time.Unix(hour.Dt, 0).In(loc)
+			day := i18n.FormatTime(t, "Mon Jan 2")
 			hourStr := t.Format("15:04")
 
 			if day != currentDay {
 				if currentDay != "" {
-					fmt.Println()
+					output.Println()
 				}
-				fmt.Printf("%s:\n", styles.HighlightStyleF(day))
+				output.Printf("%s: %s\n", styles.HighlightStyleF(day), styles.TimeStyle("("+ZoneLabel(t)+")"))
 				currentDay = day
 			}
 
 			weatherCond := hour.Weather[0]
-			temp := styles.TempStyle(fmt.Sprintf("%.1f%s", hour.Temp, tempUnit))
+			temp := styles.TempStyle(r.FormatTemperature(hour.Temp))
 
 			popStr := ""
 			if hour.Pop > 0 {
-				popStr = fmt.Sprintf(" (%.0f%% chance of precipitation)", hour.Pop*100)
+				popStr = " (" + i18n.T("%.0f%% chance of precipitation", hour.Pop*100) + ")"
 			}
 
 			extraSpace := ""
-			if hour.Temp < 10 {
+			if r.ConvertTemperature(hour.Temp) < 10 {
 				extraSpace = " "
 			}
-			fmt.Printf("  %s:   %s  %s%s  %s%s\n",
+			output.Printf("  %s:   %s  %s%s  %s%s\n",
 				hourStr,
 				temp,
 				extraSpace,
@@ -59,13 +60,13 @@ func (r *TerminalRenderer) RenderHourlyForecast(city *models.City, weather *mode
 				popStr)
 
 			if hour.Rain != nil && hour.Rain.OneHour > 0 {
-				fmt.Printf("       Rain: %.1f mm/h\n", hour.Rain.OneHour)
+				output.Println("       " + i18n.T("Rain: %s/h", r.FormatPrecipitation(hour.Rain.OneHour)))
 			}
 
 			if hour.Snow != nil && hour.Snow.OneHour > 0 {
-				fmt.Printf("       Snow: %.1f mm/h\n", hour.Snow.OneHour)
+				output.Println("       " + i18n.T("Snow: %s/h", r.FormatPrecipitation(hour.Snow.OneHour)))
 			}
 		}
-		fmt.Println()
+		output.Println()
 	}
 }
@@ -12,8 +12,28 @@ type WeatherRenderer interface {
 	RenderAlerts(city *models.City, weather *models.OneCallResponse, cfg *config.Config)
 	RenderFullWeather(city *models.City, weather *models.OneCallResponse, cfg *config.Config)
 	RenderCompactWeather(city *models.City, weather *models.OneCallResponse, cfg *config.Config)
+	RenderHourlyGraph(city *models.City, weather *models.OneCallResponse, cfg *config.Config)
+	RenderNowcast(city *models.City, weather *models.OneCallResponse, cfg *config.Config)
+	RenderSummary(locations []LocationWeather, cfg *config.Config)
 }
 
-func NewWeatherRenderer(rendererType string, units string) WeatherRenderer {
-	return NewTerminalRenderer(units)
+// one row of the multi location summary, Err is set when that location failed to load
+type LocationWeather struct {
+	Label   string
+	City    *models.City
+	Weather *models.OneCallResponse
+	Err     error
+}
+
+func NewWeatherRenderer(rendererType string, cfg *config.Config) WeatherRenderer {
+	switch rendererType {
+	case "json":
+		r := NewJSONRenderer(cfg.Units)
+		r.Display = cfg.DisplayUnits()
+		return r
+	default:
+		r := NewTerminalRenderer(cfg.Units)
+		r.Display = cfg.DisplayUnits()
+		return r
+	}
 }
@@ -0,0 +1,413 @@
+package renderer
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/models"
+)
+
+// bump whenever a field is renamed or removed so scripts can detect it
+// 2: values are converted to the configured display units, listed under display_units
+const JSONSchemaVersion = 2
+
+type JSONRenderer struct {
+	BaseRenderer
+	out io.Writer
+}
+
+func NewJSONRenderer(units string) *JSONRenderer {
+	return &JSONRenderer{
+		BaseRenderer: BaseRenderer{
+			Units: units,
+		},
+		out: os.Stdout,
+	}
+}
+
+type jsonOutput struct {
+	Version      int          `json:"version"`
+	View         string       `json:"view"`
+	Units        string       `json:"units"`
+	DisplayUnits jsonUnits    `json:"display_units"`
+	Location     jsonLocation `json:"location"`
+	Current      *jsonCurrent `json:"current,omitempty"`
+	Nowcast      *jsonNowcast `json:"nowcast,omitempty"`
+	Hourly       []jsonHour   `json:"hourly,omitempty"`
+	Daily        []jsonDay    `json:"daily,omitempty"`
+	Alerts       []jsonAlert  `json:"alerts"`
+}
+
+// the unit of every converted value
+type jsonUnits struct {
+	Temperature   string `json:"temperature"`
+	Wind          string `json:"wind"`
+	Pressure      string `json:"pressure"`
+	Precipitation string `json:"precipitation"`
+	Visibility    string `json:"visibility"`
+}
+
+type jsonLocation struct {
+	Name     string  `json:"name"`
+	Country  string  `json:"country,omitempty"`
+	State    string  `json:"state,omitempty"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Timezone string  `json:"timezone,omitempty"`
+}
+
+type jsonCondition struct {
+	ID          int    `json:"id"`
+	Main        string `json:"main"`
+	Description string `json:"description"`
+}
+
+type jsonCurrent struct {
+	Time          time.Time      `json:"time"`
+	Temp          float64        `json:"temp"`
+	FeelsLike     float64        `json:"feels_like"`
+	Humidity      int            `json:"humidity"`
+	Pressure      float64        `json:"pressure"`
+	UVI           float64        `json:"uvi"`
+	Clouds        int            `json:"clouds"`
+	Visibility    float64        `json:"visibility"`
+	WindSpeed     float64        `json:"wind_speed"`
+	WindGust      float64        `json:"wind_gust"`
+	WindDeg       int            `json:"wind_deg"`
+	WindDirection string         `json:"wind_direction"`
+	Rain1h        float64        `json:"rain_1h"`
+	Snow1h        float64        `json:"snow_1h"`
+	Sunrise       time.Time      `json:"sunrise"`
+	Sunset        time.Time      `json:"sunset"`
+	Condition     *jsonCondition `json:"condition,omitempty"`
+}
+
+type jsonNowcast struct {
+	Summary   string       `json:"summary"`
+	RainStart *time.Time   `json:"rain_start,omitempty"`
+	RainStop  *time.Time   `json:"rain_stop,omitempty"`
+	Peak      float64      `json:"peak_precipitation"`
+	Minutely  []jsonMinute `json:"minutely,omitempty"`
+}
+
+type jsonMinute struct {
+	Time          time.Time `json:"time"`
+	Precipitation float64   `json:"precipitation"`
+}
+
+type jsonHour struct {
+	Time      time.Time      `json:"time"`
+	Temp      float64        `json:"temp"`
+	FeelsLike float64        `json:"feels_like"`
+	Humidity  int            `json:"humidity"`
+	WindSpeed float64        `json:"wind_speed"`
+	WindDeg   int            `json:"wind_deg"`
+	Pop       float64        `json:"pop"`
+	Rain1h    float64        `json:"rain_1h"`
+	Snow1h    float64        `json:"snow_1h"`
+	Condition *jsonCondition `json:"condition,omitempty"`
+}
+
+type jsonDay struct {
+	Date      time.Time      `json:"date"`
+	Summary   string         `json:"summary"`
+	TempMin   float64        `json:"temp_min"`
+	TempMax   float64        `json:"temp_max"`
+	TempMorn  float64        `json:"temp_morn"`
+	TempDay   float64        `json:"temp_day"`
+	TempEve   float64        `json:"temp_eve"`
+	TempNight float64        `json:"temp_night"`
+	Humidity  int            `json:"humidity"`
+	WindSpeed float64        `json:"wind_speed"`
+	WindDeg   int            `json:"wind_deg"`
+	UVI       float64        `json:"uvi"`
+	Pop       float64        `json:"pop"`
+	Rain      float64        `json:"rain"`
+	Snow      float64        `json:"snow"`
+	Sunrise   time.Time      `json:"sunrise"`
+	Sunset    time.Time      `json:"sunset"`
+	Condition *jsonCondition `json:"condition,omitempty"`
+}
+
+type jsonAlert struct {
+	Sender      string    `json:"sender"`
+	Event       string    `json:"event"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+}
+
+func (r *JSONRenderer) RenderCurrentWeather(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("current", city, weather)
+	doc.Current = r.projectCurrent(weather.Current)
+	r.write(doc)
+}
+
+func (r *JSONRenderer) RenderCompactWeather(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("compact", city, weather)
+	doc.Current = r.projectCurrent(weather.Current)
+	// like the terminal view, only mentioned when rain is on the way
+	if n := newNowcast(weather.Minutely, time.Now()); n.rainExpected() {
+		doc.Nowcast = r.projectNowcast(n, weather, cfg, false)
+	}
+	r.write(doc)
+}
+
+func (r *JSONRenderer) RenderNowcast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("nowcast", city, weather)
+	doc.Nowcast = r.projectNowcast(newNowcast(weather.Minutely, time.Now()), weather, cfg, true)
+	r.write(doc)
+}
+
+func (r *JSONRenderer) RenderHourlyForecast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("hourly", city, weather)
+	doc.Hourly = r.projectHourly(weather.Hourly, 24)
+	r.write(doc)
+}
+
+// the same hours the chart is drawn from
+func (r *JSONRenderer) RenderHourlyGraph(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("graph", city, weather)
+	doc.Hourly = r.projectHourly(weather.Hourly, graphHours)
+	r.write(doc)
+}
+
+func (r *JSONRenderer) RenderDailyForecast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("daily", city, weather)
+	doc.Daily = r.projectDaily(weather.Daily, 5)
+	r.write(doc)
+}
+
+func (r *JSONRenderer) RenderAlerts(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	r.write(r.newOutput("alerts", city, weather))
+}
+
+func (r *JSONRenderer) RenderFullWeather(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	doc := r.newOutput("full", city, weather)
+	doc.Current = r.projectCurrent(weather.Current)
+	doc.Daily = r.projectDaily(weather.Daily, 5)
+	r.write(doc)
+}
+
+type jsonSummary struct {
+	Version      int               `json:"version"`
+	View         string            `json:"view"`
+	Units        string            `json:"units"`
+	DisplayUnits jsonUnits         `json:"display_units"`
+	Locations    []jsonSummaryItem `json:"locations"`
+}
+
+type jsonSummaryItem struct {
+	Label    string        `json:"label"`
+	Location *jsonLocation `json:"location,omitempty"`
+	Current  *jsonCurrent  `json:"current,omitempty"`
+	Alerts   []jsonAlert   `json:"alerts,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func (r *JSONRenderer) RenderSummary(locations []LocationWeather, cfg *config.Config) {
+	doc := jsonSummary{
+		Version:      JSONSchemaVersion,
+		View:         "summary",
+		Units:        r.Units,
+		DisplayUnits: r.jsonUnits(),
+		Locations:    make([]jsonSummaryItem, 0, len(locations)),
+	}
+	for _, location := range locations {
+		item := jsonSummaryItem{Label: location.Label}
+		if location.Err != nil {
+			item.Error = location.Err.Error()
+		} else {
+			base := r.newOutput("summary", location.City, location.Weather)
+			item.Location = &base.Location
+			item.Current = r.projectCurrent(location.Weather.Current)
+			item.Alerts = base.Alerts
+		}
+		doc.Locations = append(doc.Locations, item)
+	}
+	r.writeValue(doc)
+}
+
+func (r *JSONRenderer) newOutput(view string, city *models.City, weather *models.OneCallResponse) jsonOutput {
+	return jsonOutput{
+		Version:      JSONSchemaVersion,
+		View:         view,
+		Units:        r.Units,
+		DisplayUnits: r.jsonUnits(),
+		Location: jsonLocation{
+			Name:     city.Name,
+			Country:  city.Country,
+			State:    city.State,
+			Lat:      city.Lat,
+			Lon:      city.Lon,
+			Timezone: weather.Timezone,
+		},
+		Alerts: projectAlerts(weather.Alerts),
+	}
+}
+
+func (r *JSONRenderer) jsonUnits() jsonUnits {
+	units := r.displayUnits()
+	return jsonUnits{
+		Temperature:   units.Temperature,
+		Wind:          units.Wind,
+		Pressure:      units.Pressure,
+		Precipitation: units.Precipitation,
+		Visibility:    units.Visibility,
+	}
+}
+
+func (r *JSONRenderer) write(doc jsonOutput) {
+	r.writeValue(doc)
+}
+
+func (r *JSONRenderer) writeValue(doc any) {
+	encoder := json.NewEncoder(r.out)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(doc)
+}
+
+func projectCondition(conditions []models.WeatherCondition) *jsonCondition {
+	if len(conditions) == 0 {
+		return nil
+	}
+	return &jsonCondition{
+		ID:          conditions[0].ID,
+		Main:        conditions[0].Main,
+		Description: conditions[0].Description,
+	}
+}
+
+func (r *JSONRenderer) projectCurrent(current models.CurrentWeather) *jsonCurrent {
+	projected := &jsonCurrent{
+		Time:          unixUTC(current.Dt),
+		Temp:          r.ConvertTemperature(current.Temp),
+		FeelsLike:     r.ConvertTemperature(current.FeelsLike),
+		Humidity:      current.Humidity,
+		Pressure:      r.ConvertPressure(current.Pressure),
+		UVI:           current.UVI,
+		Clouds:        current.Clouds,
+		Visibility:    r.ConvertVisibility(current.Visibility),
+		WindSpeed:     r.FormatWindSpeed(current.WindSpeed),
+		WindGust:      r.FormatWindSpeed(current.WindGust),
+		WindDeg:       current.WindDeg,
+		WindDirection: models.GetWindDirection(current.WindDeg),
+		Sunrise:       unixUTC(current.Sunrise),
+		Sunset:        unixUTC(current.Sunset),
+		Condition:     projectCondition(current.Weather),
+	}
+	if current.Rain != nil {
+		projected.Rain1h = r.ConvertPrecipitation(current.Rain.OneHour)
+	}
+	if current.Snow != nil {
+		projected.Snow1h = r.ConvertPrecipitation(current.Snow.OneHour)
+	}
+	return projected
+}
+
+func (r *JSONRenderer) projectNowcast(n nowcast, weather *models.OneCallResponse, cfg *config.Config, withMinutes bool) *jsonNowcast {
+	projected := &jsonNowcast{
+		Summary: n.summary(time.Now(), DisplayZone(weather, cfg)),
+		Peak:    r.ConvertPrecipitation(n.peak),
+	}
+	if n.start >= 0 {
+		start := unixUTC(n.minutes[n.start].Dt)
+		projected.RainStart = &start
+	}
+	if n.stop >= 0 {
+		stop := unixUTC(n.minutes[n.stop].Dt)
+		projected.RainStop = &stop
+	}
+	if withMinutes {
+		projected.Minutely = make([]jsonMinute, len(n.minutes))
+		for i, minute := range n.minutes {
+			projected.Minutely[i] = jsonMinute{Time: unixUTC(minute.Dt), Precipitation: r.ConvertPrecipitation(minute.Precipitation)}
+		}
+	}
+	return projected
+}
+
+func (r *JSONRenderer) projectHourly(hourly []models.HourData, limit int) []jsonHour {
+	hours := make([]jsonHour, 0, limit)
+	for i, hour := range hourly {
+		if i >= limit {
+			break
+		}
+		projected := jsonHour{
+			Time:      unixUTC(hour.Dt),
+			Temp:      r.ConvertTemperature(hour.Temp),
+			FeelsLike: r.ConvertTemperature(hour.FeelsLike),
+			Humidity:  hour.Humidity,
+			WindSpeed: r.FormatWindSpeed(hour.WindSpeed),
+			WindDeg:   hour.WindDeg,
+			Pop:       hour.Pop,
+			Condition: projectCondition(hour.Weather),
+		}
+		if hour.Rain != nil {
+			projected.Rain1h = r.ConvertPrecipitation(hour.Rain.OneHour)
+		}
+		if hour.Snow != nil {
+			projected.Snow1h = r.ConvertPrecipitation(hour.Snow.OneHour)
+		}
+		hours = append(hours, projected)
+	}
+	return hours
+}
+
+func (r *JSONRenderer) projectDaily(daily []models.DayData, limit int) []jsonDay {
+	days := make([]jsonDay, 0, limit)
+	for i, day := range daily {
+		if i >= limit {
+			break
+		}
+		days = append(days, jsonDay{
+			Date:      unixUTC(day.Dt),
+			Summary:   day.Summary,
+			TempMin:   r.ConvertTemperature(day.Temp.Min),
+			TempMax:   r.ConvertTemperature(day.Temp.Max),
+			TempMorn:  r.ConvertTemperature(day.Temp.Morn),
+			TempDay:   r.ConvertTemperature(day.Temp.Day),
+			TempEve:   r.ConvertTemperature(day.Temp.Eve),
+			TempNight: r.ConvertTemperature(day.Temp.Night),
+			Humidity:  day.Humidity,
+			WindSpeed: r.FormatWindSpeed(day.WindSpeed),
+			WindDeg:   day.WindDeg,
+			UVI:       day.UVI,
+			Pop:       day.Pop,
+			Rain:      r.ConvertPrecipitation(day.Rain),
+			Snow:      r.ConvertPrecipitation(day.Snow),
+			Sunrise:   unixUTC(day.Sunrise),
+			Sunset:    unixUTC(day.Sunset),
+			Condition: projectCondition(day.Weather),
+		})
+	}
+	return days
+}
+
+func projectAlerts(alerts []models.Alert) []jsonAlert {
+	projected := make([]jsonAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		tags := alert.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		projected = append(projected, jsonAlert{
+			Sender:      alert.SenderName,
+			Event:       alert.Event,
+			Start:       unixUTC(alert.Start),
+			End:         unixUTC(alert.End),
+			Description: alert.Description,
+			Tags:        tags,
+		})
+	}
+	return projected
+}
+
+// timestamps are emitted as RFC 3339 in UTC so output doesn't depend on the machine's zone
+func unixUTC(timestamp int64) time.Time {
+	return time.Unix(timestamp, 0).UTC()
+}
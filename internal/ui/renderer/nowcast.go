@@ -0,0 +1,133 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+// minutely data covers the next hour
+const nowcastMinutes = 60
+
+// what the next hour of minutely precipitation amounts to
+type nowcast struct {
+	minutes []models.MinuteData
+	// index of the first wet minute and the first dry one after it, -1 if there is none
+	start, stop int
+	peak        float64
+}
+
+// drops minutes already in the past, e.g. when the weather came from the cache
+func newNowcast(minutely []models.MinuteData, now time.Time) nowcast {
+	cutoff := now.Truncate(time.Minute).Unix()
+	for len(minutely) > 0 && minutely[0].Dt < cutoff {
+		minutely = minutely[1:]
+	}
+	if len(minutely) > nowcastMinutes {
+		minutely = minutely[:nowcastMinutes]
+	}
+
+	n := nowcast{minutes: minutely, start: -1, stop: -1}
+	for i, minute := range minutely {
+		n.peak = max(n.peak, minute.Precipitation)
+		switch {
+		case minute.Precipitation > 0 && n.start < 0:
+			n.start = i
+		case minute.Precipitation == 0 && n.start >= 0 && n.stop < 0:
+			n.stop = i
+		}
+	}
+	return n
+}
+
+func (n nowcast) available() bool {
+	return len(n.minutes) > 0
+}
+
+// rain is falling or due to start within the hour
+func (n nowcast) rainExpected() bool {
+	return n.start >= 0
+}
+
+func (n nowcast) minutesUntil(i int, now time.Time) int {
+	return max(0, int(time.Unix(n.minutes[i].Dt, 0).Sub(now).Round(time.Minute).Minutes()))
+}
+
+// a one line summary, e.g. "Rain starting in 12 min, stopping around 14:40"
+func (n nowcast) summary(now time.Time, loc *time.Location) string {
+	if !n.available() {
+		return i18n.T("No minute by minute forecast available for this location")
+	}
+	if !n.rainExpected() {
+		return i18n.T("No rain expected in the next hour")
+	}
+
+	var sb strings.Builder
+	if n.start == 0 {
+		sb.WriteString(i18n.T("Rain now"))
+	} else {
+		sb.WriteString(i18n.T("Rain starting in %d min", n.minutesUntil(n.start, now)))
+	}
+
+	if n.stop >= 0 {
+		sb.WriteString(i18n.T(", stopping around %s", time.Unix(n.minutes[n.stop].Dt, 0).In(loc).Format("15:04")))
+	} else {
+		sb.WriteString(i18n.T(", lasting at least the next hour"))
+	}
+	return sb.String()
+}
+
+func (n nowcast) precipitation() []float64 {
+	values := make([]float64, len(n.minutes))
+	for i, minute := range n.minutes {
+		values[i] = minute.Precipitation
+	}
+	return values
+}
+
+func (r *TerminalRenderer) RenderNowcast(city *models.City, weather *models.OneCallResponse, cfg *config.Config) {
+	output.Print(styles.FormatHeader(i18n.T("NEXT HOUR IN %s", strings.ToUpper(city.Name))))
+
+	now := time.Now()
+	n := newNowcast(weather.Minutely, now)
+	switch {
+	case !n.available():
+		output.Println(n.summary(now, DisplayZone(weather, cfg)))
+		output.Println()
+		return
+	case !n.rainExpected():
+		output.Println(styles.InfoStyle(n.summary(now, DisplayZone(weather, cfg)) + " ☀️"))
+		output.Println()
+		return
+	}
+
+	output.Println(styles.HighlightStyleF("☔ " + n.summary(now, DisplayZone(weather, cfg))))
+	output.Println()
+
+	output.Println(axisLabel(fmt.Sprintf("%.1f%s/h", r.ConvertPrecipitation(n.peak), r.GetPrecipitationUnit())) + styles.InfoStyle(barRows(n.precipitation(), n.peak, 1)[0]))
+	ticks, labels := nowcastAxis(len(n.minutes))
+	output.Println(strings.Repeat(" ", graphGutter+1) + "└" + ticks)
+	output.Println(strings.Repeat(" ", graphGutter+2) + styles.TimeStyle(labels))
+	output.Println()
+}
+
+// a tick every 15 minutes, labelled with the minutes from now
+func nowcastAxis(minutes int) (string, string) {
+	ticks := []rune(strings.Repeat("─", minutes))
+	labels := []rune(strings.Repeat(" ", minutes+3))
+	for i := 0; i < minutes; i += 15 {
+		ticks[i] = '┬'
+		label := i18n.T("now")
+		if i > 0 {
+			label = fmt.Sprintf("+%d", i)
+		}
+		copy(labels[i:], []rune(label))
+	}
+	return string(ticks), strings.TrimRight(string(labels), " ")
+}
@@ -2,6 +2,8 @@ package renderer
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"strings"
@@ -14,7 +16,7 @@ import (
 )
 
 func TestNewWeatherRenderer(t *testing.T) {
-	renderer := NewWeatherRenderer("terminal", "")
+	renderer := NewWeatherRenderer("terminal", &config.Config{})
 	if renderer == nil {
 		t.Fatal("NewWeatherRenderer() should return a non-nil renderer")
 	}
@@ -196,18 +198,16 @@ func TestBaseRendererHelpers(t *testing.T) {
 				t.Errorf("GetWindSpeedUnit() = %v, want %v", windUnit, tc.expectedWindSpeedUnit)
 			}
 
-			// Test wind speed conversion
+			// wind always arrives in m/s
 			windSpeed := 10.0
 			convertedSpeed := renderer.FormatWindSpeed(windSpeed)
 
+			expected := windSpeed * 3.6
 			if tc.units == "imperial" {
-				if convertedSpeed != windSpeed {
-					t.Errorf("FormatWindSpeed() = %v, want %v", convertedSpeed, windSpeed)
-				}
-			} else {
-				if convertedSpeed != windSpeed*3.6 {
-					t.Errorf("FormatWindSpeed() = %v, want %v", convertedSpeed, windSpeed*3.6)
-				}
+				expected = windSpeed * 2.2369362920544
+			}
+			if diff := convertedSpeed - expected; diff > 0.001 || diff < -0.001 {
+				t.Errorf("FormatWindSpeed() = %v, want %v", convertedSpeed, expected)
 			}
 		})
 	}
@@ -237,3 +237,379 @@ func TestFormatHeader(t *testing.T) {
 		t.Errorf("Header doesn't contain divider: %s", header)
 	}
 }
+
+func TestNewWeatherRendererJSON(t *testing.T) {
+	if _, ok := NewWeatherRenderer("json", &config.Config{Units: "metric"}).(*JSONRenderer); !ok {
+		t.Fatal("NewWeatherRenderer(\"json\") should return a JSONRenderer")
+	}
+	if _, ok := NewWeatherRenderer("terminal", &config.Config{Units: "metric"}).(*TerminalRenderer); !ok {
+		t.Fatal("NewWeatherRenderer(\"terminal\") should return a TerminalRenderer")
+	}
+}
+
+func TestJSONRendererViews(t *testing.T) {
+	city := &models.City{Name: "Test City", Country: "GB", Lat: 51.5, Lon: -0.12}
+	weather := &models.OneCallResponse{
+		Timezone: "Europe/London",
+		Current: models.CurrentWeather{
+			Dt:       1609459200,
+			Temp:     10,
+			Humidity: 65,
+			WindDeg:  180,
+			Rain:     &models.RainData{OneHour: 0.4},
+			Weather:  []models.WeatherCondition{{ID: 500, Main: "Rain", Description: "light rain"}},
+		},
+		Hourly: make([]models.HourData, 48),
+		Daily:  make([]models.DayData, 8),
+	}
+	cfg := &config.Config{Units: "metric"}
+
+	testCases := []struct {
+		name        string
+		render      func(r *JSONRenderer)
+		view        string
+		hasCurrent  bool
+		hourlyCount int
+		dailyCount  int
+	}{
+		{"current", func(r *JSONRenderer) { r.RenderCurrentWeather(city, weather, cfg) }, "current", true, 0, 0},
+		{"compact", func(r *JSONRenderer) { r.RenderCompactWeather(city, weather, cfg) }, "compact", true, 0, 0},
+		{"hourly", func(r *JSONRenderer) { r.RenderHourlyForecast(city, weather, cfg) }, "hourly", false, 24, 0},
+		{"daily", func(r *JSONRenderer) { r.RenderDailyForecast(city, weather, cfg) }, "daily", false, 0, 5},
+		{"alerts", func(r *JSONRenderer) { r.RenderAlerts(city, weather, cfg) }, "alerts", false, 0, 0},
+		{"full", func(r *JSONRenderer) { r.RenderFullWeather(city, weather, cfg) }, "full", true, 0, 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderer := NewJSONRenderer("metric")
+			renderer.out = &buf
+			tc.render(renderer)
+
+			var doc jsonOutput
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("output is not valid json: %v\n%s", err, buf.String())
+			}
+
+			if doc.Version != JSONSchemaVersion {
+				t.Errorf("version = %d, want %d", doc.Version, JSONSchemaVersion)
+			}
+			if doc.View != tc.view {
+				t.Errorf("view = %q, want %q", doc.View, tc.view)
+			}
+			if doc.Location.Name != "Test City" || doc.Location.Timezone != "Europe/London" {
+				t.Errorf("unexpected location: %+v", doc.Location)
+			}
+			if (doc.Current != nil) != tc.hasCurrent {
+				t.Errorf("current present = %v, want %v", doc.Current != nil, tc.hasCurrent)
+			}
+			if len(doc.Hourly) != tc.hourlyCount {
+				t.Errorf("hourly count = %d, want %d", len(doc.Hourly), tc.hourlyCount)
+			}
+			if len(doc.Daily) != tc.dailyCount {
+				t.Errorf("daily count = %d, want %d", len(doc.Daily), tc.dailyCount)
+			}
+			if doc.Alerts == nil {
+				t.Error("alerts should always be present, even when empty")
+			}
+			if tc.hasCurrent {
+				if doc.Current.Rain1h != 0.4 || doc.Current.WindDirection != "S" {
+					t.Errorf("unexpected current projection: %+v", doc.Current)
+				}
+				if !doc.Current.Time.Equal(time.Unix(1609459200, 0)) {
+					t.Errorf("current time = %v", doc.Current.Time)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONRendererSummary(t *testing.T) {
+	locations := []LocationWeather{
+		{
+			Label:   "home",
+			City:    &models.City{Name: "London"},
+			Weather: &models.OneCallResponse{Current: models.CurrentWeather{Temp: 12}},
+		},
+		{Label: "work", Err: errors.New("city not found")},
+	}
+
+	var buf bytes.Buffer
+	renderer := NewJSONRenderer("metric")
+	renderer.out = &buf
+	renderer.RenderSummary(locations, &config.Config{})
+
+	var doc jsonSummary
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid json: %v\n%s", err, buf.String())
+	}
+
+	if doc.View != "summary" || len(doc.Locations) != 2 {
+		t.Fatalf("unexpected summary: %+v", doc)
+	}
+	if doc.Locations[0].Current == nil || doc.Locations[0].Current.Temp != 12 || doc.Locations[0].Location.Name != "London" {
+		t.Errorf("unexpected first location: %+v", doc.Locations[0])
+	}
+	if doc.Locations[1].Error != "city not found" || doc.Locations[1].Current != nil {
+		t.Errorf("failed location should only carry its error: %+v", doc.Locations[1])
+	}
+}
+
+func TestTemperatureRows(t *testing.T) {
+	rows := temperatureRows([]float64{0, 10, 20}, 2)
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	for _, row := range rows {
+		if n := len([]rune(row)); n != 3 {
+			t.Errorf("Expected one braille cell per value, got %d in %q", n, row)
+		}
+	}
+
+	top, bottom := []rune(rows[0]), []rune(rows[1])
+	if bottom[0] == 0x2800 || top[0] != 0x2800 {
+		t.Errorf("The coldest hour should be plotted at the bottom, got %q / %q", rows[0], rows[1])
+	}
+	if top[2] == 0x2800 {
+		t.Errorf("The warmest hour should reach the top row, got %q", rows[0])
+	}
+}
+
+func TestTemperatureRowsFlat(t *testing.T) {
+	// a flat line must not divide by zero
+	rows := temperatureRows([]float64{5, 5, 5}, 4)
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(rows))
+	}
+}
+
+func TestBarRows(t *testing.T) {
+	rows := barRows([]float64{0, 0.5, 1, 0.01}, 1, 2)
+
+	expected := []string{"  █ ", " ██▁"}
+	for i := range expected {
+		if rows[i] != expected[i] {
+			t.Errorf("row %d = %q, want %q", i, rows[i], expected[i])
+		}
+	}
+}
+
+func TestTimeAxis(t *testing.T) {
+	start := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	hours := make([]models.HourData, 10)
+	for i := range hours {
+		hours[i].Dt = start.Add(time.Duration(i) * time.Hour).Unix()
+	}
+
+	ticks, labels, days := timeAxis(hours, time.UTC)
+
+	if ticks != "──┬─────┬─" {
+		t.Errorf("ticks = %q", ticks)
+	}
+	if labels != "  00    06" {
+		t.Errorf("labels = %q", labels)
+	}
+
+	if days != "  Tue" {
+		t.Errorf("days = %q, the first day should give way to a close midnight", days)
+	}
+
+	_, _, days = timeAxis(hours[3:], time.UTC)
+	if days != "Tue" {
+		t.Errorf("days without a midnight = %q", days)
+	}
+
+	_, _, days = timeAxis(hours, time.FixedZone("", -6*3600))
+	if days != "Mon     Tue" {
+		t.Errorf("days in the location zone = %q", days)
+	}
+}
+
+func TestDisplayZone(t *testing.T) {
+	weather := &models.OneCallResponse{Timezone: "Asia/Tokyo"}
+
+	if zone := DisplayZone(weather, &config.Config{}); zone.String() != "Asia/Tokyo" {
+		t.Errorf("Expected the location's zone, got %s", zone)
+	}
+	if zone := DisplayZone(weather, &config.Config{LocalTime: true}); zone != time.Local {
+		t.Errorf("Expected local time, got %s", zone)
+	}
+}
+
+func TestZoneLabel(t *testing.T) {
+	at := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	testCases := []struct {
+		loc      *time.Location
+		expected string
+	}{
+		{tokyo, "JST"},
+		{time.FixedZone("+04", 4*3600), "UTC+4"},
+		{time.FixedZone("", 5*3600+1800), "UTC+5:30"},
+		{time.FixedZone("", -3*3600), "UTC-3"},
+	}
+
+	for _, tc := range testCases {
+		if got := ZoneLabel(at.In(tc.loc)); got != tc.expected {
+			t.Errorf("ZoneLabel(%s) = %q, want %q", tc.loc, got, tc.expected)
+		}
+	}
+}
+
+func minutesFrom(start time.Time, precipitation ...float64) []models.MinuteData {
+	minutes := make([]models.MinuteData, len(precipitation))
+	for i, value := range precipitation {
+		minutes[i] = models.MinuteData{Dt: start.Add(time.Duration(i) * time.Minute).Unix(), Precipitation: value}
+	}
+	return minutes
+}
+
+func TestNowcastSummary(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		minutely []models.MinuteData
+		expected string
+		rain     bool
+	}{
+		{"no data", nil, "No minute by minute forecast available for this location", false},
+		{"dry", minutesFrom(now, 0, 0, 0), "No rain expected in the next hour", false},
+		{"starting and stopping", minutesFrom(now, 0, 0, 0.3, 1.2, 0), "Rain starting in 2 min, stopping around 14:04", true},
+		{"raining now", minutesFrom(now, 0.5, 0.5, 0, 0.2), "Rain now, stopping around 14:02", true},
+		{"rain all hour", minutesFrom(now, 0, 0.5, 0.5), "Rain starting in 1 min, lasting at least the next hour", true},
+		// cached data starting before now, the rain has already stopped
+		{"stale minutes dropped", minutesFrom(now.Add(-3*time.Minute), 0.4, 0.4, 0, 0, 0), "No rain expected in the next hour", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := newNowcast(tc.minutely, now)
+			if got := n.summary(now, time.UTC); got != tc.expected {
+				t.Errorf("summary = %q, want %q", got, tc.expected)
+			}
+			if n.rainExpected() != tc.rain {
+				t.Errorf("rainExpected = %v, want %v", n.rainExpected(), tc.rain)
+			}
+		})
+	}
+}
+
+func TestNowcastLimitedToAnHour(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	n := newNowcast(minutesFrom(now, make([]float64, 90)...), now)
+	if len(n.minutes) != nowcastMinutes {
+		t.Errorf("minutes = %d, want %d", len(n.minutes), nowcastMinutes)
+	}
+
+	ticks, labels := nowcastAxis(len(n.minutes))
+	if strings.Count(ticks, "┬") != 4 {
+		t.Errorf("ticks = %q", ticks)
+	}
+	if !strings.HasPrefix(labels, "now") || !strings.HasSuffix(labels, "+45") {
+		t.Errorf("labels = %q", labels)
+	}
+}
+
+func TestJSONRendererNowcast(t *testing.T) {
+	city := &models.City{Name: "Test City"}
+	start := time.Now().Truncate(time.Minute).Add(time.Minute)
+	weather := &models.OneCallResponse{Minutely: minutesFrom(start, 0, 0.8, 1.5, 0)}
+
+	var buf bytes.Buffer
+	renderer := NewJSONRenderer("metric")
+	renderer.out = &buf
+	renderer.RenderNowcast(city, weather, &config.Config{})
+
+	var doc jsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid json: %v\n%s", err, buf.String())
+	}
+	if doc.View != "nowcast" || doc.Nowcast == nil {
+		t.Fatalf("unexpected document: %s", buf.String())
+	}
+	if len(doc.Nowcast.Minutely) != 4 || doc.Nowcast.Peak != 1.5 {
+		t.Errorf("unexpected nowcast: %+v", doc.Nowcast)
+	}
+	if doc.Nowcast.RainStart == nil || !doc.Nowcast.RainStart.Equal(start.Add(time.Minute)) {
+		t.Errorf("rain start = %v", doc.Nowcast.RainStart)
+	}
+	if doc.Nowcast.RainStop == nil || !doc.Nowcast.RainStop.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("rain stop = %v", doc.Nowcast.RainStop)
+	}
+
+	// the compact view only mentions it when rain is on the way
+	buf.Reset()
+	renderer.RenderCompactWeather(city, &models.OneCallResponse{Minutely: minutesFrom(start, 0, 0)}, &config.Config{})
+	if strings.Contains(buf.String(), "nowcast") {
+		t.Errorf("dry compact output should not include a nowcast:\n%s", buf.String())
+	}
+}
+
+func TestBaseRendererDisplayOverrides(t *testing.T) {
+	renderer := BaseRenderer{Units: "metric", Display: config.DisplayUnits{Wind: "kn", Precipitation: "in", Pressure: "inHg", Visibility: "mi"}}
+
+	if got := renderer.FormatTemperature(21.5); got != "21.5°C" {
+		t.Errorf("FormatTemperature() = %q", got)
+	}
+	if got := renderer.FormatWind(10); got != "19.4 kn" {
+		t.Errorf("FormatWind() = %q", got)
+	}
+	if got := renderer.FormatPrecipitation(12.7); got != "0.50 in" {
+		t.Errorf("FormatPrecipitation() = %q", got)
+	}
+	if got := renderer.FormatPressure(1013); got != "29.91 inHg" {
+		t.Errorf("FormatPressure() = %q", got)
+	}
+	if got := renderer.DescribeVisibility(8047); got != "Good (5.0 mi)" {
+		t.Errorf("DescribeVisibility() = %q", got)
+	}
+
+	renderer = BaseRenderer{Units: "imperial", Display: config.DisplayUnits{Wind: "bft"}}
+	if got := renderer.FormatTemperature(100); got != "212.0°F" {
+		t.Errorf("FormatTemperature() in fahrenheit = %q", got)
+	}
+	if got := renderer.FormatWind(9); got != "5 Bft" {
+		t.Errorf("FormatWind() in beaufort = %q", got)
+	}
+
+	renderer = BaseRenderer{Units: "standard", Display: config.DisplayUnits{Temperature: "C", Wind: "bft"}}
+	if got := renderer.FormatTemperature(-3.5); got != "-3.5°C" {
+		t.Errorf("FormatTemperature() overriding standard = %q", got)
+	}
+	if got := renderer.FormatWind(20); got != "8 Bft" {
+		t.Errorf("FormatWind() in beaufort = %q", got)
+	}
+}
+
+func TestJSONRendererDisplayUnits(t *testing.T) {
+	city := &models.City{Name: "Test City"}
+	weather := &models.OneCallResponse{
+		Current: models.CurrentWeather{Temp: 10, WindSpeed: 10, Pressure: 1000, Visibility: 10000, Rain: &models.RainData{OneHour: 2.54}},
+	}
+
+	var buf bytes.Buffer
+	renderer := NewJSONRenderer("metric")
+	renderer.Display = config.DisplayUnits{Temperature: "F", Wind: "m/s", Precipitation: "in"}
+	renderer.out = &buf
+	renderer.RenderCurrentWeather(city, weather, &config.Config{})
+
+	var doc jsonOutput
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid json: %v\n%s", err, buf.String())
+	}
+
+	expectedUnits := jsonUnits{Temperature: "F", Wind: "m/s", Pressure: "hPa", Precipitation: "in", Visibility: "km"}
+	if doc.DisplayUnits != expectedUnits {
+		t.Errorf("display units = %+v", doc.DisplayUnits)
+	}
+	if doc.Current.Temp != 50 || doc.Current.WindSpeed != 10 || doc.Current.Visibility != 10 {
+		t.Errorf("unexpected conversion: %+v", doc.Current)
+	}
+	if diff := doc.Current.Rain1h - 0.1; diff > 0.0001 || diff < -0.0001 {
+		t.Errorf("rain_1h = %v, want 0.1", doc.Current.Rain1h)
+	}
+}
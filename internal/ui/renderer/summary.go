@@ -0,0 +1,50 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/ui/output"
+	"github.com/josephburgess/gust/internal/ui/styles"
+)
+
+// one compact row per saved location, used by `gust --all`
+func (r *TerminalRenderer) RenderSummary(locations []LocationWeather, cfg *config.Config) {
+	output.Print(styles.FormatHeader(i18n.T("SAVED LOCATIONS")))
+
+	labelWidth := 0
+	for _, location := range locations {
+		labelWidth = max(labelWidth, len(location.Label))
+	}
+
+	for _, location := range locations {
+		label := styles.HighlightStyleF(fmt.Sprintf("%-*s", labelWidth, location.Label))
+
+		if location.Err != nil {
+			output.Printf("%s  %s\n", label, styles.AlertStyle(fmt.Sprintf("⚠️ %v", location.Err)))
+			continue
+		}
+
+		current := location.Weather.Current
+		emoji, description := "", ""
+		if len(current.Weather) > 0 {
+			emoji = models.GetWeatherEmoji(current.Weather[0].ID, &current)
+			description = current.Weather[0].Description
+		}
+
+		output.Printf("%s  %s %-16s 💨 %-8s %-2s  %s",
+			label,
+			emoji,
+			styles.TempStyle(r.FormatTemperature(current.Temp)),
+			r.FormatWind(current.WindSpeed),
+			models.GetWindDirection(current.WindDeg),
+			description)
+
+		if len(location.Weather.Alerts) > 0 {
+			output.Printf("  %s", styles.AlertStyle(i18n.T("⚠️ %d alerts", len(location.Weather.Alerts))))
+		}
+		output.Println()
+	}
+}
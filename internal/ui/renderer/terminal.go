@@ -2,9 +2,13 @@ package renderer
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/tips"
+	"github.com/josephburgess/gust/internal/ui/output"
 	"github.com/josephburgess/gust/internal/ui/styles"
 )
 
@@ -21,22 +25,15 @@ func NewTerminalRenderer(units string) *TerminalRenderer {
 }
 
 func (r *TerminalRenderer) displayWindInfo(speed float64, deg int, gust float64) {
-	windUnit := r.GetWindSpeedUnit()
-	windSpeed := r.FormatWindSpeed(speed)
-
 	if gust > 0 {
-		gustSpeed := r.FormatWindSpeed(gust)
-		fmt.Printf("Wind: %.1f %s %s %s (Gusts: %.1f %s)\n",
-			windSpeed,
-			windUnit,
+		output.Printf(i18n.T("Wind: %s %s %s (Gusts: %s)")+"\n",
+			r.FormatWind(speed),
 			models.GetWindDirection(deg),
 			"💨",
-			gustSpeed,
-			windUnit)
+			r.FormatWind(gust))
 	} else {
-		fmt.Printf("Wind: %.1f %s %s %s\n",
-			windSpeed,
-			windUnit,
+		output.Printf(i18n.T("Wind: %s %s %s")+"\n",
+			r.FormatWind(speed),
 			models.GetWindDirection(deg),
 			"💨")
 	}
@@ -44,26 +41,37 @@ func (r *TerminalRenderer) displayWindInfo(speed float64, deg int, gust float64)
 
 func (r *TerminalRenderer) displayPrecipitation(rain *models.RainData, snow *models.SnowData) {
 	if rain != nil && rain.OneHour > 0 {
-		fmt.Printf("Rain: %.1f mm (last hour) 🌧️\n", rain.OneHour)
+		output.Println(i18n.T("Rain: %s (last hour) 🌧️", r.FormatPrecipitation(rain.OneHour)))
 	}
 
 	if snow != nil && snow.OneHour > 0 {
-		fmt.Printf("Snow: %.1f mm (last hour) ❄️\n", snow.OneHour)
+		output.Println(i18n.T("Snow: %s (last hour) ❄️", r.FormatPrecipitation(snow.OneHour)))
 	}
 }
 
 func (r *TerminalRenderer) displayAlertSummary(alerts []models.Alert, cityName string) {
 	if len(alerts) > 0 {
-		fmt.Printf("%s Use 'gust --alerts %s' to view them.\n",
-			styles.AlertStyle(fmt.Sprintf("⚠️  There are %d weather alerts for this area.", len(alerts))),
-			cityName)
+		output.Printf("%s %s\n",
+			styles.AlertStyle(i18n.T("⚠️  There are %d weather alerts for this area.", len(alerts))),
+			i18n.T("Use 'gust alerts %s' to view them.", cityName))
 	}
 }
 
-func (r *TerminalRenderer) displayWeatherTip(weather *models.OneCallResponse, cfg *config.Config) {
+// the highest ranked tips, up to limit
+func (r *TerminalRenderer) displayWeatherTips(weather *models.OneCallResponse, cfg *config.Config, limit int) {
 	if !cfg.ShowTips {
 		return
 	}
-	tip := models.GetWeatherTip(weather, r.Units)
-	fmt.Printf("\n%s\n", styles.TipStyle(fmt.Sprintf("💡 %s", tip)))
+
+	engine, err := tips.New(cfg.Tips)
+	if err != nil {
+		// a broken custom tip shouldn't hide the weather
+		output.Printf("\n%s\n", styles.WarningStyle(fmt.Sprintf("⚠️ %v", err)))
+		return
+	}
+
+	output.Println()
+	for _, tip := range engine.Tips(weather, r.displayUnits(), time.Now(), DisplayZone(weather, cfg), i18n.Language(), limit) {
+		output.Println(styles.TipStyle(fmt.Sprintf("💡 %s", tip)))
+	}
 }
@@ -1,42 +1,137 @@
 package renderer
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/josephburgess/gust/internal/units"
 )
 
 type BaseRenderer struct {
 	Units string
+	// per-quantity overrides, empty fields follow Units
+	Display config.DisplayUnits
+}
+
+func (r *BaseRenderer) displayUnits() config.DisplayUnits {
+	return config.ResolveDisplayUnits(r.Units, r.Display)
 }
 
 func (r *BaseRenderer) GetTemperatureUnit() string {
-	switch r.Units {
-	case "imperial":
+	switch r.displayUnits().Temperature {
+	case "F":
 		return "°F"
-	case "metric":
-		return "°C"
-	default:
+	case "K":
 		return "K"
+	default:
+		return "°C"
 	}
 }
 
-func (r *BaseRenderer) FormatWindSpeed(speed float64) float64 {
-	switch r.Units {
-	case "imperial":
-		return speed
-	default:
-		return speed * 3.6
-	}
+// weather data is always metric, see api.CanonicalUnits
+func (r *BaseRenderer) ConvertTemperature(celsius float64) float64 {
+	return units.Temperature(celsius, r.displayUnits().Temperature)
+}
+
+func (r *BaseRenderer) FormatTemperature(celsius float64) string {
+	return fmt.Sprintf("%.1f%s", r.ConvertTemperature(celsius), r.GetTemperatureUnit())
+}
+
+func (r *BaseRenderer) FormatWindSpeed(metresPerSecond float64) float64 {
+	return units.WindSpeed(metresPerSecond, r.displayUnits().Wind)
 }
 
 func (r *BaseRenderer) GetWindSpeedUnit() string {
-	switch r.Units {
-	case "imperial":
-		return "mph"
+	if unit := r.displayUnits().Wind; unit != "bft" {
+		return unit
+	}
+	return "Bft"
+}
+
+// speed and unit, beaufort is a whole number on its scale
+func (r *BaseRenderer) FormatWind(speed float64) string {
+	if r.displayUnits().Wind == "bft" {
+		return fmt.Sprintf("%.0f Bft", r.FormatWindSpeed(speed))
+	}
+	return fmt.Sprintf("%.1f %s", r.FormatWindSpeed(speed), r.GetWindSpeedUnit())
+}
+
+func (r *BaseRenderer) ConvertPressure(hPa int) float64 {
+	return units.Pressure(float64(hPa), r.displayUnits().Pressure)
+}
+
+func (r *BaseRenderer) FormatPressure(hPa int) string {
+	switch unit := r.displayUnits().Pressure; unit {
+	case "inHg":
+		return fmt.Sprintf("%.2f %s", r.ConvertPressure(hPa), unit)
 	default:
-		return "km/h"
+		return fmt.Sprintf("%.0f %s", r.ConvertPressure(hPa), unit)
+	}
+}
+
+func (r *BaseRenderer) ConvertPrecipitation(mm float64) float64 {
+	return units.Precipitation(mm, r.displayUnits().Precipitation)
+}
+
+func (r *BaseRenderer) GetPrecipitationUnit() string {
+	return r.displayUnits().Precipitation
+}
+
+func (r *BaseRenderer) FormatPrecipitation(mm float64) string {
+	if r.GetPrecipitationUnit() == "in" {
+		return fmt.Sprintf("%.2f in", r.ConvertPrecipitation(mm))
+	}
+	return fmt.Sprintf("%.1f mm", r.ConvertPrecipitation(mm))
+}
+
+func (r *BaseRenderer) ConvertVisibility(meters int) float64 {
+	return units.Distance(float64(meters), r.displayUnits().Visibility)
+}
+
+func (r *BaseRenderer) FormatVisibility(meters int) string {
+	return fmt.Sprintf("%.1f %s", r.ConvertVisibility(meters), r.displayUnits().Visibility)
+}
+
+// a rating with the distance, e.g. "Good (6.0 km)"
+func (r *BaseRenderer) DescribeVisibility(meters int) string {
+	rating := i18n.T(models.VisibilityRating(meters))
+	switch {
+	case meters >= 10000 && r.displayUnits().Visibility == "mi":
+		return rating + " (6+ mi)"
+	case meters >= 10000:
+		return rating + " (10+ km)"
 	}
+	return fmt.Sprintf("%s (%s)", rating, r.FormatVisibility(meters))
 }
 
 func FormatDateTime(timestamp int64, format string) string {
 	return time.Unix(timestamp, 0).Format(format)
 }
+
+// times read in the forecast location's zone unless local time was asked for
+func DisplayZone(weather *models.OneCallResponse, cfg *config.Config) *time.Location {
+	if cfg != nil && cfg.LocalTime {
+		return time.Local
+	}
+	return weather.Location()
+}
+
+// the zone abbreviation, or the utc offset for zones without one (e.g. "+04")
+func ZoneLabel(t time.Time) string {
+	name, offset := t.Zone()
+	if name != "" && name[0] != '+' && name[0] != '-' {
+		return name
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	if minutes := offset % 3600 / 60; minutes != 0 {
+		return fmt.Sprintf("UTC%s%d:%02d", sign, offset/3600, minutes)
+	}
+	return fmt.Sprintf("UTC%s%d", sign, offset/3600)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
 	"github.com/josephburgess/gust/internal/ui/components"
 	"github.com/josephburgess/gust/internal/ui/styles"
@@ -35,18 +36,31 @@ const asciiLogo = `
      \__, /\__,_/____/\__/  💨🍃
     /____/                      `
 
-// setup ui styles
+// setup ui styles, rebuilt when the theme changes
 var (
-	titleStyle        = lipgloss.NewStyle().Bold(true).Foreground(styles.Rose)
-	boxStyle          = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(styles.Iris).Padding(0, 1, 0, 1)
-	logoBoxStyle      = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(styles.Subtle).Padding(0, 2, 2, 1).Foreground(styles.Foam)
-	subtitleStyle     = lipgloss.NewStyle().Foreground(styles.Gold)
-	highlightStyle    = lipgloss.NewStyle().Bold(true).Foreground(styles.Text)
-	cursorStyle       = lipgloss.NewStyle().Foreground(styles.Love)
-	selectedItemStyle = lipgloss.NewStyle().Foreground(styles.Foam)
-	hintStyle         = lipgloss.NewStyle().Foreground(styles.Subtle).Italic(true)
+	titleStyle        lipgloss.Style
+	boxStyle          lipgloss.Style
+	logoBoxStyle      lipgloss.Style
+	subtitleStyle     lipgloss.Style
+	highlightStyle    lipgloss.Style
+	cursorStyle       lipgloss.Style
+	selectedItemStyle lipgloss.Style
+	hintStyle         lipgloss.Style
 )
 
+func init() {
+	styles.OnApply(func() {
+		titleStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Rose)
+		boxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(styles.Iris).Padding(0, 1, 0, 1)
+		logoBoxStyle = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(styles.Subtle).Padding(0, 2, 2, 1).Foreground(styles.Foam)
+		subtitleStyle = lipgloss.NewStyle().Foreground(styles.Gold)
+		highlightStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Text)
+		cursorStyle = lipgloss.NewStyle().Foreground(styles.Love)
+		selectedItemStyle = lipgloss.NewStyle().Foreground(styles.Foam)
+		hintStyle = lipgloss.NewStyle().Foreground(styles.Subtle).Italic(true)
+	})
+}
+
 // current state of wizard
 type Model struct {
 	Config          *config.Config
@@ -55,7 +69,7 @@ type Model struct {
 	CitySearchQuery string
 	CityOptions     []models.City
 	CityCursor      int
-	Client          *api.Client
+	Client          api.Provider
 	UnitOptions     []string
 	UnitCursor      int
 	ViewOptions     []string
@@ -74,9 +88,9 @@ type Model struct {
 }
 
 // creates a new setup model
-func NewModel(cfg *config.Config, needsAuth bool, client *api.Client) Model {
+func NewModel(cfg *config.Config, needsAuth bool, client api.Provider) Model {
 	ti := textinput.New()
-	ti.Placeholder = "Wherever the wind blows..."
+	ti.Placeholder = i18n.T("Wherever the wind blows...")
 	ti.Focus()
 	ti.CharLimit = 50
 	ti.Width = len(ti.Placeholder)
@@ -85,7 +99,7 @@ func NewModel(cfg *config.Config, needsAuth bool, client *api.Client) Model {
 	ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.Gold)
 
 	apiKeyInput := textinput.New()
-	apiKeyInput.Placeholder = "Paste your OpenWeather API key here..."
+	apiKeyInput.Placeholder = i18n.T("Paste your OpenWeather API key here...")
 	apiKeyInput.CharLimit = 64
 	apiKeyInput.Width = len(apiKeyInput.Placeholder)
 	apiKeyInput.PromptStyle = lipgloss.NewStyle().Foreground(styles.Love)
@@ -120,26 +134,27 @@ func NewModel(cfg *config.Config, needsAuth bool, client *api.Client) Model {
 		CityOptions:     []models.City{},
 		CityCursor:      0,
 		Client:          client,
-		UnitOptions:     []string{"metric (°C, km/h) 🌡️", "imperial (°F, mph) 🌡️", "standard (K, m/s) 🌡️"},
+		UnitOptions:     []string{i18n.T("metric (°C, km/h) 🌡️"), i18n.T("imperial (°F, mph) 🌡️"), i18n.T("standard (K, m/s) 🌡️")},
 		UnitCursor:      unitCursor,
 		ViewOptions: []string{
-			"detailed 🌤️",
-			"compact 📊",
-			"5-day 📆",
-			"24-hour 🕒",
-			"full (current + 5-day + alerts) 📋",
+			i18n.T("detailed 🌤️"),
+			i18n.T("compact 📊"),
+			i18n.T("5-day 📆"),
+			i18n.T("24-hour 🕒"),
+			i18n.T("full (current + 5-day + alerts) 📋"),
 		},
 		ViewCursor:  viewCursor,
-		TipOptions:  []string{"Yes, show weather tips", "No, don't show tips"},
+		TipOptions:  []string{i18n.T("Yes, show weather tips"), i18n.T("No, don't show tips")},
 		TipCursor:   0,
-		AuthOptions: []string{"Yes, authenticate with GitHub 🔑", "No, I'll do it later ⏱️"},
+		AuthOptions: []string{i18n.T("Yes, authenticate with GitHub 🔑"), i18n.T("No, I'll do it later ⏱️")},
 		AuthCursor:  0,
 		NeedsAuth:   needsAuth,
 		Quitting:    false,
 		Spinner:     components.NewSpinner(),
 		ApiKeyOptions: []string{
-			"Use gust's authentication (recommended)",
-			"Use my own OpenWeatherMap API key",
+			i18n.T("Use gust's authentication (recommended)"),
+			i18n.T("Use my own OpenWeatherMap API key"),
+			i18n.T("Use Open-Meteo (free, no key needed)"),
 		},
 		ApiKeyCursor: 0,
 		ApiKeyInput:  apiKeyInput,
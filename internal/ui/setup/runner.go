@@ -6,22 +6,26 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 )
 
 // entry point for setup wizard
 func RunSetup(cfg *config.Config, needsAuth bool) error {
-	var apiClient *api.Client
-
 	if cfg.ApiUrl == "" {
 		cfg.ApiUrl = "https://breeze.joeburgess.dev"
 	}
 
+	// empty api key - dont need for setup
+	apiKey := ""
 	authConfig, err := config.LoadAuthConfig()
 	if err == nil && authConfig != nil {
-		apiClient = api.NewClient(cfg.ApiUrl, authConfig.APIKey, cfg.Units)
-	} else {
-		// empty api key - dont need for setup
-		apiClient = api.NewClient(cfg.ApiUrl, "", cfg.Units)
+		apiKey = authConfig.APIKey
+	}
+
+	apiClient, err := api.NewProvider(cfg.Provider, cfg.ApiUrl, apiKey, i18n.Language(), cfg.RequestTimeout())
+	if err != nil {
+		// city search works through breeze without a key
+		apiClient = api.NewClient(cfg.ApiUrl, "")
 	}
 
 	model := NewModel(cfg, needsAuth, apiClient)
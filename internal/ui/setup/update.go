@@ -6,7 +6,9 @@ import (
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/josephburgess/gust/internal/api"
 	"github.com/josephburgess/gust/internal/config"
+	"github.com/josephburgess/gust/internal/i18n"
 	"github.com/josephburgess/gust/internal/models"
 )
 
@@ -35,22 +37,7 @@ func (m Model) handleApiKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.Quitting = true
 		return m, tea.Quit
 	case "enter":
-		if m.ApiKeyInput.Value() != "" {
-			authConfig := &config.AuthConfig{
-				APIKey:     m.ApiKeyInput.Value(),
-				ServerURL:  m.Config.ApiUrl,
-				LastAuth:   time.Now(),
-				GithubUser: "OpenWeather API User",
-			}
-
-			if err := config.SaveAuthConfig(authConfig); err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				m.NeedsAuth = false
-			}
-		}
-		m.State = StateComplete
-		return m, nil
+		return m.saveApiKey(), nil
 	case "esc":
 		m.State = StateApiKeyOption
 		return m, nil
@@ -61,6 +48,30 @@ func (m Model) handleApiKeyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// own keys go straight to openweathermap rather than through breeze, but only once one is saved,
+// an empty or unsaved key keeps the wizard on this step
+func (m Model) saveApiKey() Model {
+	if m.ApiKeyInput.Value() == "" {
+		return m
+	}
+
+	authConfig := &config.AuthConfig{
+		APIKey:     m.ApiKeyInput.Value(),
+		ServerURL:  m.Config.ApiUrl,
+		LastAuth:   time.Now(),
+		GithubUser: "OpenWeather API User",
+	}
+	if err := config.SaveAuthConfig(authConfig); err != nil {
+		fmt.Println(i18n.T("Error: %v", err))
+		return m
+	}
+
+	m.Config.Provider = api.ProviderOpenWeatherMap
+	m.NeedsAuth = false
+	m.State = StateComplete
+	return m
+}
+
 // updates the model based on messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -82,7 +93,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case CitiesSearchResult:
 		if msg.err != nil {
-			fmt.Printf("Error searching cities: %v\n", msg.err)
+			fmt.Println(i18n.T("Error searching cities: %v", msg.err))
 			m.State = StateCity
 			return m, nil
 		}
@@ -91,7 +102,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.CityCursor = 0
 
 		if len(m.CityOptions) == 0 {
-			fmt.Println("No cities found. Please try a different search.")
+			fmt.Println(i18n.T("No cities found. Please try a different search."))
 			m.State = StateCity
 			return m, nil
 		}
@@ -155,6 +166,9 @@ func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 		if len(m.CityOptions) > 0 {
 			selectedCity := m.CityOptions[m.CityCursor]
 			m.Config.DefaultCity = selectedCity.Name
+			// keep the exact pick so "Springfield" doesn't resolve somewhere else later
+			m.Config.DefaultCoords = &models.Coordinates{Lat: selectedCity.Lat, Lon: selectedCity.Lon}
+			m.Config.DefaultCountry = selectedCity.Country
 			m.State = StateUnits
 		}
 
@@ -173,37 +187,29 @@ func (m Model) handleEnterKey() (tea.Model, tea.Cmd) {
 		m.State = StateApiKeyOption
 
 	case StateApiKeyOption:
-		if m.ApiKeyCursor == 0 {
+		switch m.ApiKeyCursor {
+		case 0:
+			m.Config.Provider = api.ProviderBreeze
 			if m.NeedsAuth {
 				m.State = StateAuth
 			} else {
 				m.State = StateComplete
 			}
-		} else {
+		case 1:
 			m.State = StateApiKeyInput
 			m.ApiKeyInput.Focus()
+		default:
+			m.Config.Provider = api.ProviderOpenMeteo
+			m.NeedsAuth = false
+			m.State = StateComplete
 		}
 
 	case StateApiKeyInput:
-		if m.ApiKeyInput.Value() != "" {
-			authConfig := &config.AuthConfig{
-				APIKey:     m.ApiKeyInput.Value(),
-				ServerURL:  m.Config.ApiUrl,
-				LastAuth:   time.Now(),
-				GithubUser: "",
-			}
-
-			if err := config.SaveAuthConfig(authConfig); err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				m.NeedsAuth = false
-			}
-		}
-		m.State = StateComplete
+		return m.saveApiKey(), nil
 
 	case StateAuth:
 		if err := m.Config.Save(); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			fmt.Println(i18n.T("Error: %v", err))
 		}
 
 		if m.AuthCursor == 0 {
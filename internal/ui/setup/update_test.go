@@ -301,6 +301,15 @@ func TestEnterKeyHandling(t *testing.T) {
 			},
 			expectedState: StateComplete,
 		},
+		{
+			name: "empty api key doesn't advance",
+			setupModel: func() Model {
+				m := NewModel(&config.Config{}, true, &api.Client{})
+				m.State = StateApiKeyInput
+				return m
+			},
+			expectedState: StateApiKeyInput,
+		},
 		{
 			name: "empty city input doesn't advance",
 			setupModel: func() Model {
@@ -323,6 +332,40 @@ func TestEnterKeyHandling(t *testing.T) {
 	}
 }
 
+func TestOwnApiKeyLeavesProviderUntilSaved(t *testing.T) {
+	m := NewModel(&config.Config{}, true, &api.Client{})
+	m.State = StateApiKeyOption
+	m.ApiKeyCursor = 1
+
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updatedModel.(Model)
+	assert.Equal(t, StateApiKeyInput, m.State)
+	assert.Empty(t, m.Config.Provider, "the provider shouldn't change before there's a key for it")
+
+	updatedModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updatedModel.(Model)
+	assert.Equal(t, StateApiKeyInput, m.State)
+	assert.Empty(t, m.Config.Provider)
+	assert.True(t, m.NeedsAuth)
+}
+
+func TestCitySelectStoresCoordinates(t *testing.T) {
+	m := NewModel(&config.Config{}, false, &api.Client{})
+	m.State = StateCitySelect
+	m.CityOptions = []models.City{
+		{Name: "Springfield", State: "Illinois", Lat: 39.8017, Lon: -89.6436},
+		{Name: "Springfield", State: "Missouri", Country: "US", Lat: 37.2153, Lon: -93.2982},
+	}
+	m.CityCursor = 1
+
+	updatedModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated := updatedModel.(Model)
+
+	assert.Equal(t, "Springfield", updated.Config.DefaultCity)
+	assert.Equal(t, &models.Coordinates{Lat: 37.2153, Lon: -93.2982}, updated.Config.DefaultCoords)
+	assert.Equal(t, "US", updated.Config.DefaultCountry)
+}
+
 func TestCitySearch(t *testing.T) {
 	t.Run("successful search creates command", func(t *testing.T) {
 		m := NewModel(&config.Config{}, false, &api.Client{})
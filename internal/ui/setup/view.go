@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/josephburgess/gust/internal/i18n"
+	"github.com/josephburgess/gust/internal/ui/components"
 )
 
 func ClearViewport(width, height int) string {
@@ -39,107 +41,88 @@ func (m Model) buildContent() string {
 	var sb strings.Builder
 
 	sb.WriteString(logoBoxStyle.Render(asciiLogo) + "\n\n")
-	sb.WriteString(subtitleStyle.Render("Simple terminal weather 🌤️") + "\n\n")
+	sb.WriteString(subtitleStyle.Render(i18n.T("Simple terminal weather 🌤️")) + "\n\n")
 
 	switch m.State {
 	case StateCity:
-		sb.WriteString(highlightStyle.Render("Enter a default city 🏙️") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Enter a default city 🏙️")) + "\n\n")
 		sb.WriteString(m.CityInput.View() + "\n\n")
-		sb.WriteString(hintStyle.Render("You can enter a country code too, but use a comma! (e.g. London,GB)"))
+		sb.WriteString(hintStyle.Render(i18n.T("You can enter a country code too, but use a comma! (e.g. London,GB)")))
 
 	case StateCitySearch:
-		sb.WriteString(highlightStyle.Render("Searching for cities...") + "\n\n")
-		sb.WriteString(fmt.Sprintf("%s Looking for \"%s\"", m.Spinner.View(), m.CitySearchQuery))
+		sb.WriteString(highlightStyle.Render(i18n.T("Searching for cities...")) + "\n\n")
+		sb.WriteString(m.Spinner.View() + " " + i18n.T("Looking for \"%s\"", m.CitySearchQuery))
 		sb.WriteString("\n\n")
 
 	case StateCitySelect:
-		sb.WriteString(highlightStyle.Render("Select your town or city: 🏙️") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Select your town or city: 🏙️")) + "\n\n")
 
 		if len(m.CityOptions) == 0 {
-			sb.WriteString("No cities found. Please try a different search term.\n\n")
+			sb.WriteString(i18n.T("No cities found. Please try a different search term.") + "\n\n")
 		} else {
-			for i, city := range m.CityOptions {
-				var line string
-				var locationInfo string
-
-				if city.State != "" && city.Country != "" {
-					flag := getCountryEmoji(city.Country)
-					locationInfo = fmt.Sprintf("%s, %s %s", city.State, city.Country, flag)
-				} else if city.Country != "" {
-					flag := getCountryEmoji(city.Country)
-					locationInfo = fmt.Sprintf("%s %s", flag, locationInfo)
-				} else {
-					locationInfo = fmt.Sprintf("(%.4f, %.4f)", city.Lat, city.Lon)
-				}
-
-				displayName := fmt.Sprintf("%s - %s", city.Name, locationInfo)
-
-				if m.CityCursor == i {
-					line = fmt.Sprintf("%s %s", cursorStyle.Render("→"), selectedItemStyle.Render(displayName))
-				} else {
-					line = fmt.Sprintf("  %s", displayName)
-				}
-				sb.WriteString(line + "\n")
-			}
+			sb.WriteString(components.RenderCityList(m.CityOptions, m.CityCursor))
 			sb.WriteString("\n")
 		}
 
-		sb.WriteString(hintStyle.Render("Press Enter to select or Esc to search again"))
+		sb.WriteString(hintStyle.Render(i18n.T("Press Enter to select or Esc to search again")))
 
 	case StateUnits:
-		sb.WriteString(highlightStyle.Render("Choose your preferred units: 🌡️") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Choose your preferred units: 🌡️")) + "\n\n")
 		sb.WriteString(m.renderOptions(m.UnitOptions, m.UnitCursor))
-		sb.WriteString("\n" + hintStyle.Render("Press Enter to confirm"))
+		sb.WriteString("\n" + hintStyle.Render(i18n.T("Press Enter to confirm")))
 
 	case StateView:
-		sb.WriteString(highlightStyle.Render("Choose your preferred view: 📊") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Choose your preferred view: 📊")) + "\n\n")
 		sb.WriteString(m.renderOptions(m.ViewOptions, m.ViewCursor))
-		sb.WriteString("\n" + hintStyle.Render("Press Enter to confirm"))
+		sb.WriteString("\n" + hintStyle.Render(i18n.T("Press Enter to confirm")))
 
 	case StateAuth:
-		sb.WriteString(highlightStyle.Render("GitHub Auth 🔒") + "\n\n")
-		sb.WriteString("To get weather data you need to authenticate with GitHub (don't worry, no permissions requested!).\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("GitHub Auth 🔒")) + "\n\n")
+		sb.WriteString(i18n.T("To get weather data you need to authenticate with GitHub (don't worry, no permissions requested!).") + "\n\n")
 		sb.WriteString(m.renderOptions(m.AuthOptions, m.AuthCursor))
-		sb.WriteString("\n" + hintStyle.Render("Press Enter to confirm your selection"))
+		sb.WriteString("\n" + hintStyle.Render(i18n.T("Press Enter to confirm your selection")))
 
 	case StateComplete:
-		sb.WriteString(highlightStyle.Render("✓ Setup complete! 🎉") + "\n\n")
-		sb.WriteString(fmt.Sprintf("Default city: %s 🏙️\n", m.Config.DefaultCity))
-		sb.WriteString(fmt.Sprintf("Units: %s 🌡️\n", m.Config.Units))
-		sb.WriteString(fmt.Sprintf("Default view: %s 📊\n", m.Config.DefaultView))
+		sb.WriteString(highlightStyle.Render(i18n.T("✓ Setup complete! 🎉")) + "\n\n")
+		sb.WriteString(i18n.T("Default city: %s 🏙️", m.Config.DefaultCity) + "\n")
+		sb.WriteString(i18n.T("Units: %s 🌡️", m.Config.Units) + "\n")
+		sb.WriteString(i18n.T("Default view: %s 📊", m.Config.DefaultView) + "\n")
+		if m.Config.Provider != "" {
+			sb.WriteString(i18n.T("Provider: %s 🛰️", m.Config.Provider) + "\n")
+		}
 		if m.Config.ShowTips {
-			sb.WriteString("Tips enabled 💡\n")
+			sb.WriteString(i18n.T("Tips enabled 💡") + "\n")
 		} else {
-			sb.WriteString("Tips disabled 💡\n")
+			sb.WriteString(i18n.T("Tips disabled 💡") + "\n")
 		}
 
 	case StateTips:
-		sb.WriteString(highlightStyle.Render("Would you like tips shown on daily forecasts? 💡") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Would you like tips shown on daily forecasts? 💡")) + "\n\n")
 		sb.WriteString(m.renderOptions(m.TipOptions, m.TipCursor))
-		sb.WriteString("\n" + hintStyle.Render("Press Enter to confirm"))
+		sb.WriteString("\n" + hintStyle.Render(i18n.T("Press Enter to confirm")))
 
 		if m.NeedsAuth {
-			authStatus := "Authenticated ✅"
+			authStatus := i18n.T("Authenticated ✅")
 			if m.AuthCursor == 1 {
-				authStatus = "Not authenticated ❌"
+				authStatus = i18n.T("Not authenticated ❌")
 			}
-			sb.WriteString(fmt.Sprintf("GitHub: %s\n", authStatus))
+			sb.WriteString(i18n.T("GitHub: %s", authStatus) + "\n")
 		}
 
 	case StateApiKeyOption:
-		sb.WriteString(highlightStyle.Render("Choose auth method: 🔑") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Choose auth method: 🔑")) + "\n\n")
 		sb.WriteString(m.renderOptions(m.ApiKeyOptions, m.ApiKeyCursor))
-		sb.WriteString("\n" + hintStyle.Render("Press Enter to confirm"))
+		sb.WriteString("\n" + hintStyle.Render(i18n.T("Press Enter to confirm")))
 
 	case StateApiKeyInput:
-		sb.WriteString(highlightStyle.Render("Enter your OpenWeatherMap API key: 🔑") + "\n\n")
+		sb.WriteString(highlightStyle.Render(i18n.T("Enter your OpenWeatherMap API key: 🔑")) + "\n\n")
 		sb.WriteString(m.ApiKeyInput.View() + "\n\n")
-		sb.WriteString(hintStyle.Render("Get your API key from https://home.openweathermap.org/subscriptions/unauth_subscribe/onecall_30/base"))
+		sb.WriteString(hintStyle.Render(i18n.T("Get your API key from https://home.openweathermap.org/subscriptions/unauth_subscribe/onecall_30/base")))
 
 	}
 
 	// footer
-	sb.WriteString("\n" + hintStyle.Render("↓j/↑k Navigate • Enter: Select • Ctrl + C: Quit"))
+	sb.WriteString("\n" + hintStyle.Render(i18n.T("↓j/↑k Navigate • Enter: Select • Ctrl + C: Quit")))
 
 	return sb.String()
 }
@@ -194,24 +177,6 @@ func (m Model) centerContent(content string) string {
 	return sb.String()
 }
 
-func getCountryEmoji(countryCode string) string {
-	if countryCode == "" {
-		return "🌍"
-	}
-
-	if len(countryCode) != 2 {
-		return "🌍"
-	}
-
-	cc := strings.ToUpper(countryCode)
-	const offset = 127397
-	firstLetter := rune(cc[0]) + offset
-	secondLetter := rune(cc[1]) + offset
-	flag := string(firstLetter) + string(secondLetter)
-
-	return flag
-}
-
 // Add helper functions to work with country names and emojis
 
 // GetCountryEmojiByName returns the flag emoji for a given country name
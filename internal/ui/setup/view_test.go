@@ -194,47 +194,6 @@ func TestView(t *testing.T) {
 	}
 }
 
-func TestCountryEmoji(t *testing.T) {
-	tests := []struct {
-		name        string
-		countryCode string
-		want        string
-	}{
-		{
-			name:        "valid country code",
-			countryCode: "GB",
-			want:        "🇬🇧",
-		},
-		{
-			name:        "empty country code",
-			countryCode: "",
-			want:        "🌍",
-		},
-		{
-			name:        "invalid length",
-			countryCode: "GBR",
-			want:        "🌍",
-		},
-		{
-			name:        "lowercase code",
-			countryCode: "fr",
-			want:        "🇫🇷",
-		},
-		{
-			name:        "single letter code",
-			countryCode: "X",
-			want:        "🌍",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := getCountryEmoji(tt.countryCode)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestRenderOptions(t *testing.T) {
 	model := NewModel(&config.Config{}, false, nil)
 	options := []string{"Option 1", "Option 2", "Option 3"}
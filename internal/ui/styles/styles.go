@@ -7,75 +7,161 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/fatih/color"
+	"github.com/josephburgess/gust/internal/redact"
+	"github.com/muesli/termenv"
 )
 
+// the current theme's colors, set by Apply
 var (
-	Base    = lipgloss.Color("#191724")
-	Surface = lipgloss.Color("#1f1d2e")
-	Overlay = lipgloss.Color("#26233a")
-	Muted   = lipgloss.Color("#6e6a86")
-	Subtle  = lipgloss.Color("#908caa")
-	Text    = lipgloss.Color("#e0def4")
-	Love    = lipgloss.Color("#eb6f92")
-	Gold    = lipgloss.Color("#f6c177")
-	Rose    = lipgloss.Color("#ebbcba")
-	Pine    = lipgloss.Color("#31748f")
-	Foam    = lipgloss.Color("#9ccfd8")
-	Iris    = lipgloss.Color("#c4a7e7")
+	Base      lipgloss.Color
+	Surface   lipgloss.Color
+	Overlay   lipgloss.Color
+	Highlight lipgloss.Color
+	Muted     lipgloss.Color
+	Subtle    lipgloss.Color
+	Text      lipgloss.Color
+	Love      lipgloss.Color
+	Gold      lipgloss.Color
+	Rose      lipgloss.Color
+	Pine      lipgloss.Color
+	Foam      lipgloss.Color
+	Iris      lipgloss.Color
 )
 
 var (
+	TitleStyle           lipgloss.Style
+	SubtitleStyle        lipgloss.Style
+	HighlightStyle       lipgloss.Style
+	CursorStyle          lipgloss.Style
+	SelectedItemStyle    lipgloss.Style
+	HintStyle            lipgloss.Style
+	BoxStyle             lipgloss.Style
+	ProgressMessageStyle lipgloss.Style
+)
+
+var (
+	HeaderStyle     func(a ...any) string
+	TipStyle        func(a ...any) string
+	TempStyle       func(a ...any) string
+	HighlightStyleF func(a ...any) string
+	InfoStyle       func(a ...any) string
+	TimeStyle       func(a ...any) string
+	AlertStyle      func(a ...any) string
+	ErrorStyle      func(a ...any) string
+	SuccessStyle    func(a ...any) string
+	WarningStyle    func(a ...any) string
+)
+
+var (
+	current  Theme
+	rebuilds []func()
+)
+
+func init() {
+	Apply(Themes[DefaultTheme])
+}
+
+// Apply switches every style, here and in the packages registered with OnApply, to theme
+func Apply(theme Theme) {
+	current = theme
+
+	Base = lipgloss.Color(theme.Base)
+	Surface = lipgloss.Color(theme.Surface)
+	Overlay = lipgloss.Color(theme.Overlay)
+	Highlight = lipgloss.Color(theme.Highlight)
+	Muted = lipgloss.Color(theme.Muted)
+	Subtle = lipgloss.Color(theme.Subtle)
+	Text = lipgloss.Color(theme.Text)
+	Love = lipgloss.Color(theme.Love)
+	Gold = lipgloss.Color(theme.Gold)
+	Rose = lipgloss.Color(theme.Rose)
+	Pine = lipgloss.Color(theme.Pine)
+	Foam = lipgloss.Color(theme.Foam)
+	Iris = lipgloss.Color(theme.Iris)
+
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Rose)
+		Bold(true).
+		Foreground(Rose)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(Gold)
+		Foreground(Gold)
 
 	HighlightStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Text)
+		Bold(true).
+		Foreground(Text)
 
 	CursorStyle = lipgloss.NewStyle().
-			Foreground(Love)
+		Foreground(Love)
 
 	SelectedItemStyle = lipgloss.NewStyle().
-				Foreground(Foam)
+		Foreground(Foam)
 
 	HintStyle = lipgloss.NewStyle().
-			Foreground(Subtle).
-			Italic(true)
+		Foreground(Subtle).
+		Italic(true)
 
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Iris).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Iris).
+		Padding(1, 2)
 
 	ProgressMessageStyle = lipgloss.NewStyle().
-				Foreground(Foam).
-				Italic(true)
-)
+		Foreground(Foam).
+		Italic(true)
 
-var (
-	HeaderStyle     = color.New(color.FgHiCyan, color.Bold).SprintFunc()
-	TipStyle        = color.New(color.FgHiBlue, color.Italic).SprintFunc()
-	TempStyle       = color.New(color.FgHiYellow, color.Bold).SprintFunc()
-	HighlightStyleF = color.New(color.FgHiWhite).SprintFunc()
-	InfoStyle       = color.New(color.FgHiBlue).SprintFunc()
-	TimeStyle       = color.New(color.FgHiYellow).SprintFunc()
-	AlertStyle      = color.New(color.FgHiRed, color.Bold).SprintFunc()
-	ErrorStyle      = color.New(color.FgHiRed, color.Bold).SprintFunc()
-	SuccessStyle    = color.New(color.FgHiGreen, color.Bold).SprintFunc()
-	WarningStyle    = color.New(color.FgHiYellow).SprintFunc()
-)
+	HeaderStyle = sprintFunc(lipgloss.NewStyle().Foreground(Foam).Bold(true))
+	TipStyle = sprintFunc(lipgloss.NewStyle().Foreground(Iris).Italic(true))
+	TempStyle = sprintFunc(lipgloss.NewStyle().Foreground(Gold).Bold(true))
+	HighlightStyleF = sprintFunc(lipgloss.NewStyle().Foreground(Text))
+	InfoStyle = sprintFunc(lipgloss.NewStyle().Foreground(Iris))
+	TimeStyle = sprintFunc(lipgloss.NewStyle().Foreground(Gold))
+	AlertStyle = sprintFunc(lipgloss.NewStyle().Foreground(Love).Bold(true))
+	ErrorStyle = sprintFunc(lipgloss.NewStyle().Foreground(Love).Bold(true))
+	SuccessStyle = sprintFunc(lipgloss.NewStyle().Foreground(Pine).Bold(true))
+	WarningStyle = sprintFunc(lipgloss.NewStyle().Foreground(Gold))
+
+	for _, rebuild := range rebuilds {
+		rebuild()
+	}
+}
+
+// strips color and text styles like bold from everything rendered from now on
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// the theme last passed to Apply
+func Current() Theme {
+	return current
+}
+
+// OnApply registers rebuild to run whenever the theme changes, for packages with
+// styles of their own. It runs straight away too
+func OnApply(rebuild func()) {
+	rebuilds = append(rebuilds, rebuild)
+	rebuild()
+}
+
+// styles each line on its own, lipgloss would otherwise pad multi-line text into a block
+func sprintFunc(style lipgloss.Style) func(a ...any) string {
+	style = style.TabWidth(lipgloss.NoTabConversion)
+	return func(a ...any) string {
+		lines := strings.Split(fmt.Sprint(a...), "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = style.Render(line)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+}
 
 func Divider(len int) string {
 	return strings.Repeat("─", len)
 }
 
 func ExitWithError(message string, err error) {
-	log.Printf("%s: %v", message, err)
+	log.Printf("%s: %v", message, redact.Error(err))
 	os.Exit(1)
 }
 
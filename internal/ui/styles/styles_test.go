@@ -1,8 +1,12 @@
 package styles
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestDivider(t *testing.T) {
@@ -53,3 +57,67 @@ func TestStyleFunctions(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "neon.json"), []byte(`{"foam": "#00ff00", "text": "15"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"foam": `), 0644)
+
+	theme, err := LoadTheme("", dir)
+	if err != nil || theme != Themes[DefaultTheme] {
+		t.Errorf("Expected an empty name to load the default theme, got %+v (%v)", theme, err)
+	}
+
+	theme, err = LoadTheme("rose-pine-dawn", dir)
+	if err != nil || theme.Base != "#faf4ed" {
+		t.Errorf("Expected the built in dawn theme, got %+v (%v)", theme, err)
+	}
+
+	theme, err = LoadTheme("neon", dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if theme.Foam != "#00ff00" || theme.Text != "15" {
+		t.Errorf("Expected the colors from the file, got %+v", theme)
+	}
+	if theme.Love != Themes[DefaultTheme].Love {
+		t.Errorf("Expected missing colors to come from the default theme, got %q", theme.Love)
+	}
+
+	if _, err := LoadTheme("broken", dir); err == nil {
+		t.Error("Expected an error for a theme file that isn't valid json")
+	}
+
+	if _, err := LoadTheme("missing", dir); err == nil || !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("Expected an error naming the missing file, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Cleanup(func() { Apply(Themes[DefaultTheme]) })
+
+	var rebuilt lipgloss.Color
+	OnApply(func() { rebuilt = Foam })
+
+	Apply(Themes["solarized"])
+
+	if Foam != lipgloss.Color("#2aa198") || Current() != Themes["solarized"] {
+		t.Errorf("Expected the solarized palette, got foam %q", Foam)
+	}
+	if rebuilt != Foam {
+		t.Errorf("Expected registered styles to be rebuilt, got %q", rebuilt)
+	}
+}
+
+func TestThemeNames(t *testing.T) {
+	names := ThemeNames()
+	if len(names) != len(Themes) || names[0] != DefaultTheme {
+		t.Errorf("Expected every theme with the default first, got %v", names)
+	}
+}
+
+func TestStyleFunctionsKeepLines(t *testing.T) {
+	if got := InfoStyle("a\n\tb"); got != "a\n\tb" {
+		t.Errorf("Expected lines and tabs to be left alone without a color terminal, got %q", got)
+	}
+}
@@ -0,0 +1,103 @@
+package styles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const DefaultTheme = "rose-pine"
+
+// colors are named after the rose pine roles they replace, any lipgloss color works,
+// e.g. "#eb6f92" or an ANSI number like "9", and empty leaves the terminal's own color
+type Theme struct {
+	// background of the active dashboard tab's text
+	Base string `json:"base"`
+	// html panels
+	Surface string `json:"surface"`
+	// html code blocks
+	Overlay   string `json:"overlay"`
+	Highlight string `json:"highlight"`
+	Muted     string `json:"muted"`
+	// hints, labels and borders
+	Subtle string `json:"subtle"`
+	Text   string `json:"text"`
+	// cursors, alerts and errors
+	Love string `json:"love"`
+	// temperatures, times and warnings
+	Gold string `json:"gold"`
+	// titles
+	Rose string `json:"rose"`
+	// success messages
+	Pine string `json:"pine"`
+	// headers, selections and the spinner
+	Foam string `json:"foam"`
+	// boxes and info text
+	Iris string `json:"iris"`
+}
+
+var Themes = map[string]Theme{
+	"rose-pine": {
+		Base: "#191724", Surface: "#1f1d2e", Overlay: "#26233a", Highlight: "#403d52",
+		Muted: "#6e6a86", Subtle: "#908caa", Text: "#e0def4",
+		Love: "#eb6f92", Gold: "#f6c177", Rose: "#ebbcba", Pine: "#31748f", Foam: "#9ccfd8", Iris: "#c4a7e7",
+	},
+	// for light terminals
+	"rose-pine-dawn": {
+		Base: "#faf4ed", Surface: "#fffaf3", Overlay: "#f2e9e1", Highlight: "#dfdad9",
+		Muted: "#9893a5", Subtle: "#797593", Text: "#575279",
+		Love: "#b4637a", Gold: "#ea9d34", Rose: "#d7827e", Pine: "#286983", Foam: "#56949f", Iris: "#907aa9",
+	},
+	"solarized": {
+		Base: "#002b36", Surface: "#073642", Overlay: "#073642", Highlight: "#586e75",
+		Muted: "#586e75", Subtle: "#839496", Text: "#93a1a1",
+		Love: "#dc322f", Gold: "#b58900", Rose: "#d33682", Pine: "#859900", Foam: "#2aa198", Iris: "#268bd2",
+	},
+	// the terminal's own colors, readable on any background
+	"monochrome": {},
+	"high-contrast": {
+		Base: "#000000", Surface: "#000000", Overlay: "#1c1c1c", Highlight: "#ffffff",
+		Muted: "#bcbcbc", Subtle: "#e4e4e4", Text: "#ffffff",
+		Love: "#ff5f5f", Gold: "#ffff00", Rose: "#ff87ff", Pine: "#00ff00", Foam: "#00ffff", Iris: "#87afff",
+	},
+}
+
+// built in theme names, the default first
+func ThemeNames() []string {
+	names := []string{DefaultTheme}
+	for name := range Themes {
+		if name != DefaultTheme {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// LoadTheme finds a built in theme or reads <name>.json from dir.
+// Colors a theme file leaves out come from the default theme
+func LoadTheme(name, dir string) (Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	if theme, ok := Themes[name]; ok {
+		return theme, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Theme{}, fmt.Errorf("unknown theme %q, use one of %v or add %s.json to %s", name, ThemeNames(), name, dir)
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme %q: %w", name, err)
+	}
+
+	theme := Themes[DefaultTheme]
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme %q: %w", name, err)
+	}
+	return theme, nil
+}
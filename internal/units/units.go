@@ -0,0 +1,101 @@
+// Package units converts the metric data every provider is asked for into display units.
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// accepted display units per quantity, the first of each is the metric default
+var (
+	Temperatures   = []string{"C", "F", "K"}
+	WindSpeeds     = []string{"km/h", "m/s", "mph", "kn", "bft"}
+	Pressures      = []string{"hPa", "inHg", "mmHg"}
+	Precipitations = []string{"mm", "in"}
+	Distances      = []string{"km", "mi"}
+)
+
+// per metre per second
+var windFactors = map[string]float64{
+	"m/s":  1,
+	"km/h": 3.6,
+	"mph":  2.2369362920544,
+	"kn":   1.9438444924406,
+}
+
+// upper bound in m/s of each beaufort force, anything above the last is 12
+var beaufortLimits = []float64{0.5, 1.6, 3.4, 5.5, 8.0, 10.8, 13.9, 17.2, 20.8, 24.5, 28.5, 32.7}
+
+func Temperature(celsius float64, to string) float64 {
+	switch to {
+	case "F":
+		return celsius*9/5 + 32
+	case "K":
+		return celsius + 273.15
+	default:
+		return celsius
+	}
+}
+
+// beaufort gives the force as a whole number
+func WindSpeed(metresPerSecond float64, to string) float64 {
+	if to == "bft" {
+		return float64(Beaufort(metresPerSecond))
+	}
+	if factor, ok := windFactors[to]; ok {
+		return metresPerSecond * factor
+	}
+	return metresPerSecond
+}
+
+func Beaufort(metresPerSecond float64) int {
+	for force, limit := range beaufortLimits {
+		if metresPerSecond < limit {
+			return force
+		}
+	}
+	return len(beaufortLimits)
+}
+
+func Pressure(hPa float64, to string) float64 {
+	switch to {
+	case "inHg":
+		return hPa * 0.0295299830714
+	case "mmHg":
+		return hPa * 0.750061683
+	default:
+		return hPa
+	}
+}
+
+func Precipitation(mm float64, to string) float64 {
+	if to == "in" {
+		return mm / 25.4
+	}
+	return mm
+}
+
+// visibility, reported in metres
+func Distance(metres float64, to string) float64 {
+	if to == "mi" {
+		return metres / 1609.344
+	}
+	return metres / 1000
+}
+
+// matches case-insensitively and returns the canonical spelling, "knots" and "beaufort" are accepted too
+func Normalize(options []string, value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "knots":
+		value = "kn"
+	case "beaufort":
+		value = "bft"
+	}
+
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option, nil
+		}
+	}
+	return "", fmt.Errorf("invalid unit %q, must be one of: %s", value, strings.Join(options, ", "))
+}
@@ -0,0 +1,86 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConversions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"celsius", Temperature(20, "C"), 20},
+		{"fahrenheit", Temperature(20, "F"), 68},
+		{"fahrenheit below zero", Temperature(-40, "F"), -40},
+		{"kelvin", Temperature(0, "K"), 273.15},
+		{"m/s", WindSpeed(10, "m/s"), 10},
+		{"km/h", WindSpeed(10, "km/h"), 36},
+		{"mph", WindSpeed(10, "mph"), 22.369},
+		{"knots", WindSpeed(10, "kn"), 19.438},
+		{"beaufort", WindSpeed(18, "bft"), 8},
+		{"hPa", Pressure(1013.25, "hPa"), 1013.25},
+		{"inHg", Pressure(1013.25, "inHg"), 29.921},
+		{"mmHg", Pressure(1013.25, "mmHg"), 760},
+		{"mm", Precipitation(12.7, "mm"), 12.7},
+		{"inches", Precipitation(25.4, "in"), 1},
+		{"km", Distance(2500, "km"), 2.5},
+		{"miles", Distance(1609.344, "mi"), 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if math.Abs(tc.got-tc.expected) > 0.001 {
+				t.Errorf("got %v, want %v", tc.got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBeaufort(t *testing.T) {
+	testCases := []struct {
+		metresPerSecond float64
+		expected        int
+	}{
+		{0, 0},
+		{0.5, 1},
+		{3.3, 2},
+		{5.5, 4},
+		{13.8, 6},
+		{17.2, 8},
+		{32.6, 11},
+		{40, 12},
+	}
+
+	for _, tc := range testCases {
+		if got := Beaufort(tc.metresPerSecond); got != tc.expected {
+			t.Errorf("Beaufort(%v) = %d, want %d", tc.metresPerSecond, got, tc.expected)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		options  []string
+		value    string
+		expected string
+	}{
+		{Temperatures, "f", "F"},
+		{WindSpeeds, "KM/H", "km/h"},
+		{WindSpeeds, "knots", "kn"},
+		{WindSpeeds, "Beaufort", "bft"},
+		{Pressures, "inhg", "inHg"},
+	}
+
+	for _, tc := range testCases {
+		got, err := Normalize(tc.options, tc.value)
+		if err != nil || got != tc.expected {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", tc.value, got, err, tc.expected)
+		}
+	}
+
+	if _, err := Normalize(Precipitations, "cm"); err == nil {
+		t.Error("Expected an error for an unknown unit")
+	}
+}
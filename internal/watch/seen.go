@@ -0,0 +1,148 @@
+// Package watch remembers which weather alerts and rule matches have already been announced,
+// so `gust watch` only notifies about new ones, even across restarts.
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/josephburgess/gust/internal/models"
+)
+
+// how long an alert without an end time is remembered
+const openEndedAlertLifetime = 48 * time.Hour
+
+// key -> when it can be forgotten, as a unix timestamp
+type Seen struct {
+	Alerts map[string]int64 `json:"alerts"`
+}
+
+type GetStatePathFunc func() (string, error)
+
+// for tests
+var GetStatePath GetStatePathFunc = defaultGetStatePath
+
+func defaultGetStatePath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user cache directory: %w", err)
+	}
+
+	dir := filepath.Join(cacheDir, "gust")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create cache directory: %w", err)
+	}
+
+	return filepath.Join(dir, "seen_alerts.json"), nil
+}
+
+// an alert is the same alert while its sender, event and start don't change
+func Key(location string, alert models.Alert) string {
+	return fmt.Sprintf("%s|%s|%s|%d", location, alert.SenderName, alert.Event, alert.Start)
+}
+
+// a rule is announced at most once a day per location, by the day it first matches
+func RuleKey(location, rule string, day time.Time) string {
+	return fmt.Sprintf("rule|%s|%s|%s", location, rule, day.Format("2006-01-02"))
+}
+
+// a missing state file means nothing has been seen yet
+func Load() (*Seen, error) {
+	seen := &Seen{Alerts: map[string]int64{}}
+
+	path, err := GetStatePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return seen, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read seen alerts: %w", err)
+	}
+
+	if err := json.Unmarshal(data, seen); err != nil {
+		return nil, fmt.Errorf("could not decode seen alerts: %w", err)
+	}
+	if seen.Alerts == nil {
+		seen.Alerts = map[string]int64{}
+	}
+	return seen, nil
+}
+
+func (s *Seen) Save() error {
+	path, err := GetStatePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode seen alerts: %w", err)
+	}
+
+	// write then rename, a crash halfway through mustn't forget every alert and announce them again
+	tmp, err := os.CreateTemp(filepath.Dir(path), "seen-*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not write seen alerts: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write seen alerts: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write seen alerts: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not write seen alerts: %w", err)
+	}
+	return nil
+}
+
+// alerts for location that are still running and haven't been marked seen
+func (s *Seen) Unseen(location string, alerts []models.Alert, now time.Time) []models.Alert {
+	var unseen []models.Alert
+	for _, alert := range alerts {
+		if alert.End > 0 && alert.End < now.Unix() {
+			continue
+		}
+		if !s.Has(Key(location, alert)) {
+			unseen = append(unseen, alert)
+		}
+	}
+	return unseen
+}
+
+func (s *Seen) MarkSeen(location string, alert models.Alert) {
+	expires := alert.End
+	if expires == 0 {
+		expires = max(alert.Start, time.Now().Unix()) + int64(openEndedAlertLifetime.Seconds())
+	}
+	s.Mark(Key(location, alert), expires)
+}
+
+func (s *Seen) Has(key string) bool {
+	_, ok := s.Alerts[key]
+	return ok
+}
+
+func (s *Seen) Mark(key string, expires int64) {
+	s.Alerts[key] = expires
+}
+
+// forgets alerts that have ended so the state file doesn't grow forever
+func (s *Seen) Prune(now time.Time) {
+	for key, expires := range s.Alerts {
+		if expires < now.Unix() {
+			delete(s.Alerts, key)
+		}
+	}
+}
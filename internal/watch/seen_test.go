@@ -0,0 +1,74 @@
+package watch
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/josephburgess/gust/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTempStatePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen_alerts.json")
+	original := GetStatePath
+	t.Cleanup(func() { GetStatePath = original })
+	GetStatePath = func() (string, error) {
+		return path, nil
+	}
+}
+
+func TestUnseen(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	flood := models.Alert{SenderName: "Met Office", Event: "Flood Warning", Start: now.Unix() - 3600, End: now.Unix() + 3600}
+	wind := models.Alert{SenderName: "Met Office", Event: "Wind Warning", Start: now.Unix(), End: now.Unix() + 7200}
+	ended := models.Alert{SenderName: "Met Office", Event: "Fog Advisory", Start: now.Unix() - 7200, End: now.Unix() - 60}
+
+	seen := &Seen{Alerts: map[string]int64{}}
+	assert.Equal(t, []models.Alert{flood, wind}, seen.Unseen("@home", []models.Alert{flood, wind, ended}, now))
+
+	seen.MarkSeen("@home", flood)
+	assert.Equal(t, []models.Alert{wind}, seen.Unseen("@home", []models.Alert{flood, wind}, now))
+
+	// the same alert somewhere else is news there
+	assert.Equal(t, []models.Alert{flood}, seen.Unseen("@work", []models.Alert{flood}, now))
+
+	// a reissued alert with a new start is a new alert
+	reissued := flood
+	reissued.Start = now.Unix()
+	assert.Equal(t, []models.Alert{reissued}, seen.Unseen("@home", []models.Alert{reissued}, now))
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Now()
+	seen := &Seen{Alerts: map[string]int64{}}
+	seen.MarkSeen("@home", models.Alert{Event: "Old", End: now.Unix() - 1})
+	seen.MarkSeen("@home", models.Alert{Event: "Current", End: now.Unix() + 3600})
+	seen.MarkSeen("@home", models.Alert{Event: "Open ended", Start: now.Unix()})
+
+	seen.Prune(now)
+	assert.Len(t, seen.Alerts, 2)
+	assert.NotContains(t, seen.Alerts, Key("@home", models.Alert{Event: "Old", End: now.Unix() - 1}))
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	useTempStatePath(t)
+
+	seen, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, seen.Alerts)
+
+	alert := models.Alert{SenderName: "NWS", Event: "Tornado Warning", Start: 100, End: time.Now().Unix() + 600}
+	seen.MarkSeen("@home", alert)
+	require.NoError(t, seen.Save())
+
+	loaded, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Unseen("@home", []models.Alert{alert}, time.Now()))
+
+	path, err := GetStatePath()
+	require.NoError(t, err)
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	assert.Empty(t, leftovers, "the temporary file should have been renamed into place")
+}